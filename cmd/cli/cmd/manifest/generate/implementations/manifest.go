@@ -0,0 +1,109 @@
+package implementations
+
+import (
+	"strings"
+
+	"capact.io/capact/cmd/cli/cmd/manifest/generate/common"
+	"capact.io/capact/internal/cli/manifestgen"
+
+	"capact.io/capact/internal/cli"
+	"capact.io/capact/internal/cli/heredoc"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewManifest returns a cobra.Command to bootstrap Kubernetes manifests or kustomization based manifests.
+func NewManifest() *cobra.Command {
+	var manifestCfg manifestgen.ManifestRunnerConfig
+
+	cmd := &cobra.Command{
+		Use:   "manifest [MANIFEST_PATH] [SOURCE_URL]",
+		Short: "Generate Kubernetes manifests based manifests",
+		Long:  "Generate Implementation manifests based on plain Kubernetes manifests or a Kustomize overlay",
+		Example: heredoc.WithCLIName(`
+		# Generate Implementation manifests for a kustomization stored in a Git repository
+		<cli> manifest generate implementation manifest cap.implementation.guestbook.install git::https://github.com/example/guestbook.git//overlays/prod`, cli.Name),
+
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("accepts two arguments: [MANIFEST_PATH] [SOURCE_URL]")
+			}
+
+			path := args[0]
+			if !strings.HasPrefix(path, "cap.implementation.") || len(strings.Split(path, ".")) < 4 {
+				return errors.New(`manifest path must be in format "cap.implementation.[PREFIX].[NAME]"`)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestCfg.ManifestRef.Path = args[0]
+			manifestCfg.SourceURL = args[1]
+			manifestCfg.Metadata = common.GetDefaultImplementationMetadata()
+
+			manifests, err := manifestgen.GenerateManifestRunnerManifests(&manifestCfg)
+			if err != nil {
+				return errors.Wrap(err, "while generating Kubernetes manifests based manifests")
+			}
+
+			outputDir, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return errors.Wrap(err, "while reading output flag")
+			}
+
+			overrideManifests, err := cmd.Flags().GetBool("overwrite")
+			if err != nil {
+				return errors.Wrap(err, "while reading overwrite flag")
+			}
+
+			if err := manifestgen.WriteManifestFiles(outputDir, manifests, overrideManifests); err != nil {
+				return errors.Wrap(err, "while writing manifest files")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&manifestCfg.InterfacePathWithRevision, "interface", "i", "", "Path with revision of the Interface, which is implemented by this Implementation")
+	cmd.Flags().StringVarP(&manifestCfg.ManifestRef.Revision, "revision", "r", "0.1.0", "Revision of the Implementation manifest")
+
+	return cmd
+}
+
+func generateManifestRunnerManifests(opts common.ManifestGenOptions) (manifestgen.ManifestCollection, error) {
+	var source string
+	prompt := &survey.Input{
+		Message: "Location of the Kubernetes manifests or kustomization, such as URL to Tarball or Git repository",
+	}
+	if err := survey.AskOne(prompt, &source, survey.WithValidator(survey.Required)); err != nil {
+		return nil, errors.Wrap(err, "while asking for source of Kubernetes manifests")
+	}
+
+	manifestCfg := manifestgen.ManifestRunnerConfig{
+		ImplementationConfig: manifestgen.ImplementationConfig{
+			Config: manifestgen.Config{
+				ManifestRef: types.ManifestRef{
+					Path:     common.CreateManifestPath(types.ImplementationManifestKind, opts.ManifestPath),
+					Revision: opts.Revision,
+				},
+			},
+			Metadata: types.ImplementationMetadata{
+				DocumentationURL: opts.Metadata.DocumentationURL,
+				SupportURL:       opts.Metadata.SupportURL,
+				IconURL:          opts.Metadata.IconURL,
+				Maintainers:      opts.Metadata.Maintainers,
+				License:          opts.Metadata.License,
+			},
+			InterfacePathWithRevision: opts.InterfacePath,
+		},
+		SourceURL: source,
+	}
+
+	files, err := manifestgen.GenerateManifestRunnerManifests(&manifestCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while generating Kubernetes manifests based manifests")
+	}
+	return files, nil
+}
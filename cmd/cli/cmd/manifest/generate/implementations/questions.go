@@ -19,7 +19,7 @@ func askForImplementationTool() (string, error) {
 	var selectedTool string
 	var options []string
 
-	availableTool := []implGeneratorType{helmTool, terraformTool, emptyManifest}
+	availableTool := []implGeneratorType{helmTool, terraformTool, manifestTool, emptyManifest}
 	for _, tool := range availableTool {
 		options = append(options, string(tool))
 	}
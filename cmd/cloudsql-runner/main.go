@@ -4,9 +4,9 @@ import (
 	"context"
 	"log"
 
+	statusreporter "capact.io/capact/internal/k8s-engine/status-reporter"
 	"capact.io/capact/pkg/runner"
 	"capact.io/capact/pkg/runner/cloudsql"
-	statusreporter "capact.io/capact/pkg/runner/status-reporter"
 
 	"github.com/vrischmann/envconfig"
 	"google.golang.org/api/option"
@@ -18,6 +18,8 @@ import (
 type Config struct {
 	GCP    cloudsql.GCPConfig
 	Output cloudsql.OutputConfig
+	// InstanceTypeInstanceFilepath is a path to the CloudSQL instance TypeInstance used by the patch and delete commands.
+	InstanceTypeInstanceFilepath string `envconfig:"optional"`
 }
 
 func main() {
@@ -31,9 +33,13 @@ func main() {
 	service, err := sqladmin.NewService(context.Background(), option.WithCredentials(gcpCreds))
 	exitOnError(err, "failed to create GCP service client")
 
-	cloudsqlRunner := cloudsql.NewRunner(cfg.Output, service, gcpCreds.ProjectID)
+	cloudsqlRunner := cloudsql.NewRunner(cloudsql.Config{
+		InstanceTypeInstanceFilepath: cfg.InstanceTypeInstanceFilepath,
+		Output:                       cfg.Output,
+	}, service, gcpCreds.ProjectID)
 
-	statusReporter := statusreporter.NewNoop()
+	statusReporter, err := statusreporter.NewK8sSecretProgressOrNoop()
+	exitOnError(err, "while creating status reporter")
 
 	mgr, err := runner.NewManager(cloudsqlRunner, statusReporter)
 	exitOnError(err, "failed to create manager")
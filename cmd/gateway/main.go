@@ -1,11 +1,11 @@
 package main
 
 import (
-	"encoding/json"
 	"log"
 	"net/http"
 	"time"
 
+	"capact.io/capact/internal/gateway/auth"
 	"capact.io/capact/internal/gateway/header"
 	"capact.io/capact/internal/healthz"
 	"capact.io/capact/internal/logger"
@@ -35,14 +35,8 @@ type Config struct {
 	// Introspection holds configuration parameters related to GraphQL schema introspection.
 	Introspection IntrospectionConfig
 
-	// Auth holds configuration parameters for user authentication
-	Auth BasicAuth
-}
-
-// BasicAuth holds the credentials for HTTP basic access authentication.
-type BasicAuth struct {
-	Username string `envconfig:"default=graphql"`
-	Password string
+	// Auth holds configuration parameters for user authentication and authorization.
+	Auth auth.Config
 }
 
 // IntrospectionConfig holds configuration parameters related to GraphQL schema introspection.
@@ -83,7 +77,10 @@ func main() {
 	schemas, err := introspectGraphQLSchemas(logger, cfg.Introspection)
 	exitOnError(err, "while introspecting GraphQL schemas")
 
-	gqlServer, err := setupGatewayServerFromSchemas(logger, schemas, cfg.Auth, cfg.GraphQLAddr)
+	authMiddleware, err := auth.NewMiddlewareFromConfig(logger.Named("auth"), cfg.Auth)
+	exitOnError(err, "while creating auth middleware")
+
+	gqlServer, err := setupGatewayServerFromSchemas(logger, schemas, authMiddleware, cfg.GraphQLAddr)
 	exitOnError(err, "while gateway setup")
 
 	parallelServers.Go(func() error { return gqlServer.Start(ctx) })
@@ -122,7 +119,7 @@ func introspectGraphQLSchemas(log *zap.Logger, cfg IntrospectionConfig) ([]*grap
 	return schemas, nil
 }
 
-func setupGatewayServerFromSchemas(log *zap.Logger, schemas []*graphql.RemoteSchema, authCfg BasicAuth, addr string) (httputil.StartableServer, error) {
+func setupGatewayServerFromSchemas(log *zap.Logger, schemas []*graphql.RemoteSchema, authMiddleware *auth.Middleware, addr string) (httputil.StartableServer, error) {
 	log.Info("Setting up gateway GraphQL server")
 
 	headerMiddleware := header.Middleware{}
@@ -139,13 +136,12 @@ func setupGatewayServerFromSchemas(log *zap.Logger, schemas []*graphql.RemoteSch
 	router := mux.NewRouter()
 	// TODO: Remove redirect after https://github.com/nautilus/gateway/issues/120
 	router.Handle("/", http.RedirectHandler("/graphql", http.StatusTemporaryRedirect)).Methods(http.MethodGet)
-	// TODO: Replace with proper authentication mechanism
-	gatewayHandler := withBasicAuth(log, authCfg,
+	gatewayHandler := authMiddleware.Handle(
 		headerMiddleware.StoreInCtx(
 			http.HandlerFunc(gw.PlaygroundHandler),
 		),
 	)
-	router.HandleFunc("/graphql", gatewayHandler).Methods(http.MethodGet, http.MethodPost)
+	router.Handle("/graphql", gatewayHandler).Methods(http.MethodGet, http.MethodPost)
 
 	gqlServer := httputil.NewStartableServer(
 		log.With(zap.String("server", "graphql")),
@@ -156,45 +152,6 @@ func setupGatewayServerFromSchemas(log *zap.Logger, schemas []*graphql.RemoteSch
 	return gqlServer, nil
 }
 
-func withBasicAuth(log *zap.Logger, cfg BasicAuth, handler http.Handler) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			handler.ServeHTTP(w, r)
-			return
-		}
-
-		username, password, ok := r.BasicAuth()
-
-		if !ok {
-			if err := writeJSONError(w, "missing credentials", http.StatusOK); err != nil {
-				log.Info("failed to write response")
-			}
-			return
-		}
-
-		if username != cfg.Username || password != cfg.Password {
-			if err := writeJSONError(w, "wrong credentials", http.StatusOK); err != nil {
-				log.Info("failed to write response")
-			}
-			return
-		}
-
-		handler.ServeHTTP(w, r)
-	}
-}
-
-func writeJSONError(w http.ResponseWriter, message string, statusCode int) error {
-	w.Header().Set("content-type", "application/json")
-	w.WriteHeader(statusCode)
-	return json.NewEncoder(w).Encode(map[string]interface{}{
-		"errors": []map[string]interface{}{
-			{
-				"message": message,
-			},
-		},
-	})
-}
-
 func exitOnError(err error, context string) {
 	if err != nil {
 		log.Fatalf("%s: %v", context, err)
@@ -3,9 +3,9 @@ package main
 import (
 	"log"
 
+	statusreporter "capact.io/capact/internal/k8s-engine/status-reporter"
 	"capact.io/capact/pkg/runner"
 	"capact.io/capact/pkg/runner/helm"
-	statusreporter "capact.io/capact/pkg/runner/status-reporter"
 
 	"github.com/vrischmann/envconfig"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
@@ -20,7 +20,8 @@ func main() {
 
 	helmRunner := helm.NewRunner(cfg)
 
-	statusReporter := statusreporter.NewNoop()
+	statusReporter, err := statusreporter.NewK8sSecretProgressOrNoop()
+	exitOnError(err, "while creating status reporter")
 
 	// create and run manager
 	mgr, err := runner.NewManager(helmRunner, statusReporter)
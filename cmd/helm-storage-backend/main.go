@@ -77,7 +77,8 @@ func main() {
 		handler, err = helm_storage_backend.NewReleaseHandler(logger, helmCfgFlags)
 		exitOnError(err, "while creating Helm Release backend storage")
 	case HelmTemplateMode:
-		handler = helm_storage_backend.NewTemplateHandler(logger, helmCfgFlags)
+		handler, err = helm_storage_backend.NewTemplateHandler(logger, helmCfgFlags)
+		exitOnError(err, "while creating Helm Template backend storage")
 	default:
 		exitOnError(fmt.Errorf("invalid mode %q", cfg.Mode), "while loading storage backend handler")
 	}
@@ -9,6 +9,7 @@ import (
 	"capact.io/capact/internal/k8s-engine/controller"
 	domaingraphql "capact.io/capact/internal/k8s-engine/graphql"
 	"capact.io/capact/internal/k8s-engine/graphql/namespace"
+	"capact.io/capact/internal/k8s-engine/graphql/user"
 	"capact.io/capact/internal/k8s-engine/policy"
 	"capact.io/capact/internal/k8s-engine/validate"
 	"capact.io/capact/internal/logger"
@@ -174,9 +175,10 @@ func getHubClient(cfg *Config) *hubclient.Client {
 
 func gqlServer(log *uber_zap.Logger, execSchema gqlgen_graphql.ExecutableSchema, addr, name string) httputil.StartableServer {
 	nsMiddleware := namespace.NewMiddleware()
+	userMiddleware := user.NewMiddleware()
 
 	gqlRouter := graphqlutil.NewGraphQLRouter(execSchema, name)
-	gqlRouter.Use(nsMiddleware.Handle)
+	gqlRouter.Use(nsMiddleware.Handle, userMiddleware.Handle)
 
 	return httputil.NewStartableServer(
 		log.With(uber_zap.String("server", "graphql")),
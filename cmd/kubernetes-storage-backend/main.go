@@ -0,0 +1,103 @@
+package main
+
+import (
+	"log"
+	"net"
+
+	"capact.io/capact/internal/healthz"
+	kubernetes_storage_backend "capact.io/capact/internal/kubernetes-storage-backend"
+	"capact.io/capact/internal/logger"
+	"capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"github.com/vrischmann/envconfig"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+// Config holds application related configuration.
+type Config struct {
+	// GRPCAddr is the TCP address the gRPC server binds to.
+	GRPCAddr string `envconfig:"default=:50051"`
+
+	// HealthzAddr is the TCP address the health probes endpoint binds to.
+	HealthzAddr string `envconfig:"default=:8082"`
+
+	// DefaultNamespace is the namespace used for TypeInstances, which don't have the namespace specified in context.
+	// It should be set to the namespace the storage backend runs in.
+	DefaultNamespace string `envconfig:"default=default"`
+
+	// AllowedNamespaces holds the namespaces, in which the TypeInstance values can be stored.
+	// If empty, only the DefaultNamespace is allowed.
+	AllowedNamespaces []string `envconfig:"optional"`
+
+	// MaxRevisions is the number of the latest TypeInstance resource versions kept in a given Secret or ConfigMap.
+	// If zero, all resource versions are kept.
+	MaxRevisions int `envconfig:"default=10"`
+
+	Logger logger.Config
+}
+
+const appName = "kubernetes-storage-backend"
+
+func main() {
+	var cfg Config
+	err := envconfig.InitWithPrefix(&cfg, "APP")
+	exitOnError(err, "while loading configuration")
+
+	ctx := signals.SetupSignalHandler()
+
+	// setup logger
+	unnamedLogger, err := logger.New(cfg.Logger)
+	exitOnError(err, "while creating zap logger")
+
+	logger := unnamedLogger.Named(appName)
+
+	// k8s
+	k8sCfg, err := config.GetConfig()
+	exitOnError(err, "while getting K8s config")
+
+	k8sCli, err := kubernetes.NewForConfig(k8sCfg)
+	exitOnError(err, "while creating K8s client")
+
+	// setup servers
+	parallelServers := new(errgroup.Group)
+
+	healthzServer := healthz.NewHTTPServer(logger, cfg.HealthzAddr, appName)
+	parallelServers.Go(func() error { return healthzServer.Start(ctx) })
+
+	handler := kubernetes_storage_backend.NewHandler(logger, k8sCli, kubernetes_storage_backend.Config{
+		DefaultNamespace:  cfg.DefaultNamespace,
+		AllowedNamespaces: cfg.AllowedNamespaces,
+		MaxRevisions:      cfg.MaxRevisions,
+	})
+
+	listenCfg := net.ListenConfig{}
+	listener, err := listenCfg.Listen(ctx, "tcp", cfg.GRPCAddr)
+	exitOnError(err, "while listening")
+
+	srv := grpc.NewServer()
+	storage_backend.RegisterStorageBackendServer(srv, handler)
+
+	go func() {
+		<-ctx.Done()
+		logger.Info("Stopping server gracefully")
+		srv.GracefulStop()
+	}()
+
+	parallelServers.Go(func() error {
+		logger.Info("Starting TCP server", zap.String("addr", cfg.GRPCAddr))
+		return srv.Serve(listener)
+	})
+
+	err = parallelServers.Wait()
+	exitOnError(err, "while waiting for servers to finish gracefully")
+}
+
+func exitOnError(err error, context string) {
+	if err != nil {
+		log.Fatalf("%s: %v", context, err)
+	}
+}
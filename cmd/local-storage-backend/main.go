@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"capact.io/capact/internal/healthz"
+	local_storage_backend "capact.io/capact/internal/local-storage-backend"
+	"capact.io/capact/internal/logger"
+	"capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"github.com/pkg/errors"
+	"github.com/vrischmann/envconfig"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+// Config holds application related configuration.
+type Config struct {
+	// GRPCAddr is the TCP address the gRPC server binds to.
+	GRPCAddr string `envconfig:"default=:50051"`
+
+	// HealthzAddr is the TCP address the health probes endpoint binds to.
+	HealthzAddr string `envconfig:"default=:8082"`
+
+	// DBPath is the path to the BoltDB file, which stores the TypeInstance values.
+	// Defaults to `~/.local/share/capact/local-storage.db`.
+	DBPath string `envconfig:"optional"`
+
+	// EncryptionKey is the base64 encoded 32-byte key used to encrypt the TypeInstance values.
+	EncryptionKey string `envconfig:"optional"`
+
+	// EncryptionKeyFile is the path to the file with base64 encoded 32-byte key used to encrypt the TypeInstance values.
+	EncryptionKeyFile string `envconfig:"optional"`
+
+	// AllowPlaintext allows to store the TypeInstance values in plain text, if the encryption key is not provided.
+	AllowPlaintext bool `envconfig:"default=false"`
+
+	Logger logger.Config
+}
+
+const (
+	appName       = "local-storage-backend"
+	dbOpenTimeout = 5 * time.Second
+)
+
+func main() {
+	var cfg Config
+	err := envconfig.InitWithPrefix(&cfg, "APP")
+	exitOnError(err, "while loading configuration")
+
+	ctx := signals.SetupSignalHandler()
+
+	// setup logger
+	unnamedLogger, err := logger.New(cfg.Logger)
+	exitOnError(err, "while creating zap logger")
+
+	logger := unnamedLogger.Named(appName)
+
+	err = run(ctx, cfg, logger)
+	exitOnError(err, "while running storage backend")
+}
+
+// run returns the error instead of exiting, so the database is always closed.
+func run(ctx context.Context, cfg Config, logger *zap.Logger) error {
+	// setup storage
+	key, err := local_storage_backend.LoadEncryptionKey(cfg.EncryptionKey, cfg.EncryptionKeyFile)
+	if err != nil {
+		return errors.Wrap(err, "while loading encryption key")
+	}
+	if key == nil {
+		if !cfg.AllowPlaintext {
+			return errors.New("encryption key not provided: set APP_ENCRYPTION_KEY or APP_ENCRYPTION_KEY_FILE, or set APP_ALLOW_PLAINTEXT to store TypeInstance values in plain text")
+		}
+		logger.Warn("Encryption key not provided. TypeInstance values are stored in plain text.")
+	}
+
+	cipher, err := local_storage_backend.NewCipher(key)
+	if err != nil {
+		return errors.Wrap(err, "while creating cipher")
+	}
+
+	dbPath := cfg.DBPath
+	if dbPath == "" {
+		dbPath, err = defaultDBPath()
+		if err != nil {
+			return errors.Wrap(err, "while getting default database path")
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0700); err != nil {
+		return errors.Wrap(err, "while creating database directory")
+	}
+
+	db, err := bolt.Open(dbPath, 0600, &bolt.Options{Timeout: dbOpenTimeout})
+	if err != nil {
+		return errors.Wrap(err, "while opening database")
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error("while closing database", zap.Error(err))
+		}
+	}()
+
+	handler, err := local_storage_backend.NewHandler(logger, db, cipher)
+	if err != nil {
+		return errors.Wrap(err, "while creating handler")
+	}
+
+	// setup servers
+	parallelServers := new(errgroup.Group)
+
+	healthzServer := healthz.NewHTTPServer(logger, cfg.HealthzAddr, appName)
+	parallelServers.Go(func() error { return healthzServer.Start(ctx) })
+
+	listenCfg := net.ListenConfig{}
+	listener, err := listenCfg.Listen(ctx, "tcp", cfg.GRPCAddr)
+	if err != nil {
+		return errors.Wrap(err, "while listening")
+	}
+
+	srv := grpc.NewServer()
+	storage_backend.RegisterStorageBackendServer(srv, handler)
+
+	go func() {
+		<-ctx.Done()
+		logger.Info("Stopping server gracefully")
+		srv.GracefulStop()
+	}()
+
+	parallelServers.Go(func() error {
+		logger.Info("Starting TCP server", zap.String("addr", cfg.GRPCAddr), zap.String("dbPath", dbPath))
+		return srv.Serve(listener)
+	})
+
+	if err := parallelServers.Wait(); err != nil {
+		return errors.Wrap(err, "while waiting for servers to finish gracefully")
+	}
+
+	return nil
+}
+
+// defaultDBPath returns the database path in the user data directory.
+func defaultDBPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, ".local", "share", "capact", "local-storage.db"), nil
+}
+
+func exitOnError(err error, context string) {
+	if err != nil {
+		log.Fatalf("%s: %v", context, err)
+	}
+}
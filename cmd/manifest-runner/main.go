@@ -0,0 +1,38 @@
+package main
+
+import (
+	"log"
+
+	statusreporter "capact.io/capact/internal/k8s-engine/status-reporter"
+	"capact.io/capact/pkg/runner"
+	"capact.io/capact/pkg/runner/manifest"
+
+	"github.com/vrischmann/envconfig"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+func main() {
+	var cfg manifest.Config
+	err := envconfig.InitWithPrefix(&cfg, "RUNNER")
+	exitOnError(err, "while loading configuration")
+
+	stop := signals.SetupSignalHandler()
+
+	manifestRunner := manifest.NewRunner(cfg)
+
+	statusReporter, err := statusreporter.NewK8sSecretProgressOrNoop()
+	exitOnError(err, "while creating status reporter")
+
+	// create and run manager
+	mgr, err := runner.NewManager(manifestRunner, statusReporter)
+	exitOnError(err, "while creating runner manager")
+
+	err = mgr.Execute(stop)
+	exitOnError(err, "while executing runner")
+}
+
+func exitOnError(err error, context string) {
+	if err != nil {
+		log.Fatalf("%s: %v", context, err)
+	}
+}
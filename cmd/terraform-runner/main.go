@@ -3,8 +3,8 @@ package main
 import (
 	"log"
 
+	statusreporter "capact.io/capact/internal/k8s-engine/status-reporter"
 	"capact.io/capact/pkg/runner"
-	statusreporter "capact.io/capact/pkg/runner/status-reporter"
 	"capact.io/capact/pkg/runner/terraform"
 
 	"github.com/vrischmann/envconfig"
@@ -20,7 +20,8 @@ func main() {
 
 	terraformRunner := terraform.NewTerraformRunner(cfg)
 
-	statusReporter := statusreporter.NewNoop()
+	statusReporter, err := statusreporter.NewK8sSecretProgressOrNoop()
+	exitOnError(err, "while creating status reporter")
 
 	// create and run manager
 	mgr, err := runner.NewManager(terraformRunner, statusReporter)
@@ -49,3 +49,10 @@ Selector labels
 app.kubernetes.io/name: {{ include "engine.name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
+
+{{/*
+Runner images compatible with given runner Interface revisions, in the `<revision constraint>=<image>;...` format
+*/}}
+{{- define "engine.builtInRunnerImages" -}}
+{{- range $i, $item := .Values.builtInRunner.images }}{{ if $i }};{{ end }}{{ $item.revisionConstraint }}={{ $item.image }}{{ end }}
+{{- end }}
@@ -28,6 +28,7 @@ readonly charts=(
   "capact"
   "secret-storage-backend"
   "helm-storage-backend"
+  "kubernetes-storage-backend"
 )
 
 # shellcheck source=./hack/lib/utilities.sh
@@ -73,6 +73,52 @@ export interface OnUnlockRequest {
 
 export interface OnUnlockResponse {}
 
+export interface GetValuesRequest {
+  requests: GetValueRequest[];
+}
+
+export interface GetValuesResponse {
+  /** responses are returned in the same order as the requests. */
+  responses: GetValueResponse[];
+}
+
+export interface OnLockBatchRequest {
+  requests: OnLockRequest[];
+}
+
+export interface OnLockBatchResponse {}
+
+export interface OnUnlockBatchRequest {
+  requests: OnUnlockRequest[];
+}
+
+export interface OnUnlockBatchResponse {}
+
+export interface WatchValueRequest {
+  typeInstanceId: string;
+  resourceVersion: number;
+  context: Uint8Array;
+}
+
+export interface WatchValueResponse {
+  value?: Uint8Array | undefined;
+}
+
+export interface GetCapabilitiesRequest {}
+
+export interface GetCapabilitiesResponse {
+  /** value_storage specifies whether the backend stores TypeInstance values passed in requests. */
+  valueStorage: boolean;
+  /** locking specifies whether the backend persists TypeInstance locks. */
+  locking: boolean;
+  /** versioning specifies whether the backend keeps a separate value for each TypeInstance resource version. */
+  versioning: boolean;
+  /** read_only specifies whether the backend only exposes data which is managed outside of Capact. */
+  readOnly: boolean;
+  /** context_schema is the JSON schema of the accepted TypeInstance context. It is not set if the context is not accepted. */
+  contextSchema?: string | undefined;
+}
+
 function createBaseOnCreateRequest(): OnCreateRequest {
   return { typeInstanceId: "", value: new Uint8Array(), context: undefined };
 }
@@ -1068,6 +1114,633 @@ export const OnUnlockResponse = {
   },
 };
 
+function createBaseGetValuesRequest(): GetValuesRequest {
+  return { requests: [] };
+}
+
+export const GetValuesRequest = {
+  encode(
+    message: GetValuesRequest,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    for (const v of message.requests) {
+      GetValueRequest.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetValuesRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetValuesRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.requests.push(
+            GetValueRequest.decode(reader, reader.uint32())
+          );
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetValuesRequest {
+    return {
+      requests: Array.isArray(object?.requests)
+        ? object.requests.map((e: any) => GetValueRequest.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: GetValuesRequest): unknown {
+    const obj: any = {};
+    if (message.requests) {
+      obj.requests = message.requests.map((e) =>
+        e ? GetValueRequest.toJSON(e) : undefined
+      );
+    } else {
+      obj.requests = [];
+    }
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<GetValuesRequest>): GetValuesRequest {
+    const message = createBaseGetValuesRequest();
+    message.requests =
+      object.requests?.map((e) => GetValueRequest.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseGetValuesResponse(): GetValuesResponse {
+  return { responses: [] };
+}
+
+export const GetValuesResponse = {
+  encode(
+    message: GetValuesResponse,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    for (const v of message.responses) {
+      GetValueResponse.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetValuesResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetValuesResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.responses.push(
+            GetValueResponse.decode(reader, reader.uint32())
+          );
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetValuesResponse {
+    return {
+      responses: Array.isArray(object?.responses)
+        ? object.responses.map((e: any) => GetValueResponse.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: GetValuesResponse): unknown {
+    const obj: any = {};
+    if (message.responses) {
+      obj.responses = message.responses.map((e) =>
+        e ? GetValueResponse.toJSON(e) : undefined
+      );
+    } else {
+      obj.responses = [];
+    }
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<GetValuesResponse>): GetValuesResponse {
+    const message = createBaseGetValuesResponse();
+    message.responses =
+      object.responses?.map((e) => GetValueResponse.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseOnLockBatchRequest(): OnLockBatchRequest {
+  return { requests: [] };
+}
+
+export const OnLockBatchRequest = {
+  encode(
+    message: OnLockBatchRequest,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    for (const v of message.requests) {
+      OnLockRequest.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): OnLockBatchRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOnLockBatchRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.requests.push(OnLockRequest.decode(reader, reader.uint32()));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): OnLockBatchRequest {
+    return {
+      requests: Array.isArray(object?.requests)
+        ? object.requests.map((e: any) => OnLockRequest.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: OnLockBatchRequest): unknown {
+    const obj: any = {};
+    if (message.requests) {
+      obj.requests = message.requests.map((e) =>
+        e ? OnLockRequest.toJSON(e) : undefined
+      );
+    } else {
+      obj.requests = [];
+    }
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<OnLockBatchRequest>): OnLockBatchRequest {
+    const message = createBaseOnLockBatchRequest();
+    message.requests =
+      object.requests?.map((e) => OnLockRequest.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseOnLockResponse(): OnLockBatchResponse {
+  return {};
+}
+
+export const OnLockBatchResponse = {
+  encode(
+    _: OnLockBatchResponse,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): OnLockBatchResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOnLockResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(_: any): OnLockBatchResponse {
+    return {};
+  },
+
+  toJSON(_: OnLockBatchResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  fromPartial(_: DeepPartial<OnLockBatchResponse>): OnLockBatchResponse {
+    const message = createBaseOnLockResponse();
+    return message;
+  },
+};
+
+function createBaseOnUnlockBatchRequest(): OnUnlockBatchRequest {
+  return { requests: [] };
+}
+
+export const OnUnlockBatchRequest = {
+  encode(
+    message: OnUnlockBatchRequest,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    for (const v of message.requests) {
+      OnUnlockRequest.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(
+    input: _m0.Reader | Uint8Array,
+    length?: number
+  ): OnUnlockBatchRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOnUnlockBatchRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.requests.push(
+            OnUnlockRequest.decode(reader, reader.uint32())
+          );
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): OnUnlockBatchRequest {
+    return {
+      requests: Array.isArray(object?.requests)
+        ? object.requests.map((e: any) => OnUnlockRequest.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: OnUnlockBatchRequest): unknown {
+    const obj: any = {};
+    if (message.requests) {
+      obj.requests = message.requests.map((e) =>
+        e ? OnUnlockRequest.toJSON(e) : undefined
+      );
+    } else {
+      obj.requests = [];
+    }
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<OnUnlockBatchRequest>): OnUnlockBatchRequest {
+    const message = createBaseOnUnlockBatchRequest();
+    message.requests =
+      object.requests?.map((e) => OnUnlockRequest.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseOnLockResponse(): OnUnlockBatchResponse {
+  return {};
+}
+
+export const OnUnlockBatchResponse = {
+  encode(
+    _: OnUnlockBatchResponse,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    return writer;
+  },
+
+  decode(
+    input: _m0.Reader | Uint8Array,
+    length?: number
+  ): OnUnlockBatchResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOnLockResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(_: any): OnUnlockBatchResponse {
+    return {};
+  },
+
+  toJSON(_: OnUnlockBatchResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  fromPartial(_: DeepPartial<OnUnlockBatchResponse>): OnUnlockBatchResponse {
+    const message = createBaseOnLockResponse();
+    return message;
+  },
+};
+
+function createBaseGetValueRequest(): WatchValueRequest {
+  return { typeInstanceId: "", resourceVersion: 0, context: new Uint8Array() };
+}
+
+export const WatchValueRequest = {
+  encode(
+    message: WatchValueRequest,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    if (message.typeInstanceId !== "") {
+      writer.uint32(10).string(message.typeInstanceId);
+    }
+    if (message.resourceVersion !== 0) {
+      writer.uint32(16).uint32(message.resourceVersion);
+    }
+    if (message.context.length !== 0) {
+      writer.uint32(26).bytes(message.context);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WatchValueRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetValueRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.typeInstanceId = reader.string();
+          break;
+        case 2:
+          message.resourceVersion = reader.uint32();
+          break;
+        case 3:
+          message.context = reader.bytes();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WatchValueRequest {
+    return {
+      typeInstanceId: isSet(object.typeInstanceId)
+        ? String(object.typeInstanceId)
+        : "",
+      resourceVersion: isSet(object.resourceVersion)
+        ? Number(object.resourceVersion)
+        : 0,
+      context: isSet(object.context)
+        ? bytesFromBase64(object.context)
+        : new Uint8Array(),
+    };
+  },
+
+  toJSON(message: WatchValueRequest): unknown {
+    const obj: any = {};
+    message.typeInstanceId !== undefined &&
+      (obj.typeInstanceId = message.typeInstanceId);
+    message.resourceVersion !== undefined &&
+      (obj.resourceVersion = Math.round(message.resourceVersion));
+    message.context !== undefined &&
+      (obj.context = base64FromBytes(
+        message.context !== undefined ? message.context : new Uint8Array()
+      ));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<WatchValueRequest>): WatchValueRequest {
+    const message = createBaseGetValueRequest();
+    message.typeInstanceId = object.typeInstanceId ?? "";
+    message.resourceVersion = object.resourceVersion ?? 0;
+    message.context = object.context ?? new Uint8Array();
+    return message;
+  },
+};
+
+function createBaseGetValueResponse(): WatchValueResponse {
+  return { value: undefined };
+}
+
+export const WatchValueResponse = {
+  encode(
+    message: WatchValueResponse,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    if (message.value !== undefined) {
+      writer.uint32(10).bytes(message.value);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WatchValueResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetValueResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.value = reader.bytes();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WatchValueResponse {
+    return {
+      value: isSet(object.value) ? bytesFromBase64(object.value) : undefined,
+    };
+  },
+
+  toJSON(message: WatchValueResponse): unknown {
+    const obj: any = {};
+    message.value !== undefined &&
+      (obj.value =
+        message.value !== undefined
+          ? base64FromBytes(message.value)
+          : undefined);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<WatchValueResponse>): WatchValueResponse {
+    const message = createBaseGetValueResponse();
+    message.value = object.value ?? undefined;
+    return message;
+  },
+};
+
+function createBaseOnLockResponse(): GetCapabilitiesRequest {
+  return {};
+}
+
+export const GetCapabilitiesRequest = {
+  encode(
+    _: GetCapabilitiesRequest,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    return writer;
+  },
+
+  decode(
+    input: _m0.Reader | Uint8Array,
+    length?: number
+  ): GetCapabilitiesRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOnLockResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(_: any): GetCapabilitiesRequest {
+    return {};
+  },
+
+  toJSON(_: GetCapabilitiesRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  fromPartial(_: DeepPartial<GetCapabilitiesRequest>): GetCapabilitiesRequest {
+    const message = createBaseOnLockResponse();
+    return message;
+  },
+};
+
+function createBaseGetCapabilitiesResponse(): GetCapabilitiesResponse {
+  return {
+    valueStorage: false,
+    locking: false,
+    versioning: false,
+    readOnly: false,
+    contextSchema: undefined,
+  };
+}
+
+export const GetCapabilitiesResponse = {
+  encode(
+    message: GetCapabilitiesResponse,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    if (message.valueStorage === true) {
+      writer.uint32(8).bool(message.valueStorage);
+    }
+    if (message.locking === true) {
+      writer.uint32(16).bool(message.locking);
+    }
+    if (message.versioning === true) {
+      writer.uint32(24).bool(message.versioning);
+    }
+    if (message.readOnly === true) {
+      writer.uint32(32).bool(message.readOnly);
+    }
+    if (message.contextSchema !== undefined) {
+      writer.uint32(42).string(message.contextSchema);
+    }
+    return writer;
+  },
+
+  decode(
+    input: _m0.Reader | Uint8Array,
+    length?: number
+  ): GetCapabilitiesResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetCapabilitiesResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.valueStorage = reader.bool();
+          break;
+        case 2:
+          message.locking = reader.bool();
+          break;
+        case 3:
+          message.versioning = reader.bool();
+          break;
+        case 4:
+          message.readOnly = reader.bool();
+          break;
+        case 5:
+          message.contextSchema = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetCapabilitiesResponse {
+    return {
+      valueStorage: isSet(object.valueStorage)
+        ? Boolean(object.valueStorage)
+        : false,
+      locking: isSet(object.locking) ? Boolean(object.locking) : false,
+      versioning: isSet(object.versioning) ? Boolean(object.versioning) : false,
+      readOnly: isSet(object.readOnly) ? Boolean(object.readOnly) : false,
+      contextSchema: isSet(object.contextSchema)
+        ? String(object.contextSchema)
+        : undefined,
+    };
+  },
+
+  toJSON(message: GetCapabilitiesResponse): unknown {
+    const obj: any = {};
+    message.valueStorage !== undefined &&
+      (obj.valueStorage = message.valueStorage);
+    message.locking !== undefined && (obj.locking = message.locking);
+    message.versioning !== undefined && (obj.versioning = message.versioning);
+    message.readOnly !== undefined && (obj.readOnly = message.readOnly);
+    message.contextSchema !== undefined &&
+      (obj.contextSchema = message.contextSchema);
+    return obj;
+  },
+
+  fromPartial(
+    object: DeepPartial<GetCapabilitiesResponse>
+  ): GetCapabilitiesResponse {
+    const message = createBaseGetCapabilitiesResponse();
+    message.valueStorage = object.valueStorage ?? false;
+    message.locking = object.locking ?? false;
+    message.versioning = object.versioning ?? false;
+    message.readOnly = object.readOnly ?? false;
+    message.contextSchema = object.contextSchema ?? undefined;
+    return message;
+  },
+};
+
 export const StorageBackendDefinition = {
   name: "StorageBackend",
   fullName: "storage_backend.StorageBackend",
@@ -1130,6 +1803,49 @@ export const StorageBackendDefinition = {
       responseStream: false,
       options: {},
     },
+    /** batch */
+    getValues: {
+      name: "GetValues",
+      requestType: GetValuesRequest,
+      requestStream: false,
+      responseType: GetValuesResponse,
+      responseStream: false,
+      options: {},
+    },
+    onLockBatch: {
+      name: "OnLockBatch",
+      requestType: OnLockBatchRequest,
+      requestStream: false,
+      responseType: OnLockBatchResponse,
+      responseStream: false,
+      options: {},
+    },
+    onUnlockBatch: {
+      name: "OnUnlockBatch",
+      requestType: OnUnlockBatchRequest,
+      requestStream: false,
+      responseType: OnUnlockBatchResponse,
+      responseStream: false,
+      options: {},
+    },
+    /** watch */
+    watchValue: {
+      name: "WatchValue",
+      requestType: WatchValueRequest,
+      requestStream: false,
+      responseType: WatchValueResponse,
+      responseStream: true,
+      options: {},
+    },
+    /** capabilities */
+    getCapabilities: {
+      name: "GetCapabilities",
+      requestType: GetCapabilitiesRequest,
+      requestStream: false,
+      responseType: GetCapabilitiesResponse,
+      responseStream: false,
+      options: {},
+    },
   },
 } as const;
 
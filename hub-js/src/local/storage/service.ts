@@ -7,7 +7,13 @@ import {
   OnUpdateRequest,
   StorageBackendDefinition,
 } from "../../generated/grpc/storage_backend";
-import { Client, createChannel, createClient } from "nice-grpc";
+import {
+  Client,
+  ClientError,
+  createChannel,
+  createClient,
+  Status,
+} from "nice-grpc";
 import { Driver } from "neo4j-driver";
 import { TypeInstanceBackendInput } from "../types/type-instance";
 import { logger } from "../../logger";
@@ -33,6 +39,11 @@ interface BackendContainer {
   validateSpec: ValidateBackendSpec;
 }
 
+interface BatchRequests<T> {
+  client: StorageClient;
+  requests: T[];
+}
+
 interface ValidateBackendSpec {
   backendId: string;
   contextSchema: JSONSchemaType<unknown> | undefined;
@@ -297,10 +308,16 @@ export default class DelegatedStorageService {
   /**
    * Locks a given TypeInstance
    *
+   * TypeInstances stored in the same backend are locked in a single call.
+   * If the backend doesn't support batch locking, they are locked one by one.
+   * If locking fails, the TypeInstances locked so far are unlocked.
+   *
    * @param inputs - Describes what should be locked. Owner ID is needed.
    *
    */
   async Lock(...inputs: LockInput[]) {
+    const batches = new Map<string, BatchRequests<OnLockRequest>>();
+
     for (const input of inputs) {
       logger.debug("Locking TypeInstance in external backend", {
         typeInstanceId: input.typeInstance.id,
@@ -324,17 +341,54 @@ export default class DelegatedStorageService {
         lockedBy: input.typeInstance.lockedBy,
         context: DelegatedStorageService.encode(input.backend.context),
       };
-      await backend.client.onLock(req);
+      DelegatedStorageService.addToBatch(
+        batches,
+        input.backend.id,
+        backend,
+        req
+      );
+    }
+
+    const locked: BatchRequests<OnLockRequest>[] = [];
+    try {
+      for (const { client, requests } of batches.values()) {
+        const lockedInBackend: BatchRequests<OnLockRequest> = {
+          client,
+          requests: [],
+        };
+        locked.push(lockedInBackend);
+
+        try {
+          await client.onLockBatch({ requests });
+          lockedInBackend.requests.push(...requests);
+        } catch (e) {
+          if (!DelegatedStorageService.isUnimplemented(e)) {
+            throw e;
+          }
+          for (const req of requests) {
+            await client.onLock(req);
+            lockedInBackend.requests.push(req);
+          }
+        }
+      }
+    } catch (e) {
+      await DelegatedStorageService.rollbackLocks(locked);
+      throw e;
     }
   }
 
   /**
    * Unlocks a given TypeInstance
    *
+   * TypeInstances stored in the same backend are unlocked in a single call.
+   * If the backend doesn't support batch unlocking, they are unlocked one by one.
+   *
    * @param inputs - Describes what should be unlocked. Owner ID is not needed.
    *
    */
   async Unlock(...inputs: UnlockInput[]) {
+    const batches = new Map<string, BatchRequests<OnUnlockRequest>>();
+
     for (const input of inputs) {
       logger.debug(`Unlocking TypeInstance in external backend`, {
         typeInstanceId: input.typeInstance.id,
@@ -357,7 +411,25 @@ export default class DelegatedStorageService {
         typeInstanceId: input.typeInstance.id,
         context: DelegatedStorageService.encode(input.backend.context),
       };
-      await backend.client.onUnlock(req);
+      DelegatedStorageService.addToBatch(
+        batches,
+        input.backend.id,
+        backend,
+        req
+      );
+    }
+
+    for (const { client, requests } of batches.values()) {
+      try {
+        await client.onUnlockBatch({ requests });
+      } catch (e) {
+        if (!DelegatedStorageService.isUnimplemented(e)) {
+          throw e;
+        }
+        for (const req of requests) {
+          await client.onUnlock(req);
+        }
+      }
     }
   }
 
@@ -454,6 +526,46 @@ export default class DelegatedStorageService {
     return this.registeredClients.get(id);
   }
 
+  private static addToBatch<T>(
+    batches: Map<string, BatchRequests<T>>,
+    backendId: string,
+    backend: BackendContainer,
+    req: T
+  ) {
+    const batch = batches.get(backendId);
+    if (batch) {
+      batch.requests.push(req);
+      return;
+    }
+    batches.set(backendId, { client: backend.client, requests: [req] });
+  }
+
+  /**
+   * Unlocks already locked TypeInstances on a best-effort basis,
+   * as the original locking error is more relevant for the caller.
+   */
+  private static async rollbackLocks(locked: BatchRequests<OnLockRequest>[]) {
+    for (const { client, requests } of locked) {
+      for (const req of requests) {
+        try {
+          await client.onUnlock({
+            typeInstanceId: req.typeInstanceId,
+            context: req.context,
+          });
+        } catch (e) {
+          logger.error("Failed to unlock TypeInstance after locking failure", {
+            typeInstanceId: req.typeInstanceId,
+            error: e instanceof Error ? e.message : e,
+          });
+        }
+      }
+    }
+  }
+
+  private static isUnimplemented(err: unknown): boolean {
+    return err instanceof ClientError && err.code === Status.UNIMPLEMENTED;
+  }
+
   private static convertToJSONIfObject(val: unknown): string | undefined {
     if (val instanceof Array || typeof val === "object") {
       return JSON.stringify(val);
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"capact.io/capact/internal/cli/client"
@@ -87,7 +88,7 @@ func TableDataOnGet(in interface{}) (cliprinter.TableData, error) {
 		return cliprinter.TableData{}, fmt.Errorf("got unexpected input type, expected action.GetOutput, got %T", in)
 	}
 
-	out.Headers = []string{"NAMESPACE", "NAME", "PATH", "RUN", "STATUS", "AGE"}
+	out.Headers = []string{"NAMESPACE", "NAME", "PATH", "RUN", "STATUS", "REASON", "PROGRESS", "CREATED BY", "RUN BY", "CANCELED BY", "AGE"}
 	for _, act := range getOut.Actions {
 		out.MultipleRows = append(out.MultipleRows, []string{
 			getOut.Namespace,
@@ -95,9 +96,48 @@ func TableDataOnGet(in interface{}) (cliprinter.TableData, error) {
 			act.ActionRef.Path,
 			strconv.FormatBool(act.Run),
 			string(act.Status.Phase),
+			failureReasonOrEmpty(act.Status.Reason),
+			runnerProgressOrEmpty(act.Status.Runner),
+			usernameOrEmpty(act.Status.CreatedBy),
+			usernameOrEmpty(act.Status.RunBy),
+			usernameOrEmpty(act.Status.CanceledBy),
 			duration.HumanDuration(time.Since(act.CreatedAt.Time)),
 		})
 	}
 
 	return out, nil
 }
+
+func failureReasonOrEmpty(in *gqlengine.ActionFailureReason) string {
+	if in == nil {
+		return ""
+	}
+	return string(*in)
+}
+
+func runnerProgressOrEmpty(in *gqlengine.RunnerStatus) string {
+	if in == nil || in.Progress == nil {
+		return ""
+	}
+
+	var out []string
+	if in.Progress.Phase != nil {
+		out = append(out, *in.Progress.Phase)
+	}
+
+	switch {
+	case in.Progress.Total != nil:
+		out = append(out, fmt.Sprintf("%d/%d", in.Progress.Completed, *in.Progress.Total))
+	case in.Progress.Completed > 0:
+		out = append(out, strconv.Itoa(in.Progress.Completed))
+	}
+
+	return strings.Join(out, " ")
+}
+
+func usernameOrEmpty(in *gqlengine.UserInfo) string {
+	if in == nil {
+		return ""
+	}
+	return in.Username
+}
@@ -0,0 +1,102 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"capact.io/capact/internal/ptr"
+	gqlengine "capact.io/capact/pkg/engine/api/graphql"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableDataOnGet(t *testing.T) {
+	// given
+	in := GetOutput{
+		Namespace: "default",
+		Actions: []*gqlengine.Action{
+			{
+				Name:      "canceled",
+				CreatedAt: gqlengine.Timestamp{Time: time.Now()},
+				ActionRef: &gqlengine.ManifestReference{Path: "cap.interface.productivity.mattermost.install"},
+				Run:       true,
+				Cancel:    true,
+				Status: &gqlengine.ActionStatus{
+					Phase:      gqlengine.ActionStatusPhaseCanceled,
+					CreatedBy:  &gqlengine.UserInfo{Username: "creator"},
+					RunBy:      &gqlengine.UserInfo{Username: "runner"},
+					CanceledBy: &gqlengine.UserInfo{Username: "canceler"},
+				},
+			},
+			{
+				Name:      "initial",
+				CreatedAt: gqlengine.Timestamp{Time: time.Now()},
+				ActionRef: &gqlengine.ManifestReference{Path: "cap.interface.productivity.mattermost.install"},
+				Status: &gqlengine.ActionStatus{
+					Phase: gqlengine.ActionStatusPhaseInitial,
+				},
+			},
+		},
+	}
+
+	// when
+	out, err := TableDataOnGet(in)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NAMESPACE", "NAME", "PATH", "RUN", "STATUS", "REASON", "PROGRESS", "CREATED BY", "RUN BY", "CANCELED BY", "AGE"}, out.Headers)
+	assert.Equal(t, [][]string{
+		{"default", "canceled", "cap.interface.productivity.mattermost.install", "true", "CANCELED", "", "", "creator", "runner", "canceler", "0s"},
+		{"default", "initial", "cap.interface.productivity.mattermost.install", "false", "INITIAL", "", "", "", "", "", "0s"},
+	}, out.MultipleRows)
+}
+
+func TestRunnerProgressOrEmpty(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       *gqlengine.RunnerStatus
+		expected string
+	}{
+		{
+			name:     "No runner status",
+			in:       nil,
+			expected: "",
+		},
+		{
+			name:     "No progress",
+			in:       &gqlengine.RunnerStatus{},
+			expected: "",
+		},
+		{
+			name: "Phase with known total",
+			in: &gqlengine.RunnerStatus{
+				Progress: &gqlengine.RunnerProgress{Phase: ptr.String("apply"), Completed: 1, Total: ptr.Int(3)},
+			},
+			expected: "apply 1/3",
+		},
+		{
+			name: "Phase with unknown total",
+			in: &gqlengine.RunnerStatus{
+				Progress: &gqlengine.RunnerProgress{Phase: ptr.String("install"), Completed: 2},
+			},
+			expected: "install 2",
+		},
+		{
+			name: "Only phase",
+			in: &gqlengine.RunnerStatus{
+				Progress: &gqlengine.RunnerProgress{Phase: ptr.String("plan")},
+			},
+			expected: "plan",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			out := runnerProgressOrEmpty(tt.in)
+
+			// then
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
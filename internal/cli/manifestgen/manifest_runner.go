@@ -0,0 +1,101 @@
+package manifestgen
+
+import (
+	"fmt"
+	"strings"
+
+	"capact.io/capact/internal/ptr"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"github.com/pkg/errors"
+)
+
+// GenerateManifestRunnerManifests generates manifest files for a Kubernetes manifests or kustomization based Implementation.
+func GenerateManifestRunnerManifests(cfg *ManifestRunnerConfig) (ManifestCollection, error) {
+	cfgs := make([]*templatingConfig, 0, 2)
+
+	inputTypeCfg, err := getManifestRunnerInputTypeTemplatingConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting input type templating config")
+	}
+	cfgs = append(cfgs, inputTypeCfg)
+
+	implCfg, err := getManifestRunnerImplementationTemplatingConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting Implementation templating config")
+	}
+	cfgs = append(cfgs, implCfg)
+
+	generated, err := generateManifests(cfgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "while generating Kubernetes manifests based manifests")
+	}
+
+	return createManifestCollection(generated)
+}
+
+func getManifestRunnerInputTypeTemplatingConfig(cfg *ManifestRunnerConfig) (*templatingConfig, error) {
+	prefix, name, err := splitPathToPrefixAndName(cfg.ManifestRef.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting prefix and path for manifests")
+	}
+
+	typeMetadata := types.TypeMetadata{
+		DocumentationURL: cfg.Metadata.DocumentationURL,
+		IconURL:          cfg.Metadata.IconURL,
+		SupportURL:       cfg.Metadata.SupportURL,
+		Maintainers:      cfg.Metadata.Maintainers,
+		DisplayName:      ptr.String(fmt.Sprintf("Input for %s.%s", prefix, name)),
+		Description:      fmt.Sprintf("Input for the \"%s.%s Action\"", prefix, name),
+	}
+
+	input := &typeTemplatingInput{
+		templatingInput: templatingInput{
+			Name:     getDefaultAdditionalImplTypeName(name),
+			Prefix:   prefix,
+			Revision: cfg.ManifestRef.Revision,
+		},
+		Metadata: typeMetadata,
+	}
+
+	return &templatingConfig{
+		Template: typeManifestTemplate,
+		Input:    input,
+	}, nil
+}
+
+func getManifestRunnerImplementationTemplatingConfig(cfg *ManifestRunnerConfig) (*templatingConfig, error) {
+	prefix, name, err := splitPathToPrefixAndName(cfg.ManifestRef.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting prefix and path for manifests")
+	}
+
+	var (
+		interfacePath     = cfg.InterfacePathWithRevision
+		interfaceRevision = "0.1.0"
+	)
+
+	pathSlice := strings.SplitN(cfg.InterfacePathWithRevision, ":", 2)
+	if len(pathSlice) == 2 {
+		interfacePath = pathSlice[0]
+		interfaceRevision = pathSlice[1]
+	}
+
+	input := &manifestRunnerImplementationTemplatingInput{
+		templatingInput: templatingInput{
+			Name:     name,
+			Prefix:   prefix,
+			Revision: cfg.ManifestRef.Revision,
+		},
+		Metadata: cfg.Metadata,
+		InterfaceRef: types.ManifestRef{
+			Path:     interfacePath,
+			Revision: interfaceRevision,
+		},
+		SourceURL: cfg.SourceURL,
+	}
+
+	return &templatingConfig{
+		Template: manifestRunnerImplementationManifestTemplate,
+		Input:    input,
+	}, nil
+}
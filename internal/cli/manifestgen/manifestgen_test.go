@@ -442,3 +442,40 @@ func TestGenerateHelmImplementationManifests(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateManifestRunnerImplementationManifests(t *testing.T) {
+	cfg := &manifestgen.ManifestRunnerConfig{
+		ImplementationConfig: manifestgen.ImplementationConfig{
+			Config: manifestgen.Config{
+				ManifestRef: types.ManifestRef{
+					Path:     "cap.implementation.manifest.test",
+					Revision: "0.1.0",
+				},
+			},
+			Metadata: types.ImplementationMetadata{
+				DocumentationURL: ptr.String("https://example.com"),
+				SupportURL:       ptr.String("https://example.com"),
+				Maintainers: []types.Maintainer{
+					{
+						Email: "dev@example.com",
+						Name:  ptr.String("Example Dev"),
+						URL:   ptr.String("https://example.com"),
+					},
+				},
+				License: types.License{
+					Name: common.ApacheLicense,
+				},
+			},
+			InterfacePathWithRevision: "cap.interface.group.test:0.2.0",
+		},
+		SourceURL: "git::https://github.com/kubernetes-sigs/kustomize.git//examples/helloWorld",
+	}
+
+	manifests, err := manifestgen.GenerateManifestRunnerManifests(cfg)
+	require.NoError(t, err)
+
+	for name, manifestData := range manifests {
+		filename := fmt.Sprintf("%s.yaml", name)
+		golden.Assert(t, string(manifestData), filename)
+	}
+}
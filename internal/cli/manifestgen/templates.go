@@ -25,4 +25,7 @@ var (
 
 	//go:embed templates/helm-implementation.yaml.tmpl
 	helmImplementationManifestTemplate string
+
+	//go:embed templates/manifest-runner-implementation.yaml.tmpl
+	manifestRunnerImplementationManifestTemplate string
 )
@@ -64,6 +64,14 @@ type HelmConfig struct {
 	ChartVersion string
 }
 
+// ManifestRunnerConfig stores input parameters for Kubernetes manifests based Implementation content generation.
+type ManifestRunnerConfig struct {
+	ImplementationConfig
+
+	// SourceURL is a go-getter URL of the directory with Kubernetes manifests or a kustomization.
+	SourceURL string
+}
+
 // EmptyImplementationConfig stores input parameters for empty Implementation content generation.
 type EmptyImplementationConfig struct {
 	AdditionalInputTypeName string
@@ -137,3 +145,11 @@ type helmImplementationTemplatingInput struct {
 	ValuesYAML  string
 	ArgsWarning string
 }
+
+type manifestRunnerImplementationTemplatingInput struct {
+	templatingInput
+
+	Metadata     types.ImplementationMetadata
+	InterfaceRef types.ManifestRef
+	SourceURL    string
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"capact.io/capact/internal/multierror"
+
+	"github.com/pkg/errors"
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+var (
+	// ErrNoCredentials defines an error indicating that the request doesn't contain credentials handled by a given Authenticator.
+	ErrNoCredentials = errors.New("missing credentials")
+	// ErrInvalidCredentials defines an error indicating that the request credentials are invalid.
+	ErrInvalidCredentials = errors.New("wrong credentials")
+)
+
+// Authenticator authenticates the caller of a given HTTP request.
+type Authenticator interface {
+	// Authenticate returns the caller identity. If the request doesn't contain credentials
+	// handled by a given Authenticator, it returns ErrNoCredentials error.
+	Authenticate(r *http.Request) (authv1.UserInfo, error)
+}
+
+// UnionAuthenticator authenticates the caller using the first Authenticator which accepts the request credentials.
+type UnionAuthenticator struct {
+	authenticators []Authenticator
+}
+
+// NewUnionAuthenticator returns a new UnionAuthenticator instance.
+func NewUnionAuthenticator(authenticators ...Authenticator) *UnionAuthenticator {
+	return &UnionAuthenticator{authenticators: authenticators}
+}
+
+// Authenticate returns the caller identity from the first Authenticator which accepts the request credentials.
+func (u *UnionAuthenticator) Authenticate(r *http.Request) (authv1.UserInfo, error) {
+	errs := multierror.New()
+	for _, authenticator := range u.authenticators {
+		usr, err := authenticator.Authenticate(r)
+		if err == nil {
+			return usr, nil
+		}
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		errs = multierror.Append(errs, err)
+	}
+
+	if errs.ErrorOrNil() == nil {
+		return authv1.UserInfo{}, ErrNoCredentials
+	}
+
+	return authv1.UserInfo{}, errors.Wrap(ErrInvalidCredentials, errs.Error())
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+
+	authHeader := r.Header.Get("Authorization")
+	if len(authHeader) <= len(prefix) || !strings.EqualFold(authHeader[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(authHeader[len(prefix):])
+	return token, token != ""
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+// BasicAuthenticator authenticates the caller using HTTP basic access authentication with static credentials.
+type BasicAuthenticator struct {
+	username string
+	password string
+}
+
+// NewBasicAuthenticator returns a new BasicAuthenticator instance.
+func NewBasicAuthenticator(username, password string) *BasicAuthenticator {
+	return &BasicAuthenticator{
+		username: username,
+		password: password,
+	}
+}
+
+// Authenticate returns the caller identity if the request basic credentials match the configured ones.
+func (a *BasicAuthenticator) Authenticate(r *http.Request) (authv1.UserInfo, error) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return authv1.UserInfo{}, ErrNoCredentials
+	}
+
+	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(a.username)) == 1
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(a.password)) == 1
+	if !usernameMatch || !passwordMatch {
+		return authv1.UserInfo{}, ErrInvalidCredentials
+	}
+
+	return authv1.UserInfo{Username: username}, nil
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const (
+	// BasicAuthenticatorName defines name of the HTTP basic access authenticator.
+	BasicAuthenticatorName = "basic"
+	// JWTAuthenticatorName defines name of the JWT bearer token authenticator.
+	JWTAuthenticatorName = "jwt"
+	// TokenReviewAuthenticatorName defines name of the Kubernetes TokenReview authenticator.
+	TokenReviewAuthenticatorName = "tokenreview"
+)
+
+// Config holds configuration parameters for user authentication and authorization.
+type Config struct {
+	// Authenticators holds a list of enabled authenticators. They are tried in a given order.
+	// Supported values: basic, jwt, tokenreview.
+	Authenticators []string `envconfig:"default=basic"`
+
+	// Username holds username for HTTP basic access authentication.
+	Username string `envconfig:"default=graphql"`
+
+	// Password holds password for HTTP basic access authentication.
+	Password string `envconfig:"optional"`
+
+	// JWT holds configuration for JWT bearer token authentication.
+	JWT JWTConfig
+
+	// TokenReviewAudiences holds a list of audiences passed to Kubernetes TokenReview.
+	TokenReviewAudiences []string `envconfig:"optional"`
+
+	// RBACPolicyPath is a path to the RBAC policy YAML file.
+	// If empty, all authenticated users are authorized to perform any operation.
+	RBACPolicyPath string `envconfig:"optional"`
+}
+
+// NewMiddlewareFromConfig returns a new Middleware with authenticators and authorizer configured based on a given Config.
+func NewMiddlewareFromConfig(log *zap.Logger, cfg Config) (*Middleware, error) {
+	var authenticators []Authenticator
+	for _, name := range cfg.Authenticators {
+		switch name {
+		case BasicAuthenticatorName:
+			if cfg.Password == "" {
+				return nil, errors.New("password is required for basic authenticator")
+			}
+			authenticators = append(authenticators, NewBasicAuthenticator(cfg.Username, cfg.Password))
+		case JWTAuthenticatorName:
+			jwtAuthenticator, err := NewJWTAuthenticator(cfg.JWT)
+			if err != nil {
+				return nil, errors.Wrap(err, "while creating JWT authenticator")
+			}
+			authenticators = append(authenticators, jwtAuthenticator)
+		case TokenReviewAuthenticatorName:
+			k8sCfg, err := config.GetConfig()
+			if err != nil {
+				return nil, errors.Wrap(err, "while getting K8s config")
+			}
+			clientset, err := kubernetes.NewForConfig(k8sCfg)
+			if err != nil {
+				return nil, errors.Wrap(err, "while creating K8s clientset")
+			}
+			authenticators = append(authenticators, NewTokenReviewAuthenticator(clientset.AuthenticationV1().TokenReviews(), cfg.TokenReviewAudiences))
+		default:
+			return nil, errors.Errorf("unknown authenticator %q", name)
+		}
+	}
+
+	if len(authenticators) == 0 {
+		return nil, errors.New("at least one authenticator must be enabled")
+	}
+
+	var authorizer Authorizer = AllowAllAuthorizer{}
+	if cfg.RBACPolicyPath != "" {
+		rbacAuthorizer, err := NewRBACAuthorizerFromFile(cfg.RBACPolicyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "while creating RBAC authorizer")
+		}
+		authorizer = rbacAuthorizer
+	}
+
+	return NewMiddleware(log, NewUnionAuthenticator(authenticators...), authorizer), nil
+}
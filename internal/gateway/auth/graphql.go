@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+type graphQLRequest struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName"`
+}
+
+// namespacedRootFields holds the Engine root fields, which operate on Actions in the Namespace from the request header.
+// All other root fields, such as Hub queries and mutations or the Engine Policy, are cluster-scoped.
+// Introspection fields don't expose any resources, so they are treated as namespaced.
+var namespacedRootFields = map[string]struct{}{
+	"action":                    {},
+	"actions":                   {},
+	"createAction":              {},
+	"runAction":                 {},
+	"cancelAction":              {},
+	"updateAction":              {},
+	"continueAdvancedRendering": {},
+	"deleteAction":              {},
+	"__typename":                {},
+	"__schema":                  {},
+	"__type":                    {},
+}
+
+// access describes a verb performed by the caller either in the requested Namespace or on cluster-scoped resources.
+type access struct {
+	namespaced bool
+	verb       Verb
+}
+
+// requestAccess returns the accesses required to perform GraphQL operations from a given HTTP request.
+// Mutations are treated as WriteVerb, all other operations as ReadVerb. The scope is resolved based on
+// the root fields, not on the Namespace header, which is controlled by the caller.
+// The request body is restored, so it can be read again by next handlers.
+func requestAccess(r *http.Request) ([]access, error) {
+	requests, err := graphQLRequests(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []access
+	add := func(acc access) {
+		for _, existing := range out {
+			if existing == acc {
+				return
+			}
+		}
+		out = append(out, acc)
+	}
+
+	for _, req := range requests {
+		doc, gqlErr := parser.ParseQuery(&ast.Source{Input: req.Query})
+		if gqlErr != nil {
+			return nil, errors.Wrap(gqlErr, "while parsing GraphQL query")
+		}
+
+		for _, op := range doc.Operations {
+			if req.OperationName != "" && op.Name != req.OperationName {
+				continue
+			}
+
+			verb := ReadVerb
+			if op.Operation == ast.Mutation {
+				verb = WriteVerb
+			}
+
+			for _, name := range rootFieldNames(doc, op.SelectionSet, map[string]struct{}{}) {
+				_, namespaced := namespacedRootFields[name]
+				add(access{namespaced: namespaced, verb: verb})
+			}
+		}
+	}
+
+	if len(out) == 0 {
+		out = append(out, access{namespaced: true, verb: ReadVerb})
+	}
+
+	return out, nil
+}
+
+// rootFieldNames returns names of the fields selected directly on the operation, including the ones from fragments.
+// Aliases are ignored, as they don't change the executed field.
+func rootFieldNames(doc *ast.QueryDocument, set ast.SelectionSet, visitedFragments map[string]struct{}) []string {
+	var out []string
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			out = append(out, sel.Name)
+		case *ast.InlineFragment:
+			out = append(out, rootFieldNames(doc, sel.SelectionSet, visitedFragments)...)
+		case *ast.FragmentSpread:
+			if _, visited := visitedFragments[sel.Name]; visited {
+				continue
+			}
+			visitedFragments[sel.Name] = struct{}{}
+
+			fragment := doc.Fragments.ForName(sel.Name)
+			if fragment == nil {
+				continue
+			}
+			out = append(out, rootFieldNames(doc, fragment.SelectionSet, visitedFragments)...)
+		}
+	}
+	return out
+}
+
+func graphQLRequests(r *http.Request) ([]graphQLRequest, error) {
+	if r.Method == http.MethodGet {
+		return []graphQLRequest{
+			{
+				Query:         r.URL.Query().Get("query"),
+				OperationName: r.URL.Query().Get("operationName"),
+			},
+		}, nil
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading request body")
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// batched requests are sent as JSON array
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var requests []graphQLRequest
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return nil, errors.Wrap(err, "while unmarshaling batched GraphQL requests")
+		}
+		return requests, nil
+	}
+
+	var req graphQLRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling GraphQL request")
+	}
+
+	return []graphQLRequest{req}, nil
+}
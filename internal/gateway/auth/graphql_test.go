@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	namespacedRead  = access{namespaced: true, verb: ReadVerb}
+	namespacedWrite = access{namespaced: true, verb: WriteVerb}
+	clusterRead     = access{namespaced: false, verb: ReadVerb}
+	clusterWrite    = access{namespaced: false, verb: WriteVerb}
+)
+
+func TestRequestAccess(t *testing.T) {
+	const multiOperationQuery = `
+		query GetAction { action(name: "foo") { name } }
+		mutation DeleteAction { deleteAction(name: "foo") { name } }
+	`
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedAccess []access
+		expectedErr    string
+	}{
+		{
+			name:           "Query shorthand",
+			body:           graphQLBody(t, `{ actions { name } }`, ""),
+			expectedAccess: []access{namespacedRead},
+		},
+		{
+			name:           "Mutation",
+			body:           graphQLBody(t, `mutation { deleteAction(name: "foo") { name } }`, ""),
+			expectedAccess: []access{namespacedWrite},
+		},
+		{
+			name:           "Aliased mutation",
+			body:           graphQLBody(t, `mutation { getAction: deleteAction(name: "foo") { name } }`, ""),
+			expectedAccess: []access{namespacedWrite},
+		},
+		{
+			name:           "Query aliased as mutation",
+			body:           graphQLBody(t, `query { deleteAction: action(name: "foo") { name } }`, ""),
+			expectedAccess: []access{namespacedRead},
+		},
+		{
+			name: "Query with fragment",
+			body: graphQLBody(t, `
+				query { ...Actions }
+				fragment Actions on Query { actions { name } }
+			`, ""),
+			expectedAccess: []access{namespacedRead},
+		},
+		{
+			name: "Mutation with fragment",
+			body: graphQLBody(t, `
+				mutation { deleteAction(name: "foo") { ...ActionFields } }
+				fragment ActionFields on Action { name }
+			`, ""),
+			expectedAccess: []access{namespacedWrite},
+		},
+		{
+			name:           "Multiple operations with selected query",
+			body:           graphQLBody(t, multiOperationQuery, "GetAction"),
+			expectedAccess: []access{namespacedRead},
+		},
+		{
+			name:           "Multiple operations with selected mutation",
+			body:           graphQLBody(t, multiOperationQuery, "DeleteAction"),
+			expectedAccess: []access{namespacedWrite},
+		},
+		{
+			name:           "Multiple operations without selected operation",
+			body:           graphQLBody(t, multiOperationQuery, ""),
+			expectedAccess: []access{namespacedRead, namespacedWrite},
+		},
+		{
+			name: "Batched requests with mutation",
+			body: `[` +
+				graphQLBody(t, `{ actions { name } }`, "") + `,` +
+				graphQLBody(t, `mutation { deleteAction(name: "foo") { name } }`, "") +
+				`]`,
+			expectedAccess: []access{namespacedRead, namespacedWrite},
+		},
+		{
+			name:           "Hub query",
+			body:           graphQLBody(t, `{ typeInstances { id } }`, ""),
+			expectedAccess: []access{clusterRead},
+		},
+		{
+			name:           "Hub mutation",
+			body:           graphQLBody(t, `mutation { deleteTypeInstance(id: "foo") }`, ""),
+			expectedAccess: []access{clusterWrite},
+		},
+		{
+			name:           "Hub mutation aliased as Action mutation",
+			body:           graphQLBody(t, `mutation { deleteAction: deleteTypeInstance(id: "foo") }`, ""),
+			expectedAccess: []access{clusterWrite},
+		},
+		{
+			name:           "Hub mutation in inline fragment",
+			body:           graphQLBody(t, `mutation { ... on Mutation { lockTypeInstances(in: {ids: ["foo"], ownerID: "bar"}) } }`, ""),
+			expectedAccess: []access{clusterWrite},
+		},
+		{
+			name:           "Engine Policy mutation",
+			body:           graphQLBody(t, `mutation { updatePolicy(in: {}) { rules { interface { path } } } }`, ""),
+			expectedAccess: []access{clusterWrite},
+		},
+		{
+			name:           "Action and Hub queries",
+			body:           graphQLBody(t, `{ actions { name } typeInstances { id } __typename }`, ""),
+			expectedAccess: []access{namespacedRead, clusterRead},
+		},
+		{
+			name:        "Invalid query",
+			body:        graphQLBody(t, `mutation {`, ""),
+			expectedErr: "while parsing GraphQL query",
+		},
+		{
+			name:        "Invalid body",
+			body:        `{"query":`,
+			expectedErr: "while unmarshaling GraphQL request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body))
+
+			// when
+			accesses, err := requestAccess(req)
+
+			// then
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAccess, accesses)
+
+			body, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(body), "request body should be restored")
+		})
+	}
+}
+
+func TestRequestAccessForGETRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		operationName  string
+		expectedAccess []access
+	}{
+		{
+			name:           "Query",
+			query:          `{ actions { name } }`,
+			expectedAccess: []access{namespacedRead},
+		},
+		{
+			name:           "Mutation",
+			query:          `mutation { deleteAction(name: "foo") { name } }`,
+			expectedAccess: []access{namespacedWrite},
+		},
+		{
+			name:           "Multiple operations with selected query",
+			query:          `query GetAction { action(name: "foo") { name } } mutation DeleteAction { deleteAction(name: "foo") { name } }`,
+			operationName:  "GetAction",
+			expectedAccess: []access{namespacedRead},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			params := url.Values{}
+			params.Set("query", tt.query)
+			params.Set("operationName", tt.operationName)
+			req := httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+
+			// when
+			accesses, err := requestAccess(req)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAccess, accesses)
+		})
+	}
+}
+
+func graphQLBody(t *testing.T, query, operationName string) string {
+	t.Helper()
+
+	data, err := json.Marshal(graphQLRequest{Query: query, OperationName: operationName})
+	require.NoError(t, err)
+	return string(data)
+}
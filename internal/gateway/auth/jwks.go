@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// localKeySet verifies JWT signatures using public keys from a local JSON Web Key Set.
+// The key is selected based on the key ID from the token header.
+type localKeySet struct {
+	keys jose.JSONWebKeySet
+}
+
+// loadKeySetFromFile returns public keys from JSON Web Key Set file.
+// Keys which are not used for signature verification are skipped.
+func loadKeySetFromFile(path string) (*localKeySet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading JSON Web Key Set file")
+	}
+
+	var keySet jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling JSON Web Key Set")
+	}
+
+	out := &localKeySet{}
+	for _, key := range keySet.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		out.keys.Keys = append(out.keys.Keys, key.Public())
+	}
+
+	if len(out.keys.Keys) == 0 {
+		return nil, errors.New("JSON Web Key Set doesn't contain any signature verification keys")
+	}
+
+	return out, nil
+}
+
+// VerifySignature verifies the JWT signature and returns its payload.
+func (s *localKeySet) VerifySignature(_ context.Context, jwt string) ([]byte, error) {
+	jws, err := jose.ParseSigned(jwt)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing JWT")
+	}
+	if len(jws.Signatures) != 1 {
+		return nil, errors.New("token must have exactly one signature")
+	}
+
+	kid := jws.Signatures[0].Header.KeyID
+	keys := s.keys.Key(kid)
+	if len(keys) == 0 {
+		return nil, errors.Errorf("unknown key %q", kid)
+	}
+
+	payload, err := jws.Verify(keys[0].Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/pkg/errors"
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+// JWTConfig holds configuration for JWTAuthenticator.
+type JWTConfig struct {
+	// KeySetPath is a path to the JSON Web Key Set file with public keys used to verify tokens.
+	KeySetPath string `envconfig:"optional"`
+
+	// Issuer is the expected token issuer. If empty, the issuer is not verified.
+	Issuer string `envconfig:"optional"`
+
+	// Audience is the expected token audience. If empty, the audience is not verified.
+	Audience string `envconfig:"optional"`
+
+	// UsernameClaim is the name of the claim which holds username.
+	UsernameClaim string `envconfig:"default=sub"`
+
+	// GroupsClaim is the name of the claim which holds user groups.
+	GroupsClaim string `envconfig:"default=groups"`
+}
+
+// JWTAuthenticator authenticates the caller using JWT bearer tokens issued by an OIDC provider.
+// Tokens are verified against public keys from a local JSON Web Key Set file.
+type JWTAuthenticator struct {
+	cfg      JWTConfig
+	verifier *oidc.IDTokenVerifier
+	now      func() time.Time
+}
+
+// NewJWTAuthenticator returns a new JWTAuthenticator instance.
+func NewJWTAuthenticator(cfg JWTConfig) (*JWTAuthenticator, error) {
+	keySet, err := loadKeySetFromFile(cfg.KeySetPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading JSON Web Key Set")
+	}
+
+	a := &JWTAuthenticator{
+		cfg: cfg,
+		now: time.Now,
+	}
+	a.verifier = oidc.NewVerifier(cfg.Issuer, keySet, &oidc.Config{
+		ClientID:             cfg.Audience,
+		SkipClientIDCheck:    cfg.Audience == "",
+		SkipIssuerCheck:      cfg.Issuer == "",
+		SupportedSigningAlgs: []string{oidc.RS256, oidc.RS384, oidc.RS512, oidc.ES256, oidc.ES384, oidc.ES512},
+		Now: func() time.Time {
+			return a.now()
+		},
+	})
+
+	return a, nil
+}
+
+// Authenticate returns the caller identity if the request bearer token is a valid JWT.
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (authv1.UserInfo, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return authv1.UserInfo{}, ErrNoCredentials
+	}
+
+	idToken, err := a.verifier.Verify(r.Context(), token)
+	if err != nil {
+		return authv1.UserInfo{}, errors.Wrap(err, "while verifying JWT")
+	}
+
+	claims := map[string]interface{}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return authv1.UserInfo{}, errors.Wrap(err, "while decoding JWT claims")
+	}
+
+	username, ok := claims[a.cfg.UsernameClaim].(string)
+	if !ok || username == "" {
+		return authv1.UserInfo{}, errors.Errorf("missing %q claim", a.cfg.UsernameClaim)
+	}
+
+	return authv1.UserInfo{
+		Username: username,
+		Groups:   stringsFromClaim(claims[a.cfg.GroupsClaim]),
+	}, nil
+}
+
+// stringsFromClaim returns strings from a claim which is either a single string or an array of strings.
+func stringsFromClaim(claim interface{}) []string {
+	switch val := claim.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		var out []string
+		for _, item := range val {
+			if str, ok := item.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	// given
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keySetPath := writeKeySet(t, []jose.JSONWebKey{
+		{Key: &rsaKey.PublicKey, KeyID: "rsa", Use: "sig"},
+		{Key: &ecKey.PublicKey, KeyID: "ec", Use: "sig"},
+		// keys used for encryption are ignored
+		{Key: &mustGenerateRSAKey(t).PublicKey, KeyID: "enc", Use: "enc"},
+	})
+
+	now := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	validClaims := map[string]interface{}{
+		"sub":    "alice",
+		"groups": []string{"admins", "devs"},
+		"iss":    "https://issuer.local",
+		"aud":    []string{"capact"},
+		"exp":    now.Add(time.Hour).Unix(),
+	}
+
+	authenticator, err := NewJWTAuthenticator(JWTConfig{
+		KeySetPath:    keySetPath,
+		Issuer:        "https://issuer.local",
+		Audience:      "capact",
+		UsernameClaim: "sub",
+		GroupsClaim:   "groups",
+	})
+	require.NoError(t, err)
+	authenticator.now = func() time.Time { return now }
+
+	expectedUser := authv1.UserInfo{
+		Username: "alice",
+		Groups:   []string{"admins", "devs"},
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		expectedErr string
+	}{
+		{
+			name:  "Valid RSA token",
+			token: sign(t, jose.RS256, rsaKey, "rsa", validClaims),
+		},
+		{
+			name:  "Valid EC token",
+			token: sign(t, jose.ES256, ecKey, "ec", validClaims),
+		},
+		{
+			name:        "Unknown key",
+			token:       sign(t, jose.RS256, rsaKey, "other", validClaims),
+			expectedErr: `unknown key "other"`,
+		},
+		{
+			name:        "Encryption key",
+			token:       sign(t, jose.RS256, rsaKey, "enc", validClaims),
+			expectedErr: `unknown key "enc"`,
+		},
+		{
+			name:        "Signed with different key",
+			token:       sign(t, jose.RS256, mustGenerateRSAKey(t), "rsa", validClaims),
+			expectedErr: "invalid signature",
+		},
+		{
+			name:        "Unsupported signing algorithm",
+			token:       sign(t, jose.PS256, rsaKey, "rsa", validClaims),
+			expectedErr: "while verifying JWT",
+		},
+		{
+			name:        "Expired token",
+			token:       sign(t, jose.RS256, rsaKey, "rsa", withClaim(validClaims, "exp", now.Add(-time.Minute).Unix())),
+			expectedErr: "token is expired",
+		},
+		{
+			name:        "Wrong issuer",
+			token:       sign(t, jose.RS256, rsaKey, "rsa", withClaim(validClaims, "iss", "https://other.local")),
+			expectedErr: "issued by a different provider",
+		},
+		{
+			name:        "Wrong audience",
+			token:       sign(t, jose.RS256, rsaKey, "rsa", withClaim(validClaims, "aud", "other")),
+			expectedErr: `expected audience "capact"`,
+		},
+		{
+			name:        "Missing username claim",
+			token:       sign(t, jose.RS256, rsaKey, "rsa", withClaim(validClaims, "sub", "")),
+			expectedErr: `missing "sub" claim`,
+		},
+	}
+	for _, tc := range tests {
+		testCase := tc
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/graphql", nil)
+			req.Header.Set("Authorization", "Bearer "+testCase.token)
+
+			// when
+			usr, err := authenticator.Authenticate(req)
+
+			// then
+			if testCase.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expectedUser, usr)
+		})
+	}
+
+	t.Run("No bearer token", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/graphql", nil)
+		req.SetBasicAuth("graphql", "t0p_s3cr3t")
+
+		// when
+		_, err := authenticator.Authenticate(req)
+
+		// then
+		assert.Equal(t, ErrNoCredentials, err)
+	})
+}
+
+func writeKeySet(t *testing.T, keys []jose.JSONWebKey) string {
+	t.Helper()
+
+	data, err := json.Marshal(jose.JSONWebKeySet{Keys: keys})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+
+	return path
+}
+
+func withClaim(claims map[string]interface{}, name string, value interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range claims {
+		out[k] = v
+	}
+	out[name] = value
+	return out
+}
+
+func mustGenerateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func sign(t *testing.T, alg jose.SignatureAlgorithm, key interface{}, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: alg,
+		Key:       jose.JSONWebKey{Key: key, KeyID: kid},
+	}, nil)
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+
+	token, err := jws.CompactSerialize()
+	require.NoError(t, err)
+
+	return token
+}
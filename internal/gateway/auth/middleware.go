@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"capact.io/capact/internal/gateway/header"
+	"capact.io/capact/internal/k8s-engine/graphql/namespace"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Middleware provides functionality to authenticate and authorize GraphQL requests.
+// The identity of authorized caller is passed to remote schemas in HTTP headers.
+type Middleware struct {
+	log           *zap.Logger
+	authenticator Authenticator
+	authorizer    Authorizer
+}
+
+// NewMiddleware returns a new Middleware instance.
+func NewMiddleware(log *zap.Logger, authenticator Authenticator, authorizer Authorizer) *Middleware {
+	return &Middleware{
+		log:           log,
+		authenticator: authenticator,
+		authorizer:    authorizer,
+	}
+}
+
+// Handle authenticates and authorizes the caller before passing request to next handlers.
+// GET requests without GraphQL query, such as GraphQL Playground, are not authenticated.
+func (m *Middleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			// the caller identity is forwarded to remote schemas, so it cannot be provided by the caller
+			header.DeleteUser(r.Header)
+
+			if r.Method == http.MethodGet && r.URL.Query().Get("query") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			usr, err := m.authenticator.Authenticate(r)
+			if err != nil {
+				m.log.Debug("Authentication failed", zap.Error(err))
+				m.writeError(w, m.authenticationErrMsg(err))
+				return
+			}
+
+			accesses, err := requestAccess(r)
+			if err != nil {
+				m.writeError(w, err.Error())
+				return
+			}
+
+			ns := r.Header.Get(namespace.NamespaceHeaderName)
+			if ns == "" {
+				ns = namespace.DefaultNamespace
+			}
+
+			for _, acc := range accesses {
+				// the Namespace header applies only to operations on namespaced Actions
+				scope := ClusterScope
+				if acc.namespaced {
+					scope = ns
+				}
+
+				if err := m.authorizer.Authorize(usr, scope, acc.verb); err != nil {
+					m.log.Info("Authorization failed", zap.String("username", usr.Username), zap.Error(err))
+					m.writeError(w, err.Error())
+					return
+				}
+			}
+
+			header.SetUser(r.Header, usr.Username, usr.Groups)
+			next.ServeHTTP(w, r)
+		},
+	)
+}
+
+func (m *Middleware) authenticationErrMsg(err error) string {
+	if errors.Is(err, ErrNoCredentials) {
+		return ErrNoCredentials.Error()
+	}
+	return ErrInvalidCredentials.Error()
+}
+
+// writeError writes GraphQL error. Status code is always 200 as GraphQL clients expect errors in the response body.
+func (m *Middleware) writeError(w http.ResponseWriter, message string) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{
+				"message": message,
+			},
+		},
+	})
+	if err != nil {
+		m.log.Info("failed to write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,217 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"capact.io/capact/internal/gateway/auth"
+	"capact.io/capact/internal/gateway/header"
+	"capact.io/capact/internal/k8s-engine/graphql/namespace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMiddleware_Handle(t *testing.T) {
+	const (
+		readQuery     = `{"query":"{ actions { name } }"}`
+		writeQuery    = `{"query":"mutation { deleteAction(name: \"foo\") { name } }"}`
+		hubReadQuery  = `{"query":"{ typeInstances { id } }"}`
+		hubWriteQuery = `{"query":"mutation { deleteTypeInstance(id: \"foo\") }"}`
+		teamNamespace = "team-a"
+		username      = "graphql"
+		password      = "t0p_s3cr3t"
+		otherUsername = "admin"
+	)
+
+	authorizer := auth.NewRBACAuthorizer(auth.RBACPolicy{
+		Rules: []auth.RBACRule{
+			{
+				Groups:     []string{"admins"},
+				Namespaces: []string{"*"},
+				Verbs:      []auth.Verb{"*"},
+			},
+			{
+				Users:      []string{otherUsername},
+				Namespaces: []string{"*"},
+				Verbs:      []auth.Verb{"*"},
+			},
+			{
+				Users:      []string{username},
+				Namespaces: []string{namespace.DefaultNamespace},
+				Verbs:      []auth.Verb{auth.ReadVerb},
+			},
+			{
+				Users:      []string{username},
+				Namespaces: []string{teamNamespace},
+				Verbs:      []auth.Verb{"*"},
+			},
+		},
+	})
+	middleware := auth.NewMiddleware(zap.NewNop(), auth.NewBasicAuthenticator(username, password), authorizer)
+
+	tests := []struct {
+		name             string
+		method           string
+		target           string
+		body             string
+		headers          map[string]string
+		withCredentials  bool
+		expectedNextUser string
+		expectedGroups   string
+		expectedErr      string
+	}{
+		{
+			name:             "Authenticated query",
+			method:           http.MethodPost,
+			body:             readQuery,
+			withCredentials:  true,
+			expectedNextUser: username,
+		},
+		{
+			name:   "Spoofed identity headers are replaced with authenticated user",
+			method: http.MethodPost,
+			body:   readQuery,
+			headers: map[string]string{
+				header.UsernameHeaderName: otherUsername,
+				header.GroupsHeaderName:   "admins",
+			},
+			withCredentials:  true,
+			expectedNextUser: username,
+		},
+		{
+			name:   "Spoofed identity headers don't grant write access",
+			method: http.MethodPost,
+			body:   writeQuery,
+			headers: map[string]string{
+				header.UsernameHeaderName: otherUsername,
+				header.GroupsHeaderName:   "admins",
+			},
+			withCredentials: true,
+			expectedErr:     `user "graphql" cannot write in Namespace "default": forbidden`,
+		},
+		{
+			name:   "Spoofed identity headers are removed for GraphQL Playground",
+			method: http.MethodGet,
+			headers: map[string]string{
+				header.UsernameHeaderName: otherUsername,
+				header.GroupsHeaderName:   "admins",
+			},
+			expectedNextUser: "",
+		},
+		{
+			name:   "Spoofed identity headers without credentials",
+			method: http.MethodPost,
+			body:   readQuery,
+			headers: map[string]string{
+				header.UsernameHeaderName: otherUsername,
+				header.GroupsHeaderName:   "admins",
+			},
+			expectedErr: auth.ErrNoCredentials.Error(),
+		},
+		{
+			name:   "Query in other Namespace",
+			method: http.MethodPost,
+			body:   readQuery,
+			headers: map[string]string{
+				namespace.NamespaceHeaderName: "production",
+			},
+			withCredentials: true,
+			expectedErr:     `user "graphql" cannot read in Namespace "production": forbidden`,
+		},
+		{
+			name:   "Action mutation in Namespace with write access",
+			method: http.MethodPost,
+			body:   writeQuery,
+			headers: map[string]string{
+				namespace.NamespaceHeaderName: teamNamespace,
+			},
+			withCredentials:  true,
+			expectedNextUser: username,
+		},
+		{
+			name:   "Namespace-scoped role cannot run Hub mutation",
+			method: http.MethodPost,
+			body:   hubWriteQuery,
+			headers: map[string]string{
+				namespace.NamespaceHeaderName: teamNamespace,
+			},
+			withCredentials: true,
+			expectedErr:     `user "graphql" cannot write cluster-scoped resources: forbidden`,
+		},
+		{
+			name:   "Namespace-scoped role cannot run Hub query",
+			method: http.MethodPost,
+			body:   hubReadQuery,
+			headers: map[string]string{
+				namespace.NamespaceHeaderName: teamNamespace,
+			},
+			withCredentials: true,
+			expectedErr:     `user "graphql" cannot read cluster-scoped resources: forbidden`,
+		},
+		{
+			name:            "Wrong credentials",
+			method:          http.MethodPost,
+			body:            readQuery,
+			headers:         map[string]string{"Authorization": "Basic d3Jvbmc6d3Jvbmc="},
+			withCredentials: false,
+			expectedErr:     auth.ErrInvalidCredentials.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			req := httptest.NewRequest(tt.method, "/graphql", strings.NewReader(tt.body))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if tt.withCredentials {
+				req.SetBasicAuth(username, password)
+			}
+
+			var (
+				nextCalled bool
+				nextUser   string
+				nextGroups string
+			)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				nextUser = r.Header.Get(header.UsernameHeaderName)
+				nextGroups = r.Header.Get(header.GroupsHeaderName)
+			})
+			rec := httptest.NewRecorder()
+
+			// when
+			middleware.Handle(next).ServeHTTP(rec, req)
+
+			// then
+			if tt.expectedErr != "" {
+				assert.False(t, nextCalled)
+				assert.Equal(t, tt.expectedErr, graphQLErrorMessage(t, rec))
+				return
+			}
+
+			require.True(t, nextCalled)
+			assert.Equal(t, tt.expectedNextUser, nextUser)
+			assert.Equal(t, tt.expectedGroups, nextGroups)
+		})
+	}
+}
+
+func graphQLErrorMessage(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var resp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp.Errors, 1)
+
+	return resp.Errors[0].Message
+}
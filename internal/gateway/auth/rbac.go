@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	authv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Verb defines an operation type performed by the caller.
+type Verb string
+
+const (
+	// ReadVerb is used for GraphQL queries.
+	ReadVerb Verb = "read"
+	// WriteVerb is used for GraphQL mutations.
+	WriteVerb Verb = "write"
+
+	// ClusterScope is passed to Authorizer instead of the Namespace for cluster-scoped operations,
+	// such as Hub queries and mutations. Only rules with the `*` Namespace allow them.
+	ClusterScope = ""
+
+	wildcard = "*"
+)
+
+// ErrForbidden defines an error indicating that the caller is not allowed to perform a given operation.
+var ErrForbidden = errors.New("forbidden")
+
+// Authorizer decides whether the caller can perform an operation in a given Namespace or in the ClusterScope.
+type Authorizer interface {
+	Authorize(usr authv1.UserInfo, namespace string, verb Verb) error
+}
+
+// AllowAllAuthorizer allows all authenticated callers to perform any operation.
+type AllowAllAuthorizer struct{}
+
+// Authorize always allows the operation.
+func (AllowAllAuthorizer) Authorize(authv1.UserInfo, string, Verb) error {
+	return nil
+}
+
+// RBACRule grants verbs in Namespaces to a given set of users and groups.
+// The `*` value matches all users, groups, Namespaces or verbs. The `*` Namespace matches also cluster-scoped operations.
+type RBACRule struct {
+	Users      []string `json:"users"`
+	Groups     []string `json:"groups"`
+	Namespaces []string `json:"namespaces"`
+	Verbs      []Verb   `json:"verbs"`
+}
+
+// RBACPolicy holds the RBAC rules.
+type RBACPolicy struct {
+	Rules []RBACRule `json:"rules"`
+}
+
+// RBACAuthorizer authorizes the caller based on the RBAC policy.
+type RBACAuthorizer struct {
+	policy RBACPolicy
+}
+
+// NewRBACAuthorizer returns a new RBACAuthorizer instance.
+func NewRBACAuthorizer(policy RBACPolicy) *RBACAuthorizer {
+	return &RBACAuthorizer{policy: policy}
+}
+
+// NewRBACAuthorizerFromFile returns a new RBACAuthorizer instance with the RBAC policy loaded from a given YAML file.
+func NewRBACAuthorizerFromFile(path string) (*RBACAuthorizer, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading RBAC policy file")
+	}
+
+	var policy RBACPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling RBAC policy")
+	}
+
+	return NewRBACAuthorizer(policy), nil
+}
+
+// Authorize returns ErrForbidden error if none of the rules allows the caller to perform a given operation.
+func (a *RBACAuthorizer) Authorize(usr authv1.UserInfo, namespace string, verb Verb) error {
+	for _, rule := range a.policy.Rules {
+		if !rule.matchesSubject(usr) {
+			continue
+		}
+		if !matches(rule.Namespaces, namespace) {
+			continue
+		}
+		if !matchesVerb(rule.Verbs, verb) {
+			continue
+		}
+		return nil
+	}
+
+	if namespace == ClusterScope {
+		return errors.Wrapf(ErrForbidden, "user %q cannot %s cluster-scoped resources", usr.Username, verb)
+	}
+	return errors.Wrapf(ErrForbidden, "user %q cannot %s in Namespace %q", usr.Username, verb, namespace)
+}
+
+func (r RBACRule) matchesSubject(usr authv1.UserInfo) bool {
+	if matches(r.Users, usr.Username) {
+		return true
+	}
+
+	for _, group := range usr.Groups {
+		if matches(r.Groups, group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesVerb(verbs []Verb, verb Verb) bool {
+	for _, v := range verbs {
+		if v == verb || v == wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == wildcard {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package auth_test
+
+import (
+	"testing"
+
+	"capact.io/capact/internal/gateway/auth"
+
+	"github.com/stretchr/testify/assert"
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+func TestRBACAuthorizer_Authorize(t *testing.T) {
+	// given
+	authorizer := auth.NewRBACAuthorizer(auth.RBACPolicy{
+		Rules: []auth.RBACRule{
+			{
+				Groups:     []string{"admins"},
+				Namespaces: []string{"*"},
+				Verbs:      []auth.Verb{"*"},
+			},
+			{
+				Users:      []string{"alice"},
+				Namespaces: []string{"team-a"},
+				Verbs:      []auth.Verb{auth.ReadVerb},
+			},
+		},
+	})
+
+	tests := []struct {
+		name        string
+		user        authv1.UserInfo
+		namespace   string
+		verb        auth.Verb
+		expectedErr string
+	}{
+		{
+			name:      "Admin group can write in any Namespace",
+			user:      authv1.UserInfo{Username: "bob", Groups: []string{"devs", "admins"}},
+			namespace: "production",
+			verb:      auth.WriteVerb,
+		},
+		{
+			name:      "User can read in allowed Namespace",
+			user:      authv1.UserInfo{Username: "alice"},
+			namespace: "team-a",
+			verb:      auth.ReadVerb,
+		},
+		{
+			name:        "User cannot write in allowed Namespace",
+			user:        authv1.UserInfo{Username: "alice"},
+			namespace:   "team-a",
+			verb:        auth.WriteVerb,
+			expectedErr: `user "alice" cannot write in Namespace "team-a": forbidden`,
+		},
+		{
+			name:        "User cannot read in other Namespace",
+			user:        authv1.UserInfo{Username: "alice"},
+			namespace:   "team-b",
+			verb:        auth.ReadVerb,
+			expectedErr: `user "alice" cannot read in Namespace "team-b": forbidden`,
+		},
+		{
+			name:      "Admin group can write cluster-scoped resources",
+			user:      authv1.UserInfo{Username: "bob", Groups: []string{"admins"}},
+			namespace: auth.ClusterScope,
+			verb:      auth.WriteVerb,
+		},
+		{
+			name:        "User cannot read cluster-scoped resources",
+			user:        authv1.UserInfo{Username: "alice"},
+			namespace:   auth.ClusterScope,
+			verb:        auth.ReadVerb,
+			expectedErr: `user "alice" cannot read cluster-scoped resources: forbidden`,
+		},
+		{
+			name:        "Unknown user",
+			user:        authv1.UserInfo{Username: "eve"},
+			namespace:   "default",
+			verb:        auth.ReadVerb,
+			expectedErr: `user "eve" cannot read in Namespace "default": forbidden`,
+		},
+	}
+	for _, tc := range tests {
+		testCase := tc
+		t.Run(testCase.name, func(t *testing.T) {
+			// when
+			err := authorizer.Authorize(testCase.user, testCase.namespace, testCase.verb)
+
+			// then
+			if testCase.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, testCase.expectedErr)
+			assert.ErrorIs(t, err, auth.ErrForbidden)
+		})
+	}
+}
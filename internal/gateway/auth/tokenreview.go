@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+)
+
+// TokenReviewAuthenticator authenticates the caller bearer token using the Kubernetes TokenReview API.
+type TokenReviewAuthenticator struct {
+	cli       authenticationv1client.TokenReviewInterface
+	audiences []string
+}
+
+// NewTokenReviewAuthenticator returns a new TokenReviewAuthenticator instance.
+func NewTokenReviewAuthenticator(cli authenticationv1client.TokenReviewInterface, audiences []string) *TokenReviewAuthenticator {
+	return &TokenReviewAuthenticator{
+		cli:       cli,
+		audiences: audiences,
+	}
+}
+
+// Authenticate returns the caller identity if Kubernetes authenticated the request bearer token.
+func (a *TokenReviewAuthenticator) Authenticate(r *http.Request) (authv1.UserInfo, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return authv1.UserInfo{}, ErrNoCredentials
+	}
+
+	review := &authv1.TokenReview{
+		Spec: authv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: a.audiences,
+		},
+	}
+
+	out, err := a.cli.Create(r.Context(), review, metav1.CreateOptions{})
+	if err != nil {
+		return authv1.UserInfo{}, errors.Wrap(err, "while creating TokenReview")
+	}
+
+	if !out.Status.Authenticated {
+		return authv1.UserInfo{}, errors.Errorf("token not authenticated by Kubernetes: %s", out.Status.Error)
+	}
+
+	return out.Status.User, nil
+}
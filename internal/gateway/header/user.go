@@ -0,0 +1,56 @@
+package header
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	// UsernameHeaderName defines HTTP header name where Gateway stores the username of authenticated caller.
+	UsernameHeaderName = "X-Capact-Username"
+	// GroupsHeaderName defines HTTP header name where Gateway stores comma-separated groups of authenticated caller.
+	GroupsHeaderName = "X-Capact-Groups"
+
+	groupsSeparator = ","
+)
+
+// SetUser overrides the caller identity headers with a given authenticated user data.
+// Any identity headers provided by the caller are removed, so they cannot be spoofed.
+func SetUser(headers http.Header, username string, groups []string) {
+	DeleteUser(headers)
+
+	if username == "" {
+		return
+	}
+
+	headers.Set(UsernameHeaderName, username)
+	if len(groups) > 0 {
+		headers.Set(GroupsHeaderName, strings.Join(groups, groupsSeparator))
+	}
+}
+
+// DeleteUser removes the caller identity headers.
+func DeleteUser(headers http.Header) {
+	headers.Del(UsernameHeaderName)
+	headers.Del(GroupsHeaderName)
+}
+
+// GetUser returns the caller identity stored in headers.
+// If username is not set, it returns empty string.
+func GetUser(headers http.Header) (string, []string) {
+	username := headers.Get(UsernameHeaderName)
+	if username == "" {
+		return "", nil
+	}
+
+	var groups []string
+	for _, group := range strings.Split(headers.Get(GroupsHeaderName), groupsSeparator) {
+		group = strings.TrimSpace(group)
+		if group == "" {
+			continue
+		}
+		groups = append(groups, group)
+	}
+
+	return username, groups
+}
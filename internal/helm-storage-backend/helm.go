@@ -1,14 +1,24 @@
 package helmstoragebackend
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"capact.io/capact/internal/ptr"
 )
 
-const defaultHelmDriver = "secrets"
+const (
+	defaultHelmDriver       = "secrets"
+	latestRevisionIndicator = 0
+)
 
 type actionConfigurationProducerFn func(flags *genericclioptions.ConfigFlags, driver string, ns string) (*action.Configuration, error)
 
@@ -34,3 +44,79 @@ func ActionConfigurationProducer(flags *genericclioptions.ConfigFlags, driver, n
 
 	return actionConfig, nil
 }
+
+// releaseFetcher fetches Helm releases described by the TypeInstance context.
+type releaseFetcher struct {
+	helmCfgFlags                *genericclioptions.ConfigFlags
+	actionConfigurationProducer actionConfigurationProducerFn
+}
+
+func newReleaseFetcher(helmCfgFlags *genericclioptions.ConfigFlags) releaseFetcher {
+	return releaseFetcher{
+		helmCfgFlags:                helmCfgFlags,
+		actionConfigurationProducer: ActionConfigurationProducer,
+	}
+}
+
+func (f *releaseFetcher) getReleaseContext(contextBytes []byte) (*ReleaseContext, error) {
+	var ctx ReleaseContext
+	err := json.Unmarshal(contextBytes, &ctx)
+	if err != nil {
+		return nil, gRPCInternalError(errors.Wrap(err, "while unmarshaling context"))
+	}
+
+	return &ctx, nil
+}
+
+func (f *releaseFetcher) fetchHelmRelease(ti string, ctx []byte) (*release.Release, *ReleaseContext, error) {
+	relCtx, err := f.getReleaseContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rel, err := f.fetchHelmReleaseForContext(ti, relCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rel, relCtx, nil
+}
+
+func (f *releaseFetcher) fetchHelmReleaseForContext(ti string, relCtx *ReleaseContext) (*release.Release, error) {
+	if relCtx.Driver == nil {
+		relCtx.Driver = ptr.String(defaultHelmDriver)
+	}
+
+	helmGet, err := f.newHelmGet(f.helmCfgFlags, *relCtx.Driver, relCtx.Namespace)
+	if err != nil {
+		return nil, gRPCInternalError(errors.Wrap(err, "while creating Helm get release client"))
+	}
+
+	// NOTE: req.resourceVersion is ignored on purpose.
+	// Based on our contract we always return the latest Helm release revision.
+	helmGet.Version = latestRevisionIndicator
+
+	rel, err := helmGet.Run(relCtx.Name)
+	switch {
+	case err == nil:
+	case errors.Is(err, driver.ErrReleaseNotFound):
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Helm release '%s/%s' for TypeInstance '%s' was not found", relCtx.Namespace, relCtx.Name, ti))
+	default:
+		return nil, gRPCInternalError(errors.Wrap(err, "while fetching Helm release"))
+	}
+
+	return rel, nil
+}
+
+func (f *releaseFetcher) newHelmGet(flags *genericclioptions.ConfigFlags, driver, ns string) (*action.Get, error) {
+	actionConfig, err := f.actionConfigurationProducer(flags, driver, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	return action.NewGet(actionConfig), nil
+}
+
+func gRPCInternalError(err error) error {
+	return status.Error(codes.Internal, err.Error())
+}
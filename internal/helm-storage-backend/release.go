@@ -3,15 +3,10 @@ package helmstoragebackend
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/release"
-	"helm.sh/helm/v3/pkg/storage/driver"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"capact.io/capact/internal/ptr"
@@ -20,7 +15,34 @@ import (
 
 var _ pb.StorageBackendServer = &ReleaseHandler{}
 
-const latestRevisionIndicator = 0
+const watchValuePollInterval = 10 * time.Second
+
+// ReleaseContextSchema is the JSON schema of the Helm release storage backend context.
+const ReleaseContextSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema",
+  "type": "object",
+  "required": ["name", "namespace", "chartLocation"],
+  "properties": {
+    "name": {
+      "$id": "#/properties/context/properties/name",
+      "type": "string"
+    },
+    "namespace": {
+      "$id": "#/properties/context/properties/namespace",
+      "type": "string"
+    },
+    "chartLocation": {
+      "$id": "#/properties/context/properties/chartLocation",
+      "type": "string"
+    },
+    "driver": {
+      "$id": "#/properties/context/properties/driver",
+      "type": "string",
+      "default": "secrets"
+    }
+  },
+  "additionalProperties": false
+}`
 
 type (
 	// ReleaseDetails holds Helm release details.
@@ -60,17 +82,16 @@ type (
 type ReleaseHandler struct {
 	pb.UnimplementedStorageBackendServer
 
-	log                         *zap.Logger
-	helmCfgFlags                *genericclioptions.ConfigFlags
-	actionConfigurationProducer actionConfigurationProducerFn
+	releaseFetcher
+
+	log *zap.Logger
 }
 
 // NewReleaseHandler returns new ReleaseHandler.
 func NewReleaseHandler(log *zap.Logger, helmCfgFlags *genericclioptions.ConfigFlags) (*ReleaseHandler, error) {
 	return &ReleaseHandler{
-		log:                         log,
-		helmCfgFlags:                helmCfgFlags,
-		actionConfigurationProducer: ActionConfigurationProducer,
+		log:            log,
+		releaseFetcher: newReleaseFetcher(helmCfgFlags),
 	}, nil
 }
 
@@ -139,56 +160,32 @@ func (h *ReleaseHandler) OnUnlock(_ context.Context, _ *pb.OnUnlockRequest) (*pb
 	return &pb.OnUnlockResponse{}, nil
 }
 
-func (h *ReleaseHandler) getReleaseContext(contextBytes []byte) (*ReleaseContext, error) {
-	var ctx ReleaseContext
-	err := json.Unmarshal(contextBytes, &ctx)
-	if err != nil {
-		return nil, h.internalError(errors.Wrap(err, "while unmarshaling context"))
-	}
-
-	if ctx.Driver == nil {
-		ctx.Driver = ptr.String(defaultHelmDriver)
-	}
-
-	return &ctx, nil
+// GetValues returns values for given TypeInstances in the same order as requested.
+func (h *ReleaseHandler) GetValues(ctx context.Context, req *pb.GetValuesRequest) (*pb.GetValuesResponse, error) {
+	return pb.GetValuesSequentially(ctx, req, h.GetValue)
 }
 
-func (h *ReleaseHandler) fetchHelmRelease(ti string, ctx []byte) (*release.Release, *ReleaseContext, error) {
-	relCtx, err := h.getReleaseContext(ctx)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	helmGet, err := h.newHelmGet(h.helmCfgFlags, *relCtx.Driver, relCtx.Namespace)
-	if err != nil {
-		return nil, nil, h.internalError(errors.Wrap(err, "while creating Helm get release client"))
-	}
-
-	// NOTE: req.resourceVersion is ignored on purpose.
-	// Based on our contract we always return the latest Helm release revision.
-	helmGet.Version = latestRevisionIndicator
-
-	rel, err := helmGet.Run(relCtx.Name)
-	switch {
-	case err == nil:
-	case errors.Is(err, driver.ErrReleaseNotFound):
-		return nil, nil, status.Error(codes.NotFound, fmt.Sprintf("Helm release '%s/%s' for TypeInstance '%s' was not found", relCtx.Namespace, relCtx.Name, ti))
-	default:
-		return nil, nil, h.internalError(errors.Wrap(err, "while fetching Helm release"))
-	}
-
-	return rel, relCtx, nil
+// OnLockBatch is NOP.
+func (h *ReleaseHandler) OnLockBatch(_ context.Context, _ *pb.OnLockBatchRequest) (*pb.OnLockBatchResponse, error) {
+	return &pb.OnLockBatchResponse{}, nil
 }
 
-func (h *ReleaseHandler) newHelmGet(flags *genericclioptions.ConfigFlags, driver, ns string) (*action.Get, error) {
-	actionConfig, err := h.actionConfigurationProducer(flags, driver, ns)
-	if err != nil {
-		return nil, err
-	}
+// OnUnlockBatch is NOP.
+func (h *ReleaseHandler) OnUnlockBatch(_ context.Context, _ *pb.OnUnlockBatchRequest) (*pb.OnUnlockBatchResponse, error) {
+	return &pb.OnUnlockBatchResponse{}, nil
+}
 
-	return action.NewGet(actionConfig), nil
+// WatchValue streams a value for a given TypeInstance. The value is sent immediately and then each time
+// the Helm release details change, e.g. after upgrading the release to a new chart version.
+func (h *ReleaseHandler) WatchValue(req *pb.WatchValueRequest, stream pb.StorageBackend_WatchValueServer) error {
+	return pb.WatchValueByPolling(stream.Context(), req, watchValuePollInterval, h.GetValue, stream.Send)
 }
 
-func (h *ReleaseHandler) internalError(err error) error {
-	return status.Error(codes.Internal, err.Error())
+// GetCapabilities returns the features supported by the Helm release storage backend.
+// The backend only exposes the details of Helm releases installed outside of it, so it doesn't store values nor locks.
+func (h *ReleaseHandler) GetCapabilities(_ context.Context, _ *pb.GetCapabilitiesRequest) (*pb.GetCapabilitiesResponse, error) {
+	return &pb.GetCapabilitiesResponse{
+		ReadOnly:      true,
+		ContextSchema: ptr.String(ReleaseContextSchema),
+	}, nil
 }
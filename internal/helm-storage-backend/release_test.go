@@ -10,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	"google.golang.org/grpc"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	kubefake "helm.sh/helm/v3/pkg/kube/fake"
@@ -447,6 +449,18 @@ func TestRelease_NOP_Methods(t *testing.T) {
 				return svc.OnUnlock(ctx, nil)
 			},
 		},
+		{
+			name: "no operation for OnLockBatch",
+			handler: func(ctx context.Context, svc *ReleaseHandler) (interface{}, error) {
+				return svc.OnLockBatch(ctx, nil)
+			},
+		},
+		{
+			name: "no operation for OnUnlockBatch",
+			handler: func(ctx context.Context, svc *ReleaseHandler) (interface{}, error) {
+				return svc.OnUnlockBatch(ctx, nil)
+			},
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -473,6 +487,146 @@ func TestRelease_NOP_Methods(t *testing.T) {
 	}
 }
 
+func TestRelease_GetCapabilities(t *testing.T) {
+	// given
+	svc, err := NewReleaseHandler(logger.Noop(), nil)
+	require.NoError(t, err)
+
+	// when
+	res, err := svc.GetCapabilities(context.Background(), &pb.GetCapabilitiesRequest{})
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, res.ValueStorage)
+	assert.False(t, res.Locking)
+	assert.False(t, res.Versioning)
+	assert.True(t, res.ReadOnly)
+	require.NotNil(t, res.ContextSchema)
+
+	schema := gojsonschema.NewStringLoader(*res.ContextSchema)
+	relCtx := mustMarshal(t, ReleaseContext{
+		Name:          "test-release",
+		Namespace:     "test-namespace",
+		ChartLocation: "http://example.com/charts",
+		Driver:        ptr.String("configmaps"),
+	})
+
+	result, err := gojsonschema.Validate(schema, gojsonschema.NewBytesLoader(relCtx))
+	require.NoError(t, err)
+	assert.True(t, result.Valid(), result.Errors())
+
+	result, err = gojsonschema.Validate(schema, gojsonschema.NewStringLoader(`{"name":"test-release"}`))
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+}
+
+func TestRelease_GetValues(t *testing.T) {
+	// given
+	const (
+		releaseName      = "test-get-release"
+		releaseNamespace = "test-get-namespace"
+		chartLocation    = "http://example.com/charts"
+	)
+	expHelmRelease := fixHelmRelease(releaseName, releaseNamespace)
+	expFlags := &genericclioptions.ConfigFlags{ClusterName: ptr.String("testing")}
+
+	relCtx := mustMarshal(t, ReleaseContext{
+		Name:          releaseName,
+		Namespace:     releaseNamespace,
+		ChartLocation: chartLocation,
+	})
+	expValue := mustMarshal(t, ReleaseDetails{
+		Name:      expHelmRelease.Name,
+		Namespace: expHelmRelease.Namespace,
+		Chart: ChartDetails{
+			Name:    expHelmRelease.Chart.Metadata.Name,
+			Version: expHelmRelease.Chart.Metadata.Version,
+			Repo:    chartLocation,
+		},
+	})
+
+	svc, err := NewReleaseHandler(logger.Noop(), expFlags)
+	svc.actionConfigurationProducer = mockConfigurationProducer(t, expHelmRelease, expFlags, "secrets")
+	require.NoError(t, err)
+
+	// when
+	outVal, gotErr := svc.GetValues(context.Background(), &pb.GetValuesRequest{
+		Requests: []*pb.GetValueRequest{
+			{TypeInstanceId: "123", Context: relCtx},
+			{TypeInstanceId: "456", Context: relCtx},
+		},
+	})
+
+	// then
+	require.NoError(t, gotErr)
+	assert.Equal(t, &pb.GetValuesResponse{
+		Responses: []*pb.GetValueResponse{
+			{Value: expValue},
+			{Value: expValue},
+		},
+	}, outVal)
+}
+
+func TestRelease_WatchValue(t *testing.T) {
+	// given
+	const (
+		releaseName      = "test-watch-release"
+		releaseNamespace = "test-watch-namespace"
+		chartLocation    = "http://example.com/charts"
+	)
+	expHelmRelease := fixHelmRelease(releaseName, releaseNamespace)
+	expFlags := &genericclioptions.ConfigFlags{ClusterName: ptr.String("testing")}
+
+	svc, err := NewReleaseHandler(logger.Noop(), expFlags)
+	svc.actionConfigurationProducer = mockConfigurationProducer(t, expHelmRelease, expFlags, "secrets")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &fakeWatchValueStream{ctx: ctx, onSend: cancel}
+
+	// when
+	gotErr := svc.WatchValue(&pb.WatchValueRequest{
+		TypeInstanceId: "123",
+		Context: mustMarshal(t, ReleaseContext{
+			Name:          releaseName,
+			Namespace:     releaseNamespace,
+			ChartLocation: chartLocation,
+		}),
+	}, stream)
+
+	// then
+	assert.ErrorIs(t, gotErr, context.Canceled)
+	require.Len(t, stream.sent, 1)
+	assert.Equal(t, mustMarshal(t, ReleaseDetails{
+		Name:      expHelmRelease.Name,
+		Namespace: expHelmRelease.Namespace,
+		Chart: ChartDetails{
+			Name:    expHelmRelease.Chart.Metadata.Name,
+			Version: expHelmRelease.Chart.Metadata.Version,
+			Repo:    chartLocation,
+		},
+	}), stream.sent[0].Value)
+}
+
+type fakeWatchValueStream struct {
+	grpc.ServerStream
+
+	ctx    context.Context
+	onSend func()
+	sent   []*pb.WatchValueResponse
+}
+
+func (s *fakeWatchValueStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchValueStream) Send(res *pb.WatchValueResponse) error {
+	s.sent = append(s.sent, res)
+	s.onSend()
+	return nil
+}
+
 func mockConfigurationProducer(t *testing.T, expHelmRelease *release.Release, expFlags *genericclioptions.ConfigFlags, expDriver string) actionConfigurationProducerFn {
 	t.Helper()
 	inMemoryDriver := driver.NewMemory()
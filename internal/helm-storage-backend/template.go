@@ -2,32 +2,245 @@ package helmstoragebackend
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
 
-	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/release"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+
+	"capact.io/capact/internal/ptr"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
 )
 
 var _ pb.StorageBackendServer = &TemplateHandler{}
 
+// valueTemplateName is the name of the chart template file, under which the TypeInstance Go template is rendered.
+const valueTemplateName = "templates/capact-typeinstance-value.yaml"
+
+// TemplateContextSchema is the JSON schema of the Helm template storage backend context.
+const TemplateContextSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema",
+  "type": "object",
+  "required": ["goTemplate", "release"],
+  "properties": {
+    "goTemplate": {
+      "$id": "#/properties/context/properties/goTemplate",
+      "type": "string",
+      "minLength": 1
+    },
+    "release": {
+      "$id": "#/properties/context/properties/release",
+      "type": "object",
+      "required": ["name", "namespace"],
+      "properties": {
+        "name": {
+          "$id": "#/properties/context/properties/release/properties/name",
+          "type": "string"
+        },
+        "namespace": {
+          "$id": "#/properties/context/properties/release/properties/namespace",
+          "type": "string"
+        },
+        "chartLocation": {
+          "$id": "#/properties/context/properties/release/properties/chartLocation",
+          "type": "string"
+        },
+        "driver": {
+          "$id": "#/properties/context/properties/release/properties/driver",
+          "type": "string",
+          "default": "secrets"
+        }
+      },
+      "additionalProperties": false
+    }
+  },
+  "additionalProperties": false
+}`
+
+// TemplateContext holds context used by Helm template storage backend.
+type TemplateContext struct {
+	// GoTemplate specifies Go template which is used to render TypeInstance value.
+	// It is rendered in the same way as the Helm chart templates, so it can use `.Values`, `.Release`, `.Chart`
+	// and the named templates defined in the chart, e.g. `{{ include "postgresql.fullname" . }}`.
+	GoTemplate string `json:"goTemplate"`
+	// Release specifies Helm release details against which the Go template is rendered.
+	Release ReleaseContext `json:"release"`
+}
+
 // TemplateHandler handles incoming requests to the Helm template storage backend gRPC server.
 type TemplateHandler struct {
 	pb.UnimplementedStorageBackendServer
+	releaseFetcher
 
 	log *zap.Logger
 }
 
 // NewTemplateHandler returns new TemplateHandler.
-func NewTemplateHandler(log *zap.Logger, helmCfgFlags *genericclioptions.ConfigFlags) *TemplateHandler {
+func NewTemplateHandler(log *zap.Logger, helmCfgFlags *genericclioptions.ConfigFlags) (*TemplateHandler, error) {
 	return &TemplateHandler{
-		log: log,
+		log:            log,
+		releaseFetcher: newReleaseFetcher(helmCfgFlags),
+	}, nil
+}
+
+// OnCreate checks whether a given Helm release is accessible this storage backend and the Go template can be rendered.
+func (h *TemplateHandler) OnCreate(_ context.Context, req *pb.OnCreateRequest) (*pb.OnCreateResponse, error) {
+	if _, err := h.renderValue(req.TypeInstanceId, req.Context); err != nil {
+		return nil, err
 	}
+
+	return &pb.OnCreateResponse{}, nil
 }
 
-// GetValue returns a value for a given TypeInstance.
-func (h *TemplateHandler) GetValue(_ context.Context, _ *pb.GetValueRequest) (*pb.GetValueResponse, error) {
-	h.log.Info("Getting value")
+// GetValue returns a value for a given TypeInstance. The value is the Go template rendered against the latest Helm release revision.
+func (h *TemplateHandler) GetValue(_ context.Context, req *pb.GetValueRequest) (*pb.GetValueResponse, error) {
+	value, err := h.renderValue(req.TypeInstanceId, req.Context)
+	if err != nil {
+		return nil, err
+	}
+
 	return &pb.GetValueResponse{
-		Value: []byte(`{"handler": "template"}`),
+		Value: value,
 	}, nil
 }
+
+// OnUpdate checks whether a given Helm release is accessible this storage backend and the Go template can be rendered.
+func (h *TemplateHandler) OnUpdate(_ context.Context, req *pb.OnUpdateRequest) (*pb.OnUpdateResponse, error) {
+	if _, err := h.renderValue(req.TypeInstanceId, req.Context); err != nil {
+		return nil, err
+	}
+
+	return &pb.OnUpdateResponse{}, nil
+}
+
+// OnDelete is NOP.
+func (h *TemplateHandler) OnDelete(_ context.Context, _ *pb.OnDeleteRequest) (*pb.OnDeleteResponse, error) {
+	return &pb.OnDeleteResponse{}, nil
+}
+
+// GetLockedBy is NOP.
+func (h *TemplateHandler) GetLockedBy(_ context.Context, _ *pb.GetLockedByRequest) (*pb.GetLockedByResponse, error) {
+	return &pb.GetLockedByResponse{}, nil
+}
+
+// OnLock is NOP.
+func (h *TemplateHandler) OnLock(_ context.Context, _ *pb.OnLockRequest) (*pb.OnLockResponse, error) {
+	return &pb.OnLockResponse{}, nil
+}
+
+// OnUnlock is NOP.
+func (h *TemplateHandler) OnUnlock(_ context.Context, _ *pb.OnUnlockRequest) (*pb.OnUnlockResponse, error) {
+	return &pb.OnUnlockResponse{}, nil
+}
+
+// GetCapabilities returns the features supported by the Helm template storage backend.
+// The value is always rendered against the latest Helm release revision, so the backend doesn't store values nor locks.
+func (h *TemplateHandler) GetCapabilities(_ context.Context, _ *pb.GetCapabilitiesRequest) (*pb.GetCapabilitiesResponse, error) {
+	return &pb.GetCapabilitiesResponse{
+		ReadOnly:      true,
+		ContextSchema: ptr.String(TemplateContextSchema),
+	}, nil
+}
+
+func (h *TemplateHandler) getTemplateContext(contextBytes []byte) (*TemplateContext, error) {
+	var ctx TemplateContext
+	err := json.Unmarshal(contextBytes, &ctx)
+	if err != nil {
+		return nil, gRPCInternalError(errors.Wrap(err, "while unmarshaling context"))
+	}
+
+	if strings.TrimSpace(ctx.GoTemplate) == "" {
+		return nil, status.Error(codes.InvalidArgument, "Go template must not be empty")
+	}
+
+	return &ctx, nil
+}
+
+func (h *TemplateHandler) renderValue(ti string, contextBytes []byte) ([]byte, error) {
+	tplCtx, err := h.getTemplateContext(contextBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := h.fetchHelmReleaseForContext(ti, &tplCtx.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debug("Rendering value", zap.String("typeInstanceID", ti), zap.String("release", fmt.Sprintf("%s/%s", rel.Namespace, rel.Name)), zap.Int("revision", rel.Version))
+
+	rendered, err := renderReleaseTemplate(rel, tplCtx.GoTemplate)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("while rendering Go template for TypeInstance '%s': %v", ti, err))
+	}
+
+	value, err := yaml.YAMLToJSON([]byte(rendered))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("while converting rendered Go template for TypeInstance '%s' to JSON: %v", ti, err))
+	}
+
+	return value, nil
+}
+
+// renderReleaseTemplate renders the Go template with the Helm engine, using the chart and values of a given release.
+func renderReleaseTemplate(rel *release.Release, goTemplate string) (string, error) {
+	if rel.Chart == nil {
+		return "", errors.New("release doesn't contain chart")
+	}
+
+	chrt := chartWithNamedTemplatesOnly(rel.Chart)
+	chrt.Templates = append(chrt.Templates, &chart.File{
+		Name: valueTemplateName,
+		Data: []byte(goTemplate),
+	})
+
+	opts := chartutil.ReleaseOptions{
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  rel.Version,
+		IsUpgrade: rel.Version > 1,
+		IsInstall: rel.Version <= 1,
+	}
+	values, err := chartutil.ToRenderValues(chrt, rel.Config, opts, chartutil.DefaultCapabilities)
+	if err != nil {
+		return "", errors.Wrap(err, "while preparing values")
+	}
+
+	out, err := engine.Render(chrt, values)
+	if err != nil {
+		return "", err
+	}
+
+	return out[path.Join(chrt.Name(), valueTemplateName)], nil
+}
+
+// chartWithNamedTemplatesOnly returns a copy of a given chart, which contains only the files with named templates,
+// e.g. `_helpers.tpl`. As a result, the Kubernetes manifests of the chart are not rendered.
+func chartWithNamedTemplatesOnly(in *chart.Chart) *chart.Chart {
+	out := *in
+
+	out.Templates = nil
+	for _, tpl := range in.Templates {
+		if strings.HasPrefix(path.Base(tpl.Name), "_") {
+			out.Templates = append(out.Templates, tpl)
+		}
+	}
+
+	var deps []*chart.Chart
+	for _, dep := range in.Dependencies() {
+		deps = append(deps, chartWithNamedTemplatesOnly(dep))
+	}
+	out.SetDependencies(deps...)
+
+	return &out
+}
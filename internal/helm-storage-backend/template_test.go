@@ -0,0 +1,334 @@
+package helmstoragebackend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"capact.io/capact/internal/logger"
+	"capact.io/capact/internal/ptr"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+)
+
+const fixGoTemplate = `
+host: '{{ include "test.fullname" . }}.{{ .Release.Namespace }}'
+port: {{ .Values.service.port }}
+user: '{{ .Values.auth.username }}'
+chart: '{{ .Chart.Name }}'
+`
+
+func TestTemplate_GetValue_Success(t *testing.T) {
+	tests := []struct {
+		name string
+
+		givenDriver    *string
+		expectedDriver string
+	}{
+		{
+			name:           "should use default driver and render template against the latest release",
+			givenDriver:    nil,
+			expectedDriver: "secrets",
+		},
+		{
+			name:           "should use configmap driver and render template against the latest release",
+			givenDriver:    ptr.String("configmaps"),
+			expectedDriver: "configmaps",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			const (
+				releaseName      = "test-get-release"
+				releaseNamespace = "test-get-namespace"
+			)
+			expHelmRelease := fixHelmReleaseWithTemplates(releaseName, releaseNamespace)
+			expFlags := &genericclioptions.ConfigFlags{ClusterName: ptr.String("testing")}
+			mockConfigurationProducer := mockConfigurationProducer(t, expHelmRelease, expFlags, test.expectedDriver)
+
+			givenReq := &pb.GetValueRequest{
+				TypeInstanceId: "123",
+				Context: mustMarshal(t, TemplateContext{
+					GoTemplate: fixGoTemplate,
+					Release: ReleaseContext{
+						Name:      releaseName,
+						Namespace: releaseNamespace,
+						Driver:    test.givenDriver,
+					},
+				}),
+			}
+
+			svc, err := NewTemplateHandler(logger.Noop(), expFlags)
+			svc.actionConfigurationProducer = mockConfigurationProducer
+			require.NoError(t, err)
+
+			// when
+			outVal, gotErr := svc.GetValue(context.Background(), givenReq)
+
+			// then
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, `{
+				"host": "test-get-release-test-get-release-chart.test-get-namespace",
+				"port": 5432,
+				"user": "capact",
+				"chart": "test-get-release-chart"
+			}`, string(outVal.Value))
+		})
+	}
+}
+
+func TestTemplate_GetValue_Failures(t *testing.T) {
+	// globally given
+	const (
+		releaseName      = "test-release"
+		releaseNamespace = "test-namespace"
+	)
+	tests := []struct {
+		name string
+
+		request       *pb.GetValueRequest
+		internalError error
+
+		expErrMsg string
+	}{
+		{
+			name: "should return not found error if release name is wrong",
+			request: &pb.GetValueRequest{
+				TypeInstanceId: "123",
+				Context: mustMarshal(t, TemplateContext{
+					GoTemplate: fixGoTemplate,
+					Release: ReleaseContext{
+						Name:      "other-release",
+						Namespace: releaseNamespace,
+					},
+				}),
+			},
+			expErrMsg: "rpc error: code = NotFound desc = Helm release 'test-namespace/other-release' for TypeInstance '123' was not found",
+		},
+		{
+			name: "should return invalid argument error if Go template is empty",
+			request: &pb.GetValueRequest{
+				TypeInstanceId: "123",
+				Context: mustMarshal(t, TemplateContext{
+					Release: ReleaseContext{
+						Name:      releaseName,
+						Namespace: releaseNamespace,
+					},
+				}),
+			},
+			expErrMsg: "rpc error: code = InvalidArgument desc = Go template must not be empty",
+		},
+		{
+			name: "should return invalid argument error if Go template is invalid",
+			request: &pb.GetValueRequest{
+				TypeInstanceId: "123",
+				Context: mustMarshal(t, TemplateContext{
+					GoTemplate: `host: {{ .Values.service.port`,
+					Release: ReleaseContext{
+						Name:      releaseName,
+						Namespace: releaseNamespace,
+					},
+				}),
+			},
+			expErrMsg: "rpc error: code = InvalidArgument desc = while rendering Go template for TypeInstance '123': parse error at (test-release-chart/templates/capact-typeinstance-value.yaml:1): unclosed action",
+		},
+		{
+			name: "should return internal error",
+			request: &pb.GetValueRequest{
+				TypeInstanceId: "123",
+				Context: mustMarshal(t, TemplateContext{
+					GoTemplate: fixGoTemplate,
+					Release: ReleaseContext{
+						Name:      releaseName,
+						Namespace: releaseNamespace,
+					},
+				}),
+			},
+			internalError: errors.New("internal error"),
+			expErrMsg:     "rpc error: code = Internal desc = while creating Helm get release client: internal error",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			expHelmRelease := fixHelmReleaseWithTemplates(releaseName, releaseNamespace)
+			expFlags := &genericclioptions.ConfigFlags{ClusterName: ptr.String("testing")}
+
+			mockConfigurationProducer := func(inputFlags *genericclioptions.ConfigFlags, inputDriver, inputNs string) (*action.Configuration, error) {
+				if test.internalError != nil {
+					return nil, test.internalError
+				}
+				producer := mockConfigurationProducer(t, expHelmRelease, expFlags, "secrets")
+				return producer(inputFlags, inputDriver, inputNs)
+			}
+
+			svc, err := NewTemplateHandler(logger.Noop(), expFlags)
+			svc.actionConfigurationProducer = mockConfigurationProducer
+			require.NoError(t, err)
+
+			// when
+			outVal, gotErr := svc.GetValue(context.Background(), test.request)
+
+			// then
+			assert.EqualError(t, gotErr, test.expErrMsg)
+			assert.Nil(t, outVal)
+		})
+	}
+}
+
+func TestTemplate_GetCapabilities(t *testing.T) {
+	// given
+	svc, err := NewTemplateHandler(logger.Noop(), nil)
+	require.NoError(t, err)
+
+	// when
+	res, err := svc.GetCapabilities(context.Background(), &pb.GetCapabilitiesRequest{})
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, res.ValueStorage)
+	assert.False(t, res.Locking)
+	assert.False(t, res.Versioning)
+	assert.True(t, res.ReadOnly)
+	require.NotNil(t, res.ContextSchema)
+
+	schema := gojsonschema.NewStringLoader(*res.ContextSchema)
+	tplCtx := mustMarshal(t, TemplateContext{
+		GoTemplate: fixGoTemplate,
+		Release: ReleaseContext{
+			Name:      "test-release",
+			Namespace: "test-namespace",
+			Driver:    ptr.String("secrets"),
+		},
+	})
+
+	result, err := gojsonschema.Validate(schema, gojsonschema.NewBytesLoader(tplCtx))
+	require.NoError(t, err)
+	assert.True(t, result.Valid(), result.Errors())
+
+	result, err = gojsonschema.Validate(schema, gojsonschema.NewStringLoader(`{"goTemplate":"","release":{"name":"test-release"}}`))
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+}
+
+func TestTemplate_OnCreate_OnUpdate(t *testing.T) {
+	// globally given
+	const (
+		releaseName      = "test-release"
+		releaseNamespace = "test-namespace"
+	)
+	tests := []struct {
+		name string
+
+		givenContext TemplateContext
+		expErrMsg    string
+	}{
+		{
+			name: "should succeed if release exists and Go template is valid",
+			givenContext: TemplateContext{
+				GoTemplate: fixGoTemplate,
+				Release: ReleaseContext{
+					Name:      releaseName,
+					Namespace: releaseNamespace,
+				},
+			},
+		},
+		{
+			name: "should return not found error if release doesn't exist",
+			givenContext: TemplateContext{
+				GoTemplate: fixGoTemplate,
+				Release: ReleaseContext{
+					Name:      releaseName,
+					Namespace: "other-ns",
+				},
+			},
+			expErrMsg: "rpc error: code = NotFound desc = Helm release 'other-ns/test-release' for TypeInstance '42' was not found",
+		},
+		{
+			name: "should return invalid argument error if Go template is empty",
+			givenContext: TemplateContext{
+				Release: ReleaseContext{
+					Name:      releaseName,
+					Namespace: releaseNamespace,
+				},
+			},
+			expErrMsg: "rpc error: code = InvalidArgument desc = Go template must not be empty",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			// given
+			expHelmRelease := fixHelmReleaseWithTemplates(releaseName, releaseNamespace)
+			expFlags := &genericclioptions.ConfigFlags{ClusterName: ptr.String("testing")}
+
+			svc, err := NewTemplateHandler(logger.Noop(), expFlags)
+			svc.actionConfigurationProducer = mockConfigurationProducer(t, expHelmRelease, expFlags, "secrets")
+			require.NoError(t, err)
+
+			givenCtx := mustMarshal(t, test.givenContext)
+
+			// when
+			createOut, createErr := svc.OnCreate(context.Background(), &pb.OnCreateRequest{TypeInstanceId: "42", Context: givenCtx})
+			updateOut, updateErr := svc.OnUpdate(context.Background(), &pb.OnUpdateRequest{TypeInstanceId: "42", Context: givenCtx})
+
+			// then
+			if test.expErrMsg == "" {
+				assert.NoError(t, createErr)
+				assert.NoError(t, updateErr)
+				assert.Empty(t, createOut)
+				assert.Empty(t, updateOut)
+				return
+			}
+
+			assert.EqualError(t, createErr, test.expErrMsg)
+			assert.EqualError(t, updateErr, test.expErrMsg)
+			assert.Nil(t, createOut)
+			assert.Nil(t, updateOut)
+		})
+	}
+}
+
+func fixHelmReleaseWithTemplates(name, ns string) *release.Release {
+	rel := fixHelmRelease(name, ns)
+	rel.Version = 1
+	rel.Chart.Metadata.APIVersion = chart.APIVersionV2
+	rel.Chart.Templates = []*chart.File{
+		{
+			Name: "templates/_helpers.tpl",
+			Data: []byte(`{{- define "test.fullname" -}}{{ .Release.Name }}-{{ .Chart.Name }}{{- end -}}`),
+		},
+		{
+			// should not be rendered
+			Name: "templates/deployment.yaml",
+			Data: []byte(`{{ required "image is required" .Values.image }}`),
+		},
+	}
+	rel.Chart.Values = map[string]interface{}{
+		"service": map[string]interface{}{
+			"port": 5432,
+		},
+		"auth": map[string]interface{}{
+			"username": "postgres",
+		},
+	}
+	rel.Config = map[string]interface{}{
+		"auth": map[string]interface{}{
+			"username": "capact",
+		},
+	}
+	return rel
+}
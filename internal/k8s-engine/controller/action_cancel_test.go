@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	statusreporter "capact.io/capact/internal/k8s-engine/status-reporter"
+	"capact.io/capact/internal/ptr"
+	"capact.io/capact/pkg/engine/k8s/api/v1alpha1"
+	gqllocalapi "capact.io/capact/pkg/hub/api/graphql/local"
+	"capact.io/capact/pkg/hub/client/local"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+)
+
+func TestActionReconciler_CancelAction(t *testing.T) {
+	tests := []struct {
+		name                string
+		workflow            *wfv1.Workflow
+		expectedWfShutdown  wfv1.ShutdownStrategy
+		expectedWfNotExists bool
+	}{
+		{
+			name:               "Terminates running Workflow",
+			workflow:           fixArgoWorkflow(wfv1.WorkflowRunning),
+			expectedWfShutdown: wfv1.ShutdownStrategyTerminate,
+		},
+		{
+			name:               "Leaves already finished Workflow untouched",
+			workflow:           fixArgoWorkflow(wfv1.WorkflowSucceeded),
+			expectedWfShutdown: "",
+		},
+		{
+			name:                "Ignores not submitted Workflow",
+			workflow:            nil,
+			expectedWfNotExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+
+			objs := []client.Object{fixCanceledAction(), fixRunnerJob(), fixRunnerStatusSecret()}
+			if tt.workflow != nil {
+				objs = append(objs, tt.workflow)
+			}
+			k8sCli := newFakeClientWithObjects(t, objs...)
+
+			locker := &recordingTypeInstanceLocker{}
+			svc := NewActionService(zap.NewNop(), k8sCli, nil, nil, nil, nil, locker, nil, Config{})
+			reconciler := newFakeActionReconciler(k8sCli, svc)
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "cancel", Namespace: "default"}}
+
+			// when
+			_, err := reconciler.Reconcile(ctx, req)
+
+			// then
+			require.NoError(t, err)
+
+			action := getAction(t, k8sCli, req.NamespacedName)
+			assert.Equal(t, v1alpha1.BeingCanceledActionPhase, action.Status.Phase)
+			require.NotNil(t, action.Status.Runner)
+			require.NotNil(t, action.Status.Runner.Status)
+			assert.JSONEq(t, `{"message":"running"}`, string(action.Status.Runner.Status.Raw))
+			assert.Empty(t, locker.unlocked)
+
+			// when
+			_, err = reconciler.Reconcile(ctx, req)
+
+			// then
+			require.NoError(t, err)
+
+			action = getAction(t, k8sCli, req.NamespacedName)
+			assert.Equal(t, v1alpha1.CanceledActionPhase, action.Status.Phase)
+			assert.Equal(t, []string{"ti-id"}, locker.unlocked)
+
+			err = k8sCli.Get(ctx, req.NamespacedName, &batchv1.Job{})
+			assert.True(t, apierrors.IsNotFound(err), "runner Job should be deleted")
+
+			gotWf := &wfv1.Workflow{}
+			err = k8sCli.Get(ctx, req.NamespacedName, gotWf)
+			if tt.expectedWfNotExists {
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedWfShutdown, gotWf.Spec.Shutdown)
+		})
+	}
+}
+
+func TestActionReconciler_CancelNotExecutedAction(t *testing.T) {
+	// given
+	ctx := context.Background()
+
+	action := fixCanceledAction()
+	action.Status.Phase = v1alpha1.ReadyToRunActionPhase
+	k8sCli := newFakeClientWithObjects(t, action)
+
+	// input TypeInstances are locked by another running Action
+	locker := &recordingTypeInstanceLocker{unlockErr: errors.New("TypeInstances are locked by different owner")}
+	svc := NewActionService(zap.NewNop(), k8sCli, nil, nil, nil, nil, locker, &fakeTypeInstanceGetter{}, Config{})
+	reconciler := newFakeActionReconciler(k8sCli, svc)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "cancel", Namespace: "default"}}
+
+	// when
+	_, err := reconciler.Reconcile(ctx, req)
+
+	// then
+	require.NoError(t, err)
+
+	got := getAction(t, k8sCli, req.NamespacedName)
+	assert.Equal(t, v1alpha1.CanceledActionPhase, got.Status.Phase)
+	assert.Empty(t, got.Status.Reason)
+
+	// when
+	_, err = reconciler.Reconcile(ctx, req)
+
+	// then
+	require.NoError(t, err)
+
+	got = getAction(t, k8sCli, req.NamespacedName)
+	assert.Equal(t, v1alpha1.CanceledActionPhase, got.Status.Phase)
+	assert.Zero(t, locker.unlockCalls)
+}
+
+func newFakeClientWithObjects(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, wfv1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		Build()
+}
+
+func newFakeActionReconciler(k8sCli client.Client, svc actionService) *ActionReconciler {
+	r := NewActionReconciler(ctrl.Log, svc, 1)
+	r.k8sCli = k8sCli
+	r.recorder = record.NewFakeRecorder(10)
+	r.rateLimiter = workqueue.DefaultControllerRateLimiter()
+	return r
+}
+
+func getAction(t *testing.T, k8sCli client.Client, key types.NamespacedName) *v1alpha1.Action {
+	t.Helper()
+
+	action := &v1alpha1.Action{}
+	require.NoError(t, k8sCli.Get(context.Background(), key, action))
+	return action
+}
+
+func fixCanceledAction() *v1alpha1.Action {
+	return &v1alpha1.Action{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "cancel",
+			Namespace:  "default",
+			Finalizers: []string{v1alpha1.ActionFinalizer},
+		},
+		Spec: v1alpha1.ActionSpec{
+			Cancel: ptr.Bool(true),
+		},
+		Status: v1alpha1.ActionStatus{
+			Phase: v1alpha1.RunningActionPhase,
+			Rendering: &v1alpha1.RenderingStatus{
+				TypeInstancesToLock: []string{"ti-id"},
+			},
+		},
+	}
+}
+
+func fixRunnerJob() *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cancel",
+			Namespace: "default",
+		},
+	}
+}
+
+func fixRunnerStatusSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cancel",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			statusreporter.SecretStatusEntryKey: []byte(`{"message":"running"}`),
+		},
+	}
+}
+
+func fixArgoWorkflow(phase wfv1.WorkflowPhase) *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cancel",
+			Namespace: "default",
+		},
+		Status: wfv1.WorkflowStatus{
+			Phase: phase,
+		},
+	}
+}
+
+type recordingTypeInstanceLocker struct {
+	unlocked    []string
+	unlockCalls int
+	unlockErr   error
+}
+
+func (l *recordingTypeInstanceLocker) LockTypeInstances(context.Context, *gqllocalapi.LockTypeInstancesInput) error {
+	return nil
+}
+
+func (l *recordingTypeInstanceLocker) UnlockTypeInstances(_ context.Context, in *gqllocalapi.UnlockTypeInstancesInput) error {
+	l.unlockCalls++
+	if l.unlockErr != nil {
+		return l.unlockErr
+	}
+	l.unlocked = append(l.unlocked, in.Ids...)
+	return nil
+}
+
+type fakeTypeInstanceGetter struct{}
+
+func (fakeTypeInstanceGetter) ListTypeInstances(context.Context, *gqllocalapi.TypeInstanceFilter, ...local.TypeInstancesOption) ([]gqllocalapi.TypeInstance, error) {
+	return nil, nil
+}
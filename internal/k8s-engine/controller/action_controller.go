@@ -3,16 +3,19 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"capact.io/capact/internal/ptr"
 	"capact.io/capact/pkg/engine/k8s/api/v1alpha1"
+	"capact.io/capact/pkg/sdk/renderer"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,7 @@ type (
 		EnsureWorkflowSAExists(ctx context.Context, action *v1alpha1.Action) (*corev1.ServiceAccount, error)
 		EnsureRunnerInputDataCreated(ctx context.Context, saName string, action *v1alpha1.Action) error
 		EnsureRunnerExecuted(ctx context.Context, saName string, action *v1alpha1.Action) error
+		EnsureRunnerCanceled(ctx context.Context, action *v1alpha1.Action) error
 		LockTypeInstances(ctx context.Context, action *v1alpha1.Action) error
 		UnlockTypeInstances(ctx context.Context, action *v1alpha1.Action) error
 	}
@@ -86,13 +90,13 @@ func NewActionReconciler(log logr.Logger, svc actionService, maxRetriesForAction
 // +kubebuilder:rbac:groups=core.capact.io,resources=actions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core.capact.io,resources=actions/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core.capact.io,resources=actions/finalizers,verbs=update
-// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile handles the reconcile logic for the Action CR.
-// TODO: introduce and ignore permanent error in reconcile loop
+// Transient errors are retried up to the configured limit. Permanent errors fail the Action immediately.
 func (r *ActionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var log = r.log.WithValues("action", req.NamespacedName)
 
@@ -132,6 +136,24 @@ func (r *ActionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return result, nil
 	}
 
+	if action.IsCancelRequested() {
+		log.Info("Canceling runner action")
+		result, err := r.cancelAction(ctx, action)
+		if err != nil {
+			return reportOnError(err, "Cancel runner action")
+		}
+		return result, nil
+	}
+
+	if action.IsAdvancedRenderingIterationApproved() {
+		log.Info("Continuing advanced rendering of runner action")
+		result, err := r.renderAction(ctx, action)
+		if err != nil {
+			return reportOnError(err, "Continue advanced rendering")
+		}
+		return result, nil
+	}
+
 	if action.IsBeingRendered() {
 		log.Info("Rendering runner action")
 		result, err := r.renderAction(ctx, action)
@@ -191,8 +213,8 @@ func (r *ActionReconciler) initAction(ctx context.Context, action *v1alpha1.Acti
 }
 
 // renderAction renders a given action. If finally rendered, sets status to v1alpha1.ReadyToRunActionPhase phase.
-//
-// TODO: add support for v1alpha1.AdvancedModeRenderingIterationActionPhase phase
+// In advanced rendering mode, if rendering stopped on a rendering iteration, sets status to
+// v1alpha1.AdvancedModeRenderingIterationActionPhase phase.
 func (r *ActionReconciler) renderAction(ctx context.Context, action *v1alpha1.Action) (ctrl.Result, error) {
 	renderingStatus, err := r.svc.RenderAction(ctx, action)
 	if renderingStatus != nil {
@@ -201,9 +223,24 @@ func (r *ActionReconciler) renderAction(ctx context.Context, action *v1alpha1.Ac
 
 	if err != nil {
 		msg := fmt.Sprintf("Cannot render given action: %s", err)
+		if permErr, ok := renderer.AsPermanentError(err); ok {
+			return r.handlePermanentError(ctx, action, failureReasonFromPermanentError(permErr), msg)
+		}
 		return r.handleRetry(ctx, action, v1alpha1.BeingRenderedActionPhase, msg)
 	}
 
+	if iteration := action.Status.Rendering.GetRenderingIteration(); iteration != nil {
+		msg := fmt.Sprintf("Rendering iteration %q is waiting for approval", iteration.CurrentIterationName)
+		action.Status = r.successStatus(action, v1alpha1.AdvancedModeRenderingIterationActionPhase, msg)
+		if err := r.k8sCli.Status().Update(ctx, action); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "while updating action object status")
+		}
+
+		// Requeue is not needed.
+		// User needs to approve the rendering iteration, so we will be notified on Action update.
+		return ctrl.Result{}, nil
+	}
+
 	action.Status = r.successStatus(action, v1alpha1.ReadyToRunActionPhase, "Runner action is rendered and ready to be executed")
 	if err := r.k8sCli.Status().Update(ctx, action); err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "while updating action object status")
@@ -214,9 +251,7 @@ func (r *ActionReconciler) renderAction(ctx context.Context, action *v1alpha1.Ac
 	return ctrl.Result{}, nil
 }
 
-// executeAction executes action (run, dryRun, cancel etc) and set v1alpha1.RunningActionPhase.
-//
-// TODO: add support v1alpha1.BeingCanceledActionPhase phase.
+// executeAction executes action (run, dryRun etc) and set v1alpha1.RunningActionPhase.
 func (r *ActionReconciler) executeAction(ctx context.Context, action *v1alpha1.Action) (ctrl.Result, error) {
 	sa, err := r.svc.EnsureWorkflowSAExists(ctx, action)
 	if err != nil {
@@ -251,9 +286,8 @@ func (r *ActionReconciler) executeAction(ctx context.Context, action *v1alpha1.A
 }
 
 // handleRunningAction checks execution status. If completed, sets final state v1alpha1.SucceededActionPhase,
-// v1alpha1.CanceledActionPhase, or v1alpha1.FailedActionPhase depends on currently scheduled activity.
-//
-// TODO: add support for cancel phase.
+// or v1alpha1.FailedActionPhase depends on currently scheduled activity.
+// Canceled Actions are handled by cancelAction.
 func (r *ActionReconciler) handleRunningAction(ctx context.Context, action *v1alpha1.Action) (ctrl.Result, error) {
 	type newStatusCreator func(ctx context.Context, action *v1alpha1.Action) (*v1alpha1.ActionStatus, error)
 	steps := []newStatusCreator{
@@ -283,6 +317,50 @@ func (r *ActionReconciler) handleRunningAction(ctx context.Context, action *v1al
 	return ctrl.Result{}, nil
 }
 
+// cancelAction cancels a given action. If the runner is executed, first, it sets the v1alpha1.BeingCanceledActionPhase phase,
+// and records the last status reported by runner. Next, it terminates the runner, unlocks TypeInstances
+// and sets final state v1alpha1.CanceledActionPhase.
+func (r *ActionReconciler) cancelAction(ctx context.Context, action *v1alpha1.Action) (ctrl.Result, error) {
+	if action.Status.Phase == v1alpha1.RunningActionPhase {
+		newStatus, err := r.reportedRunnerStatus(ctx, action)
+		if err != nil {
+			msg := fmt.Sprintf("Unable to check runner status: %s", err)
+			return r.handleRetry(ctx, action, action.Status.Phase, msg)
+		}
+		if newStatus != nil {
+			action.Status = *newStatus
+		}
+
+		action.Status = r.successStatus(action, v1alpha1.BeingCanceledActionPhase, "Canceling runner action")
+		if err := r.k8sCli.Status().Update(ctx, action); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "while updating status of action being canceled")
+		}
+		return ctrl.Result{RequeueAfter: noWait}, nil
+	}
+
+	if err := r.svc.EnsureRunnerCanceled(ctx, action); err != nil {
+		msg := fmt.Sprintf("Cannot cancel runner: %s", err)
+		return r.handleRetry(ctx, action, action.Status.Phase, msg)
+	}
+
+	// TypeInstances are locked only when the runner is executed. Otherwise, they may be locked by other Actions,
+	// and unlocking them would fail.
+	if action.IsExecuted() {
+		if err := r.svc.UnlockTypeInstances(ctx, action); err != nil {
+			msg := fmt.Sprintf("Cannot unlock TypeInstances: %s", err)
+			return r.handleRetry(ctx, action, v1alpha1.BeingCanceledActionPhase, msg)
+		}
+	}
+
+	action.Status = r.successStatus(action, v1alpha1.CanceledActionPhase, "Runner action canceled")
+	if err := r.k8sCli.Status().Update(ctx, action); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "while updating status of canceled action")
+	}
+
+	// requeue to handle the finished action
+	return ctrl.Result{RequeueAfter: noWait}, nil
+}
+
 func (r *ActionReconciler) reportedRunnerStatus(ctx context.Context, action *v1alpha1.Action) (*v1alpha1.ActionStatus, error) {
 	reportedStatus, err := r.svc.GetReportedRunnerStatus(ctx, action)
 	if err != nil {
@@ -297,8 +375,25 @@ func (r *ActionReconciler) reportedRunnerStatus(ctx context.Context, action *v1a
 	if statusCpy.Runner == nil {
 		statusCpy.Runner = &v1alpha1.RunnerStatus{}
 	}
-	statusCpy.Runner.Status = &runtime.RawExtension{
-		Raw: reportedStatus.Status,
+	if reportedStatus.Status != nil {
+		statusCpy.Runner.Status = &runtime.RawExtension{
+			Raw: reportedStatus.Status,
+		}
+	}
+	if reportedStatus.Progress != nil {
+		statusCpy.Runner.Progress = reportedStatus.Progress
+	}
+	if len(reportedStatus.Warnings) > 0 {
+		statusCpy.Runner.Warnings = reportedStatus.Warnings
+		msg := strings.Join(reportedStatus.Warnings, "\n")
+		meta.SetStatusCondition(&statusCpy.Conditions, metav1.Condition{
+			Type:               string(v1alpha1.RunnerWarningsActionCondition),
+			Status:             metav1.ConditionTrue,
+			Reason:             "WarningsReported",
+			Message:            msg,
+			ObservedGeneration: action.Generation,
+		})
+		r.recorder.Event(action, corev1.EventTypeWarning, string(v1alpha1.RunnerWarningsActionCondition), msg)
 	}
 
 	return statusCpy, nil
@@ -319,17 +414,20 @@ func (r *ActionReconciler) runnerJobStatus(ctx context.Context, action *v1alpha1
 	case batchv1.JobComplete:
 		outStatus = r.successStatus(action, v1alpha1.SucceededActionPhase, "Runner finished successfully")
 	case batchv1.JobFailed:
-		outStatus = r.failStatus(action, v1alpha1.FailedActionPhase, "Runner finished unsuccessfully")
+		outStatus = r.failedStatus(action, v1alpha1.RunnerFailedActionFailureReason, "Runner finished unsuccessfully")
 	default:
-		outStatus = r.failStatus(action, v1alpha1.FailedActionPhase, "Unknown runner job status")
+		outStatus = r.failedStatus(action, v1alpha1.RunnerFailedActionFailureReason, "Unknown runner job status")
 	}
 
 	return &outStatus, nil
 }
 
 func (r *ActionReconciler) handleFinishedAction(ctx context.Context, action *v1alpha1.Action) (ctrl.Result, error) {
-	if err := r.svc.UnlockTypeInstances(ctx, action); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "while unlocking TypeInstances")
+	// Canceled Actions are already unlocked by cancelAction, if they locked the TypeInstances.
+	if action.Status.Phase != v1alpha1.CanceledActionPhase {
+		if err := r.svc.UnlockTypeInstances(ctx, action); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "while unlocking TypeInstances")
+		}
 	}
 
 	if action.Status.Output == nil {
@@ -384,7 +482,7 @@ func (r *ActionReconciler) handleRetry(ctx context.Context, action *v1alpha1.Act
 		result = ctrl.Result{Requeue: true}
 	default:
 		errMsg = fmt.Sprintf("%s (giving up - exceeded %d retries)", errMsg, r.maxRetries)
-		action.Status = r.failStatus(action, v1alpha1.FailedActionPhase, errMsg)
+		action.Status = r.failedStatus(action, v1alpha1.RetriesExceededActionFailureReason, errMsg)
 		result = ctrl.Result{} // no retry
 	}
 
@@ -395,6 +493,47 @@ func (r *ActionReconciler) handleRetry(ctx context.Context, action *v1alpha1.Act
 	return result, nil
 }
 
+// handlePermanentError sets the final v1alpha1.FailedActionPhase phase without retrying,
+// as the same error would occur on each retry.
+func (r *ActionReconciler) handlePermanentError(ctx context.Context, action *v1alpha1.Action, reason v1alpha1.ActionFailureReason, errMsg string) (ctrl.Result, error) {
+	errMsg = fmt.Sprintf("%s (permanent error - not retrying)", errMsg)
+	action.Status = r.failedStatus(action, reason, errMsg)
+
+	if err := r.k8sCli.Status().Update(ctx, action); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "while updating action object status")
+	}
+
+	return ctrl.Result{}, nil // no retry
+}
+
+// failureReasonFromPermanentError maps the renderer permanent error reason to the Action failure reason.
+// Returns an empty reason for an unknown permanent error reason.
+func failureReasonFromPermanentError(err *renderer.PermanentError) v1alpha1.ActionFailureReason {
+	switch err.Reason {
+	case renderer.InterfaceNotFoundReason:
+		return v1alpha1.InterfaceNotFoundActionFailureReason
+	case renderer.ImplementationNotFoundReason:
+		return v1alpha1.ImplementationNotFoundActionFailureReason
+	case renderer.InvalidInputReason:
+		return v1alpha1.InvalidInputActionFailureReason
+	case renderer.InvalidPolicyReason:
+		return v1alpha1.InvalidPolicyActionFailureReason
+	case renderer.InvalidManifestReason:
+		return v1alpha1.InvalidManifestActionFailureReason
+	case renderer.UnsupportedRunnerReason:
+		return v1alpha1.UnsupportedRunnerActionFailureReason
+	default:
+		return ""
+	}
+}
+
+// failedStatus sets generic status fields to indicate the final action failure with a given reason. Emits proper K8s Event.
+func (r *ActionReconciler) failedStatus(action *v1alpha1.Action, reason v1alpha1.ActionFailureReason, msg string) v1alpha1.ActionStatus {
+	status := r.failStatus(action, v1alpha1.FailedActionPhase, msg)
+	status.Reason = reason
+	return status
+}
+
 // failStatus sets generic status fields to indicated action failed state. Emits proper K8s Event.
 func (r *ActionReconciler) failStatus(action *v1alpha1.Action, phase v1alpha1.ActionPhase, msg string) v1alpha1.ActionStatus {
 	return r.newStatusForAction(action, corev1.EventTypeWarning, phase, msg)
@@ -411,6 +550,7 @@ func (r *ActionReconciler) newStatusForAction(action *v1alpha1.Action, eventType
 	statusCpy := action.Status.DeepCopy()
 	statusCpy.Phase = phase
 	statusCpy.Message = ptr.String(msg)
+	statusCpy.Reason = ""
 	statusCpy.LastTransitionTime = metav1.Now()
 	statusCpy.ObservedGeneration = action.Generation
 
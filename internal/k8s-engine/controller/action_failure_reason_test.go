@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"testing"
+
+	"capact.io/capact/pkg/engine/k8s/api/v1alpha1"
+	"capact.io/capact/pkg/sdk/renderer"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureReasonFromPermanentError(t *testing.T) {
+	tests := []struct {
+		reason   renderer.PermanentErrorReason
+		expected v1alpha1.ActionFailureReason
+	}{
+		{reason: renderer.InterfaceNotFoundReason, expected: v1alpha1.InterfaceNotFoundActionFailureReason},
+		{reason: renderer.ImplementationNotFoundReason, expected: v1alpha1.ImplementationNotFoundActionFailureReason},
+		{reason: renderer.InvalidInputReason, expected: v1alpha1.InvalidInputActionFailureReason},
+		{reason: renderer.InvalidPolicyReason, expected: v1alpha1.InvalidPolicyActionFailureReason},
+		{reason: renderer.InvalidManifestReason, expected: v1alpha1.InvalidManifestActionFailureReason},
+		{reason: renderer.UnsupportedRunnerReason, expected: v1alpha1.UnsupportedRunnerActionFailureReason},
+		{reason: "Unknown", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.reason), func(t *testing.T) {
+			// given
+			err := renderer.NewPermanentError(tt.reason, errors.New("test"))
+
+			// when
+			out := failureReasonFromPermanentError(err)
+
+			// then
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
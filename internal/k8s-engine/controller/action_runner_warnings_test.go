@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	statusreporter "capact.io/capact/internal/k8s-engine/status-reporter"
+	"capact.io/capact/pkg/engine/k8s/api/v1alpha1"
+	"capact.io/capact/pkg/runner"
+	"capact.io/capact/pkg/runner/terraform"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestActionReconciler_RunnerWarnings(t *testing.T) {
+	// given
+	ctx := context.Background()
+	action := &v1alpha1.Action{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "warnings",
+			Namespace:  "default",
+			Finalizers: []string{v1alpha1.ActionFinalizer},
+		},
+		Status: v1alpha1.ActionStatus{
+			Phase: v1alpha1.RunningActionPhase,
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "warnings",
+			Namespace: "default",
+		},
+	}
+	k8sCli := newFakeClientWithObjects(t, action, secret)
+
+	svc := NewActionService(zap.NewNop(), k8sCli, nil, nil, nil, nil, nil, nil, Config{})
+	reconciler := newFakeActionReconciler(k8sCli, svc)
+
+	runnerCtx := runner.Context{
+		Name:     "warnings",
+		Platform: runner.KubernetesPlatformConfig{Namespace: "default"},
+	}
+	stepReporter := statusreporter.NewK8sSecretProgress(k8sCli)
+	err := stepReporter.Report(ctx, runnerCtx, terraform.Status{
+		Phase:   terraform.AppliedWithOutputErrorsStatusPhase,
+		Message: "output template is invalid",
+	})
+	require.NoError(t, err)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "warnings", Namespace: "default"}}
+
+	// when
+	_, err = reconciler.Reconcile(ctx, req)
+
+	// then
+	require.NoError(t, err)
+
+	expectedWarning := "Terraform resources applied, but outputs cannot be produced: output template is invalid"
+	got := getAction(t, k8sCli, req.NamespacedName)
+	assert.Equal(t, v1alpha1.RunningActionPhase, got.Status.Phase)
+	require.NotNil(t, got.Status.Runner)
+	assert.Equal(t, []string{expectedWarning}, got.Status.Runner.Warnings)
+
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(v1alpha1.RunnerWarningsActionCondition))
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, expectedWarning, cond.Message)
+}
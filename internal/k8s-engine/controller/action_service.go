@@ -18,15 +18,21 @@ import (
 	"capact.io/capact/pkg/hub/client/local"
 	"capact.io/capact/pkg/runner"
 	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"capact.io/capact/pkg/sdk/renderer"
 	"capact.io/capact/pkg/sdk/renderer/argo"
 
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/yaml"
@@ -45,8 +51,17 @@ const (
 	k8sJobActiveDeadlinePadding    = 10 * time.Second
 
 	listTypeInstanceFields = local.TypeInstanceRootFields | local.TypeInstanceTypeRefFields | local.TypeInstanceBackendFields
+
+	// argoWorkflowTerminatePatch is the same patch as the one sent by `argo terminate` command.
+	argoWorkflowTerminatePatch = `{"spec":{"shutdown":"Terminate"}}`
 )
 
+var argoWorkflowGVK = schema.GroupVersionKind{
+	Group:   "argoproj.io",
+	Version: "v1alpha1",
+	Kind:    "Workflow",
+}
+
 type (
 	// ArgoRenderer allows to render Capact Action defines in Argo format.
 	ArgoRenderer interface {
@@ -177,12 +192,12 @@ func (a *ActionService) CleanupActionOwnedResources(ctx context.Context, action
 
 	if action.IsExecuted() {
 		// Current decision:
-		a.log.Info("Ignoring delete request. Wait until Action execution will be finished.", zap.String("phase", string(action.Status.Phase)))
+		a.log.Info("Ignoring delete request. Wait until Action execution will be finished or cancel it.", zap.String("phase", string(action.Status.Phase)))
 
 		// Deletion in this state is complicated as such Action can be in the middle of e.g. data migration, system shutdown,
 		// creating resources on hyperscaler side, etc.
-		// In the future we can revisit this approach based on user feedback and e.g. cancel running actions, maybe even rollback
-		// already executed steps, etc.
+		// Running Actions can be canceled first. In the future we can revisit this approach based on user feedback
+		// and e.g. rollback already executed steps, etc.
 		return isCleanupIgnored, nil
 	}
 
@@ -335,6 +350,61 @@ func (a *ActionService) EnsureRunnerExecuted(ctx context.Context, saName string,
 	return nil
 }
 
+// +kubebuilder:rbac:groups=argoproj.io,resources=workflows,verbs=get;patch
+
+// EnsureRunnerCanceled ensures that the runner K8s Job is deleted and the Argo Workflow started by it is terminated.
+// It is safe to call it multiple times, and for Actions, which were not executed yet.
+func (a *ActionService) EnsureRunnerCanceled(ctx context.Context, action *v1alpha1.Action) error {
+	// 1. Terminate Argo Workflow, so all already started steps are stopped.
+	// The Workflow is not deleted, so its history is still available.
+	if err := a.ensureArgoWorkflowTerminated(ctx, action); err != nil {
+		return err
+	}
+
+	// 2. Delete runner Job together with its Pods.
+	runnerJob := &batchv1.Job{
+		ObjectMeta: a.objectMetaFromAction(action),
+	}
+	err := a.k8sCli.Delete(ctx, runnerJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if client.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, "while deleting runner K8s Job")
+	}
+
+	return nil
+}
+
+func (a *ActionService) ensureArgoWorkflowTerminated(ctx context.Context, action *v1alpha1.Action) error {
+	wf := &unstructured.Unstructured{}
+	wf.SetGroupVersionKind(argoWorkflowGVK)
+
+	err := a.k8sCli.Get(ctx, client.ObjectKey{Name: action.Name, Namespace: action.Namespace}, wf)
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		// Workflow was not submitted yet, or Argo is not installed at all.
+		return nil
+	default:
+		return errors.Wrap(err, "while getting Argo Workflow")
+	}
+
+	phase, _, err := unstructured.NestedString(wf.Object, "status", "phase")
+	if err != nil {
+		return errors.Wrap(err, "while getting Argo Workflow phase")
+	}
+	switch wfv1.WorkflowPhase(phase) {
+	case wfv1.WorkflowSucceeded, wfv1.WorkflowFailed, wfv1.WorkflowError:
+		// Workflow already finished, nothing to terminate.
+		return nil
+	}
+
+	err = a.k8sCli.Patch(ctx, wf, client.RawPatch(k8stypes.MergePatchType, []byte(argoWorkflowTerminatePatch)))
+	if client.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, "while terminating Argo Workflow")
+	}
+
+	return nil
+}
+
 // LockTypeInstances locks TypeInstance used by a given Action.
 func (a *ActionService) LockTypeInstances(ctx context.Context, action *v1alpha1.Action) error {
 	if action == nil || action.Status.Rendering == nil {
@@ -408,6 +478,10 @@ func (a *ActionService) RenderAction(ctx context.Context, action *v1alpha1.Actio
 		options = append(options, argo.WithActionPolicy(*actionPolicy))
 	}
 
+	if action.Spec.IsAdvancedRenderingEnabled() {
+		options = append(options, argo.WithAdvancedRendering(action.Spec.ApprovedRenderingIterationName()))
+	}
+
 	renderOutput, err := a.argoRenderer.Render(
 		ctx,
 		&argo.RenderInput{
@@ -420,11 +494,6 @@ func (a *ActionService) RenderAction(ctx context.Context, action *v1alpha1.Actio
 		return nil, errors.Wrap(err, "while rendering Action")
 	}
 
-	actionBytes, err := json.Marshal(renderOutput.Action)
-	if err != nil {
-		return nil, errors.Wrap(err, "while marshaling action to json")
-	}
-
 	status := &v1alpha1.RenderingStatus{}
 
 	if parametersCollection != nil {
@@ -440,17 +509,110 @@ func (a *ActionService) RenderAction(ctx context.Context, action *v1alpha1.Actio
 		status.SetInputTypeInstances(typeInstancesData)
 	}
 
+	status.ImplementationSelections = a.implementationSelectionsToK8s(renderOutput.ImplementationSelections)
+
+	if renderOutput.RenderingIteration != nil {
+		// rendering stopped on a rendering iteration, which needs to be approved by user
+		status.SetRenderingIteration(renderOutput.RenderingIteration.Name, a.typeInstancesToProvideToK8s(renderOutput.RenderingIteration.InputTypeInstancesToProvide))
+		status.SetActionPolicy(actionPolicyData)
+		return status, nil
+	}
+
+	status.Runner, err = a.resolveRunner(renderOutput.RunnerInterface)
+	if err != nil {
+		return nil, errors.Wrap(err, "while resolving runner for rendered Action")
+	}
+
+	renderedAction := renderOutput.Action
+	if action.Spec.RenderedActionOverride != nil {
+		renderedAction, err = a.getRenderedActionOverride(action, renderOutput.Action)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	actionBytes, err := json.Marshal(renderedAction)
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling action to json")
+	}
+
 	status.SetAction(actionBytes)
 	status.SetTypeInstancesToLock(renderOutput.TypeInstancesToLock)
 	status.SetActionPolicy(actionPolicyData)
 
-	if err := a.actionValidator.Validate(renderOutput.Action, action.Namespace); err != nil {
+	if err := a.actionValidator.Validate(renderedAction, action.Namespace); err != nil {
 		return status, errors.Wrap(err, "while validating rendered Action")
 	}
 
 	return status, nil
 }
 
+// getRenderedActionOverride returns the rendered Action override provided by user.
+// The override is validated against the Action rendered by Engine, so it cannot change the way how TypeInstances are handled.
+func (a *ActionService) getRenderedActionOverride(action *v1alpha1.Action, rendered *types.Action) (*types.Action, error) {
+	override := &types.Action{}
+	if err := json.Unmarshal(action.Spec.RenderedActionOverride.Raw, override); err != nil {
+		return nil, renderer.NewPermanentError(renderer.InvalidInputReason, errors.Wrap(err, "while unmarshaling rendered Action override"))
+	}
+
+	if err := argo.ValidateActionOverride(rendered, override); err != nil {
+		return nil, renderer.NewPermanentError(renderer.InvalidInputReason, errors.Wrap(err, "while validating rendered Action override"))
+	}
+
+	return override, nil
+}
+
+func (a *ActionService) typeInstancesToProvideToK8s(in []argo.InputTypeInstanceToProvide) []v1alpha1.InputTypeInstanceToProvide {
+	var out []v1alpha1.InputTypeInstanceToProvide
+	for _, ti := range in {
+		revision := ti.TypeRef.Revision
+		out = append(out, v1alpha1.InputTypeInstanceToProvide{
+			Name: ti.Name,
+			TypeRef: &v1alpha1.ManifestReference{
+				Path:     v1alpha1.NodePath(ti.TypeRef.Path),
+				Revision: &revision,
+			},
+		})
+	}
+
+	return out
+}
+
+func (a *ActionService) implementationSelectionsToK8s(in []argo.ImplementationSelection) []v1alpha1.ImplementationSelectionStatus {
+	var out []v1alpha1.ImplementationSelectionStatus
+	for _, selection := range in {
+		var discarded []v1alpha1.DiscardedImplementation
+		for _, impl := range selection.Discarded {
+			discarded = append(discarded, v1alpha1.DiscardedImplementation{
+				Implementation: a.manifestRefToK8s(impl.Implementation),
+				Reason:         impl.Reason,
+			})
+		}
+
+		out = append(out, v1alpha1.ImplementationSelectionStatus{
+			Name:      selection.Name,
+			Interface: a.manifestRefToK8s(selection.Interface),
+			Strategy:  string(selection.Strategy),
+			Selected:  a.manifestRefToK8s(selection.Selected),
+			Discarded: discarded,
+		})
+	}
+
+	return out
+}
+
+func (a *ActionService) manifestRefToK8s(in types.ManifestRef) v1alpha1.ManifestReference {
+	out := v1alpha1.ManifestReference{
+		Path: v1alpha1.NodePath(in.Path),
+	}
+	if in.Revision != "" {
+		revision := in.Revision
+		out.Revision = &revision
+	}
+
+	return out
+}
+
 func (a *ActionService) getUserInputData(ctx context.Context, action *v1alpha1.Action) (*argo.UserInputSecretRef, types.ParametersCollection, error) {
 	if action.Spec.Input == nil || action.Spec.Input.Parameters == nil {
 		return nil, nil, nil
@@ -487,12 +649,15 @@ func (a *ActionService) getActionPolicyData(ctx context.Context, action *v1alpha
 
 	policyData := secret.Data[graphqldomain.ActionPolicySecretDataKey]
 
-	policy := &policy.ActionPolicy{}
-	if err := json.Unmarshal(policyData, policy); err != nil {
-		return nil, nil, errors.Wrap(err, "while unmarshaling Policy data")
+	actionPolicy := &policy.ActionPolicy{}
+	if err := json.Unmarshal(policyData, actionPolicy); err != nil {
+		return nil, nil, renderer.NewPermanentError(renderer.InvalidPolicyReason, errors.Wrap(err, "while unmarshaling Policy data"))
+	}
+	if err := policy.Policy(*actionPolicy).Validate(); err != nil {
+		return nil, nil, renderer.NewPermanentError(renderer.InvalidPolicyReason, errors.Wrap(err, "while validating Policy"))
 	}
 
-	return policy, policyData, nil
+	return actionPolicy, policyData, nil
 }
 
 func (a *ActionService) getUserInputTypeInstances(action *v1alpha1.Action) ([]types.InputTypeInstanceRef, []v1alpha1.InputTypeInstance) {
@@ -524,8 +689,10 @@ func (a *ActionService) getPolicyWithFallbackToEmpty(ctx context.Context) (polic
 
 // GetReportedRunnerStatusOutput defines output for GetReportedRunnerStatus method.
 type GetReportedRunnerStatusOutput struct {
-	Changed bool
-	Status  []byte
+	Changed  bool
+	Status   []byte
+	Progress *v1alpha1.RunnerProgress
+	Warnings []string
 }
 
 // GetReportedRunnerStatus returns status reported by action runner.
@@ -541,22 +708,71 @@ func (a *ActionService) GetReportedRunnerStatus(ctx context.Context, action *v1a
 		return &GetReportedRunnerStatusOutput{Changed: false}, nil
 	}
 
-	status, found := secret.Data[statusreporter.SecretStatusEntryKey]
-	if !found {
-		return &GetReportedRunnerStatusOutput{Changed: false}, nil
+	status := secret.Data[statusreporter.SecretStatusEntryKey]
+	progress, err := a.reportedRunnerProgress(secret)
+	if err != nil {
+		return nil, err
+	}
+	warnings, err := a.reportedRunnerWarnings(secret)
+	if err != nil {
+		return nil, err
 	}
 
-	if action.Status.Runner != nil && action.Status.Runner.Status != nil &&
-		bytes.Equal(action.Status.Runner.Status.Raw, status) {
+	current := action.Status.Runner
+	if current == nil {
+		current = &v1alpha1.RunnerStatus{}
+	}
+
+	statusChanged := status != nil && (current.Status == nil || !bytes.Equal(current.Status.Raw, status))
+	progressChanged := progress != nil && (current.Progress == nil || *current.Progress != *progress)
+	// warnings are only appended, so it's enough to compare their number
+	warningsChanged := len(warnings) != len(current.Warnings)
+	if !statusChanged && !progressChanged && !warningsChanged {
 		return &GetReportedRunnerStatusOutput{Changed: false}, nil
 	}
 
 	return &GetReportedRunnerStatusOutput{
-		Changed: true,
-		Status:  status,
+		Changed:  true,
+		Status:   status,
+		Progress: progress,
+		Warnings: warnings,
+	}, nil
+}
+
+func (a *ActionService) reportedRunnerProgress(secret *corev1.Secret) (*v1alpha1.RunnerProgress, error) {
+	data, found := secret.Data[statusreporter.SecretProgressEntryKey]
+	if !found {
+		return nil, nil
+	}
+
+	var progress runner.Progress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling runner progress")
+	}
+
+	return &v1alpha1.RunnerProgress{
+		Phase:     progress.Phase,
+		Message:   progress.Message,
+		Completed: int32(progress.Completed),
+		Total:     int32(progress.Total),
 	}, nil
 }
 
+// reportedRunnerWarnings returns warnings reported by the runners executed as workflow steps.
+func (a *ActionService) reportedRunnerWarnings(secret *corev1.Secret) ([]string, error) {
+	data, found := secret.Data[statusreporter.SecretWarningsEntryKey]
+	if !found {
+		return nil, nil
+	}
+
+	var warnings []string
+	if err := json.Unmarshal(data, &warnings); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling runner warnings")
+	}
+
+	return warnings, nil
+}
+
 // GetRunnerJobStatusOutput defines output for GetRunnerJobStatus method.
 type GetRunnerJobStatusOutput struct {
 	Finished  bool
@@ -634,6 +850,37 @@ func (a *ActionService) objectMetaFromAction(action *v1alpha1.Action) metav1.Obj
 	}
 }
 
+// resolveRunner returns the runner compatible with a given runner Interface revision.
+func (a *ActionService) resolveRunner(runnerInterface types.ManifestRef) (*v1alpha1.RenderedRunner, error) {
+	if runnerInterface.Path != temporaryBuiltinArgoRunnerName {
+		return nil, renderer.NewPermanentError(renderer.UnsupportedRunnerReason, errors.Errorf("unsupported %q runner", runnerInterface.Path))
+	}
+
+	if runnerInterface.Revision == "" {
+		a.log.Warn("Revision of the runner Interface is not specified in Implementation imports. Falling back to the latest runner revision.",
+			zap.String("runnerInterface", runnerInterface.Path))
+	}
+
+	image, err := a.builtinRunner.ImageForRevision(runnerInterface.Revision)
+	if err != nil {
+		return nil, renderer.NewPermanentError(renderer.UnsupportedRunnerReason, err)
+	}
+
+	return &v1alpha1.RenderedRunner{
+		Interface: a.manifestRefToK8s(runnerInterface),
+		Image:     image,
+	}, nil
+}
+
+// runnerImage returns the runner image resolved during rendering.
+// Actions rendered before the runner was resolved fall back to the default built-in runner image.
+func (a *ActionService) runnerImage(action *v1alpha1.Action) string {
+	if action.Status.Rendering == nil || action.Status.Rendering.Runner == nil {
+		return a.builtinRunner.Image
+	}
+	return action.Status.Rendering.Runner.Image
+}
+
 func (a *ActionService) argoRunnerJob(saName string, action *v1alpha1.Action) *batchv1.Job {
 	activeDeadline := a.builtinRunner.Timeout + k8sJobActiveDeadlinePadding
 	activeDeadlineSec := activeDeadline.Seconds()
@@ -641,7 +888,8 @@ func (a *ActionService) argoRunnerJob(saName string, action *v1alpha1.Action) *b
 	return &batchv1.Job{
 		ObjectMeta: a.objectMetaFromAction(action),
 		Spec: batchv1.JobSpec{
-			// TODO: In the future we should add retries and each runner should handle them properly.
+			// The retry Policy is injected into the Argo Workflow steps as the retry strategy,
+			// so only the failed steps are retried. Retrying the runner Job would multiply the number of attempts.
 			BackoffLimit: ptr.Int32(0),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
@@ -651,7 +899,7 @@ func (a *ActionService) argoRunnerJob(saName string, action *v1alpha1.Action) *b
 					Containers: []corev1.Container{
 						{
 							Name:  "runner",
-							Image: a.builtinRunner.Image,
+							Image: a.runnerImage(action),
 							Env: []corev1.EnvVar{
 								{
 									Name:  "RUNNER_ARGS_PATH",
@@ -696,7 +944,8 @@ type renderedAction struct {
 	Args            json.RawMessage `json:"args"`
 }
 
-// CAUTION: assumption that the `runnerInterface` is already resolved to full node path. Currently, we do not support revision.
+// CAUTION: assumption that the `runnerInterface` is already resolved to full node path.
+// The runner Interface revision is available in the Action rendering status.
 func (a *ActionService) extractRunnerInterfaceAndArgs(action *v1alpha1.Action) (*renderedAction, error) {
 	var renderingAction renderedAction
 	err := yaml.Unmarshal(action.Status.Rendering.Action.Raw, &renderingAction)
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	statusreporter "capact.io/capact/internal/k8s-engine/status-reporter"
+	"capact.io/capact/pkg/engine/k8s/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestActionService_GetReportedRunnerStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		secretData     map[string][]byte
+		currentRunner  *v1alpha1.RunnerStatus
+		expectedOutput *GetReportedRunnerStatusOutput
+		expectedErr    string
+	}{
+		{
+			name:           "Nothing reported",
+			secretData:     nil,
+			expectedOutput: &GetReportedRunnerStatusOutput{Changed: false},
+		},
+		{
+			name: "Status and progress reported",
+			secretData: map[string][]byte{
+				statusreporter.SecretStatusEntryKey:   []byte(`{"message":"running"}`),
+				statusreporter.SecretProgressEntryKey: []byte(`{"phase":"apply","message":"created","completed":1,"total":2}`),
+			},
+			expectedOutput: &GetReportedRunnerStatusOutput{
+				Changed: true,
+				Status:  []byte(`{"message":"running"}`),
+				Progress: &v1alpha1.RunnerProgress{
+					Phase:     "apply",
+					Message:   "created",
+					Completed: 1,
+					Total:     2,
+				},
+			},
+		},
+		{
+			name: "Only progress changed",
+			secretData: map[string][]byte{
+				statusreporter.SecretStatusEntryKey:   []byte(`{"message":"running"}`),
+				statusreporter.SecretProgressEntryKey: []byte(`{"phase":"apply","completed":2,"total":2}`),
+			},
+			currentRunner: &v1alpha1.RunnerStatus{
+				Status:   &runtime.RawExtension{Raw: []byte(`{"message":"running"}`)},
+				Progress: &v1alpha1.RunnerProgress{Phase: "apply", Completed: 1, Total: 2},
+			},
+			expectedOutput: &GetReportedRunnerStatusOutput{
+				Changed:  true,
+				Status:   []byte(`{"message":"running"}`),
+				Progress: &v1alpha1.RunnerProgress{Phase: "apply", Completed: 2, Total: 2},
+			},
+		},
+		{
+			name: "Nothing changed",
+			secretData: map[string][]byte{
+				statusreporter.SecretStatusEntryKey:   []byte(`{"message":"running"}`),
+				statusreporter.SecretProgressEntryKey: []byte(`{"phase":"apply","completed":1,"total":2}`),
+			},
+			currentRunner: &v1alpha1.RunnerStatus{
+				Status:   &runtime.RawExtension{Raw: []byte(`{"message":"running"}`)},
+				Progress: &v1alpha1.RunnerProgress{Phase: "apply", Completed: 1, Total: 2},
+			},
+			expectedOutput: &GetReportedRunnerStatusOutput{Changed: false},
+		},
+		{
+			name: "New warning reported",
+			secretData: map[string][]byte{
+				statusreporter.SecretStatusEntryKey:   []byte(`{"message":"running"}`),
+				statusreporter.SecretWarningsEntryKey: []byte(`["first","second"]`),
+			},
+			currentRunner: &v1alpha1.RunnerStatus{
+				Status:   &runtime.RawExtension{Raw: []byte(`{"message":"running"}`)},
+				Warnings: []string{"first"},
+			},
+			expectedOutput: &GetReportedRunnerStatusOutput{
+				Changed:  true,
+				Status:   []byte(`{"message":"running"}`),
+				Warnings: []string{"first", "second"},
+			},
+		},
+		{
+			name: "Invalid warnings",
+			secretData: map[string][]byte{
+				statusreporter.SecretWarningsEntryKey: []byte(`not-a-json`),
+			},
+			expectedErr: "while unmarshaling runner warnings: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name: "Invalid progress",
+			secretData: map[string][]byte{
+				statusreporter.SecretProgressEntryKey: []byte(`not-a-json`),
+			},
+			expectedErr: "while unmarshaling runner progress: invalid character 'o' in literal null (expecting 'u')",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "action", Namespace: "default"},
+				Data:       tt.secretData,
+			}
+			k8sCli := newFakeClientWithObjects(t, secret)
+			svc := NewActionService(zap.NewNop(), k8sCli, nil, nil, nil, nil, nil, nil, Config{})
+
+			action := &v1alpha1.Action{
+				ObjectMeta: metav1.ObjectMeta{Name: "action", Namespace: "default"},
+				Status: v1alpha1.ActionStatus{
+					Runner: tt.currentRunner,
+				},
+			}
+
+			// when
+			out, err := svc.GetReportedRunnerStatus(context.Background(), action)
+
+			// then
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, out)
+		})
+	}
+}
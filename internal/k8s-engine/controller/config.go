@@ -1,6 +1,13 @@
 package controller
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+	"github.com/vrischmann/envconfig"
+)
 
 // Config holds Capact controller configuration.
 type Config struct {
@@ -10,5 +17,76 @@ type Config struct {
 // BuiltinRunnerConfig holds configuration for built-in Action runner.
 type BuiltinRunnerConfig struct {
 	Timeout time.Duration `envconfig:"default=2h"`
-	Image   string
+	// Image is used for all runner Interface revisions if Images are not specified.
+	Image string
+	// Images holds runner images compatible with given runner Interface revisions.
+	Images RunnerImages `envconfig:"optional"`
+}
+
+// ImageForRevision returns the runner image compatible with a given runner Interface revision.
+// If the revision is not specified, the default Image is returned, as it is compatible with the latest runner Interface revision.
+func (c BuiltinRunnerConfig) ImageForRevision(revision string) (string, error) {
+	if len(c.Images) == 0 || revision == "" {
+		return c.Image, nil
+	}
+
+	version, err := semver.NewVersion(revision)
+	if err != nil {
+		return "", errors.Wrapf(err, "while parsing runner Interface revision %q", revision)
+	}
+
+	for _, item := range c.Images {
+		constraint, err := semver.NewConstraint(item.RevisionConstraint)
+		if err != nil {
+			return "", errors.Wrapf(err, "while parsing runner Interface revision constraint %q", item.RevisionConstraint)
+		}
+		if constraint.Check(version) {
+			return item.Image, nil
+		}
+	}
+
+	return "", errors.Errorf("no runner image compatible with runner Interface revision %q", revision)
+}
+
+// RunnerImage holds the runner image compatible with runner Interface revisions, which satisfy a given constraint.
+type RunnerImage struct {
+	RevisionConstraint string
+	Image              string
+}
+
+// RunnerImages holds runner images for different runner Interface revisions.
+type RunnerImages []RunnerImage
+
+var _ envconfig.Unmarshaler = &RunnerImages{}
+
+// Unmarshal provides custom parsing for runner images syntax.
+// Input is a semicolon separated list of `<revision constraint>=<image>` entries, e.g. `~0.1.0=argo-runner:v0.4.0;>=0.2.0=argo-runner:v0.5.0`.
+// The first entry, which constraint is satisfied, is used.
+// Implements envconfig.Unmarshal interface.
+func (r *RunnerImages) Unmarshal(s string) error {
+	var out RunnerImages
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		idx := strings.LastIndex(entry, "=")
+		if idx <= 0 || idx == len(entry)-1 {
+			return errors.Errorf("runner image entry %q must be in `<revision constraint>=<image>` format", entry)
+		}
+
+		rawConstraint, image := strings.TrimSpace(entry[:idx]), strings.TrimSpace(entry[idx+1:])
+		if _, err := semver.NewConstraint(rawConstraint); err != nil {
+			return errors.Wrapf(err, "while parsing runner Interface revision constraint %q", rawConstraint)
+		}
+
+		out = append(out, RunnerImage{
+			RevisionConstraint: rawConstraint,
+			Image:              image,
+		})
+	}
+	*r = out
+
+	return nil
 }
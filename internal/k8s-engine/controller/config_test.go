@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinRunnerConfig_ImageForRevision(t *testing.T) {
+	// given
+	var images RunnerImages
+	err := images.Unmarshal("~0.1.0=argo-runner:v0.4.0; >=0.2.0, <0.3.0=argo-runner:v0.5.0")
+	require.NoError(t, err)
+
+	cfg := BuiltinRunnerConfig{
+		Image:  "argo-runner:latest",
+		Images: images,
+	}
+
+	tests := []struct {
+		name          string
+		cfg           BuiltinRunnerConfig
+		revision      string
+		expectedImage string
+		expectedErr   string
+	}{
+		{
+			name:          "Image for the first matching constraint",
+			cfg:           cfg,
+			revision:      "0.1.2",
+			expectedImage: "argo-runner:v0.4.0",
+		},
+		{
+			name:          "Image for the second matching constraint",
+			cfg:           cfg,
+			revision:      "0.2.0",
+			expectedImage: "argo-runner:v0.5.0",
+		},
+		{
+			name:        "No compatible image",
+			cfg:         cfg,
+			revision:    "1.0.0",
+			expectedErr: `no runner image compatible with runner Interface revision "1.0.0"`,
+		},
+		{
+			name:          "Default image if revision is not specified",
+			cfg:           cfg,
+			revision:      "",
+			expectedImage: "argo-runner:latest",
+		},
+		{
+			name:          "Default image if images are not specified",
+			cfg:           BuiltinRunnerConfig{Image: "argo-runner:latest"},
+			revision:      "1.0.0",
+			expectedImage: "argo-runner:latest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			image, err := tt.cfg.ImageForRevision(tt.revision)
+
+			// then
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedImage, image)
+		})
+	}
+}
+
+func TestRunnerImages_UnmarshalInvalidEntry(t *testing.T) {
+	// given
+	var images RunnerImages
+
+	// when
+	err := images.Unmarshal("argo-runner:v0.4.0")
+
+	// then
+	assert.EqualError(t, err, "runner image entry \"argo-runner:v0.4.0\" must be in `<revision constraint>=<image>` format")
+}
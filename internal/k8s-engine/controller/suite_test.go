@@ -115,7 +115,11 @@ func (c *argoRendererFake) Render(ctx context.Context, input *argo.RenderInput)
 			Args: map[string]interface{}{
 				"workflow": struct{}{},
 			},
-			RunnerInterface: "argo.run",
+			RunnerInterface: "cap.interface.runner.argo.run",
+		},
+		RunnerInterface: types.ManifestRef{
+			Path:     "cap.interface.runner.argo.run",
+			Revision: "0.1.0",
 		},
 	}, nil
 }
@@ -7,8 +7,10 @@ import (
 	"strings"
 
 	"capact.io/capact/internal/k8s-engine/graphql/model"
+	"capact.io/capact/internal/ptr"
 	"capact.io/capact/pkg/engine/api/graphql"
 	"capact.io/capact/pkg/engine/k8s/api/v1alpha1"
+	"capact.io/capact/pkg/engine/k8s/policy"
 	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
 	"github.com/pkg/errors"
 	authv1 "k8s.io/api/authentication/v1"
@@ -204,6 +206,21 @@ func (c *Converter) AdvancedModeContinueRenderingInputFromGraphQL(in graphql.Adv
 	}
 }
 
+// validateActionPolicy decodes the Action policy in the same way as the Action controller does and validates it,
+// so the invalid policy is rejected on Action creation instead of failing the rendering.
+func validateActionPolicy(policyData []byte) error {
+	actionPolicy := policy.ActionPolicy{}
+	if err := json.Unmarshal(policyData, &actionPolicy); err != nil {
+		return errors.Wrap(err, "while unmarshaling Action policy")
+	}
+
+	if err := policy.Policy(actionPolicy).Validate(); err != nil {
+		return errors.Wrap(err, "while validating Action policy")
+	}
+
+	return nil
+}
+
 func (c *Converter) inputParamsFromGraphQL(in *graphql.ActionInputData, name string) (*v1.Secret, error) {
 	if in == nil || (in.Parameters == nil && in.ActionPolicy == nil) {
 		return nil, nil
@@ -227,6 +244,10 @@ func (c *Converter) inputParamsFromGraphQL(in *graphql.ActionInputData, name str
 			return nil, errors.Wrap(err, "while marshaling policy to JSON")
 		}
 
+		if err := validateActionPolicy(policyData); err != nil {
+			return nil, err
+		}
+
 		data[ActionPolicySecretDataKey] = string(policyData)
 	}
 
@@ -420,7 +441,8 @@ func (c *Converter) statusToGraphQL(in *v1alpha1.ActionStatus) *graphql.ActionSt
 	var runnerStatus *graphql.RunnerStatus
 	if in.Runner != nil {
 		runnerStatus = &graphql.RunnerStatus{
-			Status: c.runtimeExtensionToJSONRawMessage(in.Runner.Status),
+			Status:   c.runtimeExtensionToJSONRawMessage(in.Runner.Status),
+			Progress: c.runnerProgressToGraphQL(in.Runner.Progress),
 		}
 	}
 
@@ -428,6 +450,7 @@ func (c *Converter) statusToGraphQL(in *v1alpha1.ActionStatus) *graphql.ActionSt
 		Phase:      c.phaseToGraphQL(in.Phase),
 		Timestamp:  graphql.Timestamp{Time: in.LastTransitionTime.Time},
 		Message:    in.Message,
+		Reason:     c.failureReasonToGraphQL(in.Reason),
 		Runner:     runnerStatus,
 		CreatedBy:  c.userInfoToGraphQL(in.CreatedBy),
 		RunBy:      c.userInfoToGraphQL(in.RunBy),
@@ -497,6 +520,53 @@ func (c *Converter) phaseToGraphQL(in v1alpha1.ActionPhase) graphql.ActionStatus
 	return graphql.ActionStatusPhaseInitial
 }
 
+func (c *Converter) runnerProgressToGraphQL(in *v1alpha1.RunnerProgress) *graphql.RunnerProgress {
+	if in == nil {
+		return nil
+	}
+
+	out := &graphql.RunnerProgress{
+		Completed: int(in.Completed),
+	}
+	if in.Phase != "" {
+		out.Phase = ptr.String(in.Phase)
+	}
+	if in.Message != "" {
+		out.Message = ptr.String(in.Message)
+	}
+	if in.Total > 0 {
+		out.Total = ptr.Int(int(in.Total))
+	}
+
+	return out
+}
+
+func (c *Converter) failureReasonToGraphQL(in v1alpha1.ActionFailureReason) *graphql.ActionFailureReason {
+	var out graphql.ActionFailureReason
+	switch in {
+	case v1alpha1.InterfaceNotFoundActionFailureReason:
+		out = graphql.ActionFailureReasonInterfaceNotFound
+	case v1alpha1.ImplementationNotFoundActionFailureReason:
+		out = graphql.ActionFailureReasonImplementationNotFound
+	case v1alpha1.InvalidInputActionFailureReason:
+		out = graphql.ActionFailureReasonInvalidInput
+	case v1alpha1.InvalidPolicyActionFailureReason:
+		out = graphql.ActionFailureReasonInvalidPolicy
+	case v1alpha1.InvalidManifestActionFailureReason:
+		out = graphql.ActionFailureReasonInvalidManifest
+	case v1alpha1.UnsupportedRunnerActionFailureReason:
+		out = graphql.ActionFailureReasonUnsupportedRunner
+	case v1alpha1.RetriesExceededActionFailureReason:
+		out = graphql.ActionFailureReasonRetriesExceeded
+	case v1alpha1.RunnerFailedActionFailureReason:
+		out = graphql.ActionFailureReasonRunnerFailed
+	default:
+		return nil
+	}
+
+	return &out
+}
+
 func (c *Converter) phaseFromGraphQL(in graphql.ActionStatusPhase) v1alpha1.ActionPhase {
 	switch in {
 	case graphql.ActionStatusPhaseInitial:
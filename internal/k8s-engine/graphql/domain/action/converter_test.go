@@ -76,6 +76,26 @@ func TestConverter_FromGraphQLInput_HappyPath(t *testing.T) {
 	}
 }
 
+func TestConverter_FromGraphQLInput_InvalidActionPolicy(t *testing.T) {
+	// given
+	const name = "from-gql"
+
+	gqlPolicy := fixGQLInputActionPolicy()
+	gqlPolicy.Interface.Rules[0].OneOf[0].ImplementationSelection = &graphql.PolicyRuleImplementationSelectionInput{
+		Strategy: graphql.ImplementationSelectionStrategyWeightedScore,
+	}
+
+	c := action.NewConverter()
+	givenGQLInput := fixGQLActionInput(name, nil, nil, gqlPolicy)
+
+	// when
+	_, err := c.FromGraphQLInput(givenGQLInput)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Attribute weights must be provided for WEIGHTED_SCORE strategy")
+}
+
 func TestConverter_ToGraphQL_HappyPath(t *testing.T) {
 	// given
 	const (
@@ -106,6 +106,12 @@ func fixGQLAction(t *testing.T, name string) graphql.Action {
 			Message:   ptr.String("message"),
 			Runner: &graphql.RunnerStatus{
 				Status: ptrToJSONRawMessage(`{"runner":true}`),
+				Progress: &graphql.RunnerProgress{
+					Phase:     ptr.String("apply"),
+					Message:   ptr.String("db: Creation complete after 5m2s"),
+					Completed: 1,
+					Total:     ptr.Int(2),
+				},
 			},
 			CreatedBy:  &userInfo,
 			RunBy:      &userInfo,
@@ -167,6 +173,12 @@ func fixK8sAction(t *testing.T, name, namespace string) v1alpha1.Action {
 			Message: ptr.String("message"),
 			Runner: &v1alpha1.RunnerStatus{
 				Status: &runtime.RawExtension{Raw: []byte(`{"runner":true}`)},
+				Progress: &v1alpha1.RunnerProgress{
+					Phase:     "apply",
+					Message:   "db: Creation complete after 5m2s",
+					Completed: 1,
+					Total:     2,
+				},
 			},
 			Output: &v1alpha1.ActionOutput{
 				TypeInstances: &[]v1alpha1.OutputTypeInstanceDetails{
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"go.uber.org/zap"
+	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,10 +12,12 @@ import (
 	"capact.io/capact/internal/k8s-engine/graphql/model"
 
 	"capact.io/capact/internal/k8s-engine/graphql/namespace"
+	"capact.io/capact/internal/k8s-engine/graphql/user"
 	"capact.io/capact/internal/ptr"
 	"capact.io/capact/pkg/engine/k8s/api/v1alpha1"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -60,6 +63,13 @@ func (s *Service) Create(ctx context.Context, item model.ActionToCreateOrUpdate)
 		return v1alpha1.Action{}, err
 	}
 
+	err = s.recordUser(ctx, &item.Action, func(status *v1alpha1.ActionStatus, usr *authv1.UserInfo) {
+		status.CreatedBy = usr
+	})
+	if err != nil {
+		return v1alpha1.Action{}, err
+	}
+
 	return item.Action, nil
 }
 
@@ -217,7 +227,13 @@ func (s *Service) RunByName(ctx context.Context, name string) error {
 	item.Spec.Run = ptr.Bool(true)
 
 	err = s.updateAction(ctx, item)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return s.recordUser(ctx, &item, func(status *v1alpha1.ActionStatus, usr *authv1.UserInfo) {
+		status.RunBy = usr
+	})
 }
 
 // CancelByName cancels Action with a given name from the Namespace extracted from a given ctx.
@@ -244,7 +260,13 @@ func (s *Service) CancelByName(ctx context.Context, name string) error {
 	item.Spec.Run = ptr.Bool(false)
 
 	err = s.updateAction(ctx, item)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return s.recordUser(ctx, &item, func(status *v1alpha1.ActionStatus, usr *authv1.UserInfo) {
+		status.CanceledBy = usr
+	})
 }
 
 // ContinueAdvancedRendering continues advanced rendering for Action with a given name from the Namespace extracted from a given ctx.
@@ -394,6 +416,40 @@ func (s *Service) updateAction(ctx context.Context, item v1alpha1.Action) error
 	return nil
 }
 
+// recordUser saves the caller identity extracted from a given ctx in the Action status.
+// The status is updated only if the identity is available.
+func (s *Service) recordUser(ctx context.Context, item *v1alpha1.Action, setUser func(status *v1alpha1.ActionStatus, usr *authv1.UserInfo)) error {
+	usr, ok := user.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	log := s.logWithNameAndNs(item.Name, item.Namespace)
+	log.Info("Recording user in Action status", zap.String("username", usr.Username))
+
+	key := client.ObjectKey{Name: item.Name, Namespace: item.Namespace}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := s.k8sCli.Get(ctx, key, item); err != nil {
+			return err
+		}
+
+		// status update is rejected if the phase is not set yet
+		if item.Status.Phase == "" {
+			item.Status.Phase = v1alpha1.InitialActionPhase
+		}
+		setUser(&item.Status, usr.DeepCopy())
+
+		return s.k8sCli.Status().Update(ctx, item)
+	})
+	if err != nil {
+		errContext := "while recording user in Action status"
+		log.Error(errContext, zap.Error(err))
+		return errors.Wrap(err, errContext)
+	}
+
+	return nil
+}
+
 func (s *Service) mergeTypeInstances(slice1, slice2 *[]v1alpha1.InputTypeInstance) *[]v1alpha1.InputTypeInstance {
 	if slice1 == nil && slice2 == nil {
 		return nil
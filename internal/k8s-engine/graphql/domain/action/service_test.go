@@ -9,11 +9,13 @@ import (
 	"capact.io/capact/internal/k8s-engine/graphql/domain/action"
 	"capact.io/capact/internal/k8s-engine/graphql/model"
 	"capact.io/capact/internal/k8s-engine/graphql/namespace"
+	"capact.io/capact/internal/k8s-engine/graphql/user"
 	"capact.io/capact/internal/ptr"
 	corev1alpha1 "capact.io/capact/pkg/engine/k8s/api/v1alpha1"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -315,6 +317,33 @@ func TestService_RunByName(t *testing.T) {
 		assert.True(t, *actual.Spec.Run)
 	})
 
+	t.Run("Success - records user", func(t *testing.T) {
+		inputAction := fixK8sActionMinimal(name, ns, corev1alpha1.ReadyToRunActionPhase, fixManifestReference("foo.bar"))
+		usr := authv1.UserInfo{Username: "alice", Groups: []string{"admins"}}
+
+		svc, k8sCli := newServiceWithFakeClient(t, &inputAction)
+
+		ctx := namespace.NewContext(context.Background(), ns)
+		ctx = user.NewContext(ctx, usr)
+
+		// when
+		err := svc.RunByName(ctx, name)
+
+		// then
+		require.NoError(t, err)
+
+		var actual corev1alpha1.Action
+		err = k8sCli.Get(context.Background(), client.ObjectKey{
+			Namespace: ns,
+			Name:      name,
+		}, &actual)
+		require.NoError(t, err)
+		assert.True(t, *actual.Spec.Run)
+		assert.Equal(t, &usr, actual.Status.RunBy)
+		assert.Nil(t, actual.Status.CreatedBy)
+		assert.Equal(t, corev1alpha1.ReadyToRunActionPhase, actual.Status.Phase)
+	})
+
 	t.Run("Error - Already Cancelled", func(t *testing.T) {
 		inputAction := fixK8sActionMinimal(name, ns, corev1alpha1.InitialActionPhase, fixManifestReference("foo.bar"))
 		inputAction.Spec.Cancel = ptr.Bool(true)
@@ -24,12 +24,40 @@ func (c *Converter) FromGraphQLInput(in graphql.PolicyInput) (policy.Policy, err
 
 	typeInstanceRules := c.typeInstanceFromGraphQLInput(in.TypeInstance)
 
+	retry := c.retryFromGraphQLInput(in.Retry)
+	if err := retry.Validate(); err != nil {
+		return policy.Policy{}, errors.Wrap(err, "while validating retry Policy")
+	}
+
 	return policy.Policy{
 		Interface:    ifaceRules,
 		TypeInstance: typeInstanceRules,
+		Retry:        retry,
 	}, nil
 }
 
+func (c *Converter) retryFromGraphQLInput(in *graphql.RetryPolicyInput) *policy.RetryPolicy {
+	if in == nil {
+		return nil
+	}
+
+	out := &policy.RetryPolicy{
+		Limit: in.Limit,
+	}
+	if in.RetryOn != nil {
+		out.RetryOn = policy.RetryOn(*in.RetryOn)
+	}
+	if in.Backoff != nil {
+		out.Backoff = &policy.RetryBackoff{
+			Duration:    in.Backoff.Duration,
+			Factor:      in.Backoff.Factor,
+			MaxDuration: in.Backoff.MaxDuration,
+		}
+	}
+
+	return out
+}
+
 func (c *Converter) interfaceFromGraphQLInput(in *graphql.InterfacePolicyInput) (policy.InterfacePolicy, error) {
 	if in == nil {
 		return policy.InterfacePolicy{}, nil
@@ -96,9 +124,31 @@ func (c *Converter) ToGraphQL(in policy.Policy) graphql.Policy {
 	return graphql.Policy{
 		Interface:    c.interfaceToGraphQL(in.Interface),
 		TypeInstance: c.typeInstanceToGraphQL(in.TypeInstance),
+		Retry:        c.retryToGraphQL(in.Retry),
 	}
 }
 
+func (c *Converter) retryToGraphQL(in *policy.RetryPolicy) *graphql.RetryPolicy {
+	if in == nil {
+		return nil
+	}
+
+	retryOn := graphql.RetryOn(in.RetryOnOrDefault())
+	out := &graphql.RetryPolicy{
+		Limit:   in.Limit,
+		RetryOn: &retryOn,
+	}
+	if in.Backoff != nil {
+		out.Backoff = &graphql.RetryBackoff{
+			Duration:    in.Backoff.Duration,
+			Factor:      in.Backoff.Factor,
+			MaxDuration: in.Backoff.MaxDuration,
+		}
+	}
+
+	return out
+}
+
 func (c *Converter) typeInstanceToGraphQL(in policy.TypeInstancePolicy) *graphql.TypeInstancePolicy {
 	var gqlRules []*graphql.RulesForTypeInstance
 
@@ -154,7 +204,8 @@ func (c *Converter) policyRulesToGraphQL(in []policy.Rule) []*graphql.PolicyRule
 				Attributes: c.manifestRefsToGraphQL(rule.ImplementationConstraints.Attributes),
 				Path:       rule.ImplementationConstraints.Path,
 			},
-			Inject: c.policyInjectDataToGraphQL(rule.Inject),
+			ImplementationSelection: c.implementationSelectionToGraphQL(rule.ImplementationSelection),
+			Inject:                  c.policyInjectDataToGraphQL(rule.Inject),
 		}
 
 		gqlRules = append(gqlRules, gqlRule)
@@ -163,6 +214,32 @@ func (c *Converter) policyRulesToGraphQL(in []policy.Rule) []*graphql.PolicyRule
 	return gqlRules
 }
 
+func (c *Converter) implementationSelectionToGraphQL(in *policy.ImplementationSelection) *graphql.PolicyRuleImplementationSelection {
+	if in == nil {
+		return nil
+	}
+
+	var attributes []*graphql.ManifestReferenceWithOptionalRevision
+	for _, item := range in.Attributes {
+		attributes = append(attributes, c.manifestRefToGraphQL(item))
+	}
+
+	var weights []*graphql.ImplementationAttributeWeight
+	for _, item := range in.Weights {
+		weights = append(weights, &graphql.ImplementationAttributeWeight{
+			Attribute: c.manifestRefToGraphQL(item.Attribute),
+			Weight:    item.Weight,
+		})
+	}
+
+	return &graphql.PolicyRuleImplementationSelection{
+		Strategy:             graphql.ImplementationSelectionStrategy(in.Strategy),
+		Attributes:           attributes,
+		AppVersionConstraint: in.AppVersionConstraint,
+		Weights:              weights,
+	}
+}
+
 func (c *Converter) policyInjectDataToGraphQL(data *policy.InjectData) *graphql.PolicyRuleInjectData {
 	if data == nil {
 		return nil
@@ -195,6 +272,7 @@ func (c *Converter) policyRulesFromGraphQLInput(in []*graphql.PolicyRuleInput) (
 
 		rule := policy.Rule{
 			ImplementationConstraints: implConstraints,
+			ImplementationSelection:   c.implementationSelectionFromGraphQLInput(gqlRule.ImplementationSelection),
 			Inject:                    injectData,
 		}
 
@@ -204,6 +282,38 @@ func (c *Converter) policyRulesFromGraphQLInput(in []*graphql.PolicyRuleInput) (
 	return rules, nil
 }
 
+func (c *Converter) implementationSelectionFromGraphQLInput(in *graphql.PolicyRuleImplementationSelectionInput) *policy.ImplementationSelection {
+	if in == nil {
+		return nil
+	}
+
+	var attributes []types.ManifestRefWithOptRevision
+	for _, item := range in.Attributes {
+		if item == nil {
+			continue
+		}
+		attributes = append(attributes, c.manifestRefFromGraphQLInput(item))
+	}
+
+	var weights []policy.ImplementationAttributeWeight
+	for _, item := range in.Weights {
+		if item == nil {
+			continue
+		}
+		weights = append(weights, policy.ImplementationAttributeWeight{
+			Attribute: c.manifestRefFromGraphQLInput(item.Attribute),
+			Weight:    item.Weight,
+		})
+	}
+
+	return &policy.ImplementationSelection{
+		Strategy:             policy.ImplementationSelectionStrategy(in.Strategy),
+		Attributes:           attributes,
+		AppVersionConstraint: in.AppVersionConstraint,
+		Weights:              weights,
+	}
+}
+
 func (c *Converter) policyInjectDataFromGraphQLInput(input *graphql.PolicyRuleInjectDataInput) (*policy.InjectData, error) {
 	if input == nil {
 		return nil, nil
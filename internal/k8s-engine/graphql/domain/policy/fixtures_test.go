@@ -78,6 +78,17 @@ func fixGQLInput() graphql.PolicyInput {
 					OneOf: []*graphql.PolicyRuleInput{
 						{
 							ImplementationConstraints: &graphql.PolicyRuleImplementationConstraintsInput{},
+							ImplementationSelection: &graphql.PolicyRuleImplementationSelectionInput{
+								Strategy: graphql.ImplementationSelectionStrategyWeightedScore,
+								Weights: []*graphql.ImplementationAttributeWeightInput{
+									{
+										Attribute: &graphql.ManifestReferenceInput{
+											Path: "cap.attribute.cloud.provider.gcp",
+										},
+										Weight: 10,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -114,6 +125,15 @@ func fixGQLInput() graphql.PolicyInput {
 				},
 			},
 		},
+		Retry: &graphql.RetryPolicyInput{
+			Limit:   3,
+			RetryOn: retryOnPtr(graphql.RetryOnOnTransientError),
+			Backoff: &graphql.RetryBackoffInput{
+				Duration:    "30s",
+				Factor:      ptr.Int(2),
+				MaxDuration: ptr.String("1h"),
+			},
+		},
 	}
 }
 
@@ -188,6 +208,17 @@ func fixGQL() graphql.Policy {
 					OneOf: []*graphql.PolicyRule{
 						{
 							ImplementationConstraints: &graphql.PolicyRuleImplementationConstraints{},
+							ImplementationSelection: &graphql.PolicyRuleImplementationSelection{
+								Strategy: graphql.ImplementationSelectionStrategyWeightedScore,
+								Weights: []*graphql.ImplementationAttributeWeight{
+									{
+										Attribute: &graphql.ManifestReferenceWithOptionalRevision{
+											Path: "cap.attribute.cloud.provider.gcp",
+										},
+										Weight: 10,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -224,6 +255,15 @@ func fixGQL() graphql.Policy {
 				},
 			},
 		},
+		Retry: &graphql.RetryPolicy{
+			Limit:   3,
+			RetryOn: retryOnPtr(graphql.RetryOnOnTransientError),
+			Backoff: &graphql.RetryBackoff{
+				Duration:    "30s",
+				Factor:      ptr.Int(2),
+				MaxDuration: ptr.String("1h"),
+			},
+		},
 	}
 }
 
@@ -304,6 +344,17 @@ func fixModel() policy.Policy {
 					OneOf: []policy.Rule{
 						{
 							ImplementationConstraints: policy.ImplementationConstraints{},
+							ImplementationSelection: &policy.ImplementationSelection{
+								Strategy: policy.WeightedScoreImplementationSelection,
+								Weights: []policy.ImplementationAttributeWeight{
+									{
+										Attribute: types.ManifestRefWithOptRevision{
+											Path: "cap.attribute.cloud.provider.gcp",
+										},
+										Weight: 10,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -346,5 +397,18 @@ func fixModel() policy.Policy {
 				},
 			},
 		},
+		Retry: &policy.RetryPolicy{
+			Limit:   3,
+			RetryOn: policy.OnTransientErrorRetryOn,
+			Backoff: &policy.RetryBackoff{
+				Duration:    "30s",
+				Factor:      ptr.Int(2),
+				MaxDuration: ptr.String("1h"),
+			},
+		},
 	}
 }
+
+func retryOnPtr(in graphql.RetryOn) *graphql.RetryOn {
+	return &in
+}
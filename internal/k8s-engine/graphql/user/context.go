@@ -0,0 +1,25 @@
+package user
+
+import (
+	"context"
+
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of parent context with associated user data.
+func NewContext(ctx context.Context, user authv1.UserInfo) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// FromContext returns user data saved in a given context.
+// It returns false if there is no user data in the context.
+func FromContext(ctx context.Context) (authv1.UserInfo, bool) {
+	if ctx == nil {
+		return authv1.UserInfo{}, false
+	}
+
+	user, ok := ctx.Value(contextKey{}).(authv1.UserInfo)
+	return user, ok
+}
@@ -0,0 +1,39 @@
+package user
+
+import (
+	"net/http"
+
+	"capact.io/capact/internal/gateway/header"
+
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+// Middleware provides functionality to handle the caller identity in HTTP requests.
+// The identity is set by Gateway after the caller is authenticated.
+type Middleware struct{}
+
+// NewMiddleware returns a new Middleware instance.
+func NewMiddleware() *Middleware {
+	return &Middleware{}
+}
+
+// Handle reads the caller identity from headers and passes it to next handlers in request context.
+// If the identity is not provided, the request context is not modified.
+func (m *Middleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			username, groups := header.GetUser(r.Header)
+			if username == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := NewContext(r.Context(), authv1.UserInfo{
+				Username: username,
+				Groups:   groups,
+			})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		},
+	)
+}
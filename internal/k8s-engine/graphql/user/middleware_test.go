@@ -0,0 +1,85 @@
+package user_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"capact.io/capact/internal/gateway/header"
+	"capact.io/capact/internal/k8s-engine/graphql/user"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+func TestMiddleware_Handle(t *testing.T) {
+	// given
+	reqWithUser := sampleRequest()
+	header.SetUser(reqWithUser.Header, "alice", []string{"admins", "devs"})
+
+	reqWithUsernameOnly := sampleRequest()
+	header.SetUser(reqWithUsernameOnly.Header, "bob", nil)
+
+	tests := []struct {
+		name          string
+		inputRequest  *http.Request
+		expectedUser  authv1.UserInfo
+		expectedFound bool
+	}{
+		{
+			name:         "User with groups",
+			inputRequest: reqWithUser,
+			expectedUser: authv1.UserInfo{
+				Username: "alice",
+				Groups:   []string{"admins", "devs"},
+			},
+			expectedFound: true,
+		},
+		{
+			name:         "User without groups",
+			inputRequest: reqWithUsernameOnly,
+			expectedUser: authv1.UserInfo{
+				Username: "bob",
+			},
+			expectedFound: true,
+		},
+		{
+			name:          "No user",
+			inputRequest:  sampleRequest(),
+			expectedUser:  authv1.UserInfo{},
+			expectedFound: false,
+		},
+	}
+	for _, tc := range tests {
+		testCase := tc
+		t.Run(testCase.name, func(t *testing.T) {
+			var (
+				actualUser  authv1.UserInfo
+				actualFound bool
+			)
+
+			router := mux.NewRouter()
+			router.Use(user.NewMiddleware().Handle)
+			router.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+				actualUser, actualFound = user.FromContext(req.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			rw := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(rw, testCase.inputRequest)
+
+			// then
+			assert.Equal(t, http.StatusOK, rw.Code)
+			assert.Equal(t, testCase.expectedFound, actualFound)
+			assert.Equal(t, testCase.expectedUser, actualUser)
+		})
+	}
+}
+
+func sampleRequest() *http.Request {
+	return httptest.NewRequest("GET", "/", strings.NewReader(""))
+}
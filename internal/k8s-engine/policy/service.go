@@ -55,6 +55,10 @@ func (s *Service) Get(ctx context.Context) (policy.Policy, error) {
 
 // Update updates current Capact Policy configuration with a given input.
 func (s *Service) Update(ctx context.Context, in policy.Policy) (policy.Policy, error) {
+	if err := in.Validate(); err != nil {
+		return policy.Policy{}, errors.Wrap(err, "while validating Policy")
+	}
+
 	cfgMap, err := s.getConfigMap(ctx)
 	if err != nil {
 		return policy.Policy{}, err
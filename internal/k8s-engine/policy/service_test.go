@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"capact.io/capact/internal/ptr"
 	corev1alpha1 "capact.io/capact/pkg/engine/k8s/api/v1alpha1"
 	"capact.io/capact/pkg/engine/k8s/policy"
 	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
@@ -55,6 +56,58 @@ func TestService_Update(t *testing.T) {
 	getConfigMapAndAssertEqual(t, k8sCli, model)
 }
 
+func TestService_UpdateInvalidImplementationSelection(t *testing.T) {
+	tests := []struct {
+		name        string
+		selection   *policy.ImplementationSelection
+		expectedErr string
+	}{
+		{
+			name:        "Unknown strategy",
+			selection:   &policy.ImplementationSelection{Strategy: "RANDOM"},
+			expectedErr: `unknown Implementation selection strategy "RANDOM"`,
+		},
+		{
+			name:        "Missing preferred Attributes",
+			selection:   &policy.ImplementationSelection{Strategy: policy.AttributePreferenceImplementationSelection},
+			expectedErr: "preferred Attributes must be provided for ATTRIBUTE_PREFERENCE strategy",
+		},
+		{
+			name:        "Missing Attribute weights",
+			selection:   &policy.ImplementationSelection{Strategy: policy.WeightedScoreImplementationSelection},
+			expectedErr: "Attribute weights must be provided for WEIGHTED_SCORE strategy",
+		},
+		{
+			name: "Invalid appVersion constraint",
+			selection: &policy.ImplementationSelection{
+				Strategy:             policy.HighestAppVersionImplementationSelection,
+				AppVersionConstraint: ptr.String("not-a-constraint"),
+			},
+			expectedErr: `while parsing appVersion constraint "not-a-constraint"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			model := fixModel()
+			cfgMap := fixCfgMap(t, model)
+
+			svc, k8sCli := newServiceWithFakeClient(t, cfgMap)
+
+			invalid := fixModel()
+			invalid.Interface.Rules[0].OneOf[0].ImplementationSelection = tt.selection
+
+			// when
+			_, err := svc.Update(context.Background(), invalid)
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+			getConfigMapAndAssertEqual(t, k8sCli, model)
+		})
+	}
+}
+
 func TestService_Get(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		// given
@@ -5,20 +5,31 @@ import (
 	"encoding/json"
 
 	"capact.io/capact/pkg/runner"
+	noopreporter "capact.io/capact/pkg/runner/status-reporter"
+
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
-// SecretStatusEntryKey defines key name under which Action status is saved.
-const SecretStatusEntryKey = "status"
+const (
+	// SecretStatusEntryKey defines key name under which Action status is saved.
+	SecretStatusEntryKey = "status"
+	// SecretProgressEntryKey defines key name under which Action runner progress is saved.
+	SecretProgressEntryKey = "progress"
+	// SecretWarningsEntryKey defines key name under which warnings reported by runners executed as workflow steps are saved.
+	SecretWarningsEntryKey = "warnings"
+)
 
 var _ runner.StatusReporter = &K8sSecretReporter{}
 
 // K8sSecretReporter provides functionality to report status from Action Runner in a way that K8s Engine can
 // consume it later.
 type K8sSecretReporter struct {
-	cli client.Client
+	cli          client.Client
+	progressOnly bool
 }
 
 // NewK8sSecret returns new K8sSecretReporter instance.
@@ -28,8 +39,97 @@ func NewK8sSecret(cli client.Client) *K8sSecretReporter {
 	}
 }
 
+// NewK8sSecretProgress returns new K8sSecretReporter instance, which reports only the progress and warnings.
+// It is used by runners executed as workflow steps, which must not override the status reported by the Action runner.
+func NewK8sSecretProgress(cli client.Client) *K8sSecretReporter {
+	return &K8sSecretReporter{
+		cli:          cli,
+		progressOnly: true,
+	}
+}
+
 // Report a given status to K8s Secret, so K8s engine can consume it later.
+// The Secret is patched, so reporting status doesn't conflict with reporting progress.
+// If the reporter reports only the progress, only the warning from runner.WarningStatus is reported.
 func (c *K8sSecretReporter) Report(ctx context.Context, runnerCtx runner.Context, status interface{}) error {
+	if c.progressOnly {
+		warningStatus, ok := status.(runner.WarningStatus)
+		if !ok || warningStatus.Warning() == "" {
+			return nil
+		}
+		return c.reportWarning(ctx, runnerCtx, warningStatus.Warning())
+	}
+
+	secret, err := c.getSecret(ctx, runnerCtx)
+	if err != nil {
+		return err
+	}
+	base := secret.DeepCopy()
+
+	jsonStatus, err := json.Marshal(status)
+	if err != nil {
+		return errors.Wrap(err, "while marshaling status")
+	}
+	secret.Data[SecretStatusEntryKey] = jsonStatus
+
+	if err := c.cli.Patch(ctx, secret, client.MergeFrom(base)); err != nil {
+		return errors.Wrap(err, "while patching Secret")
+	}
+
+	return nil
+}
+
+// ReportProgress reports a given progress to K8s Secret, so K8s engine can consume it later.
+// The Secret is patched, so reporting progress doesn't conflict with reporting status.
+func (c *K8sSecretReporter) ReportProgress(ctx context.Context, runnerCtx runner.Context, progress runner.Progress) error {
+	secret, err := c.getSecret(ctx, runnerCtx)
+	if err != nil {
+		return err
+	}
+	base := secret.DeepCopy()
+
+	jsonProgress, err := json.Marshal(progress)
+	if err != nil {
+		return errors.Wrap(err, "while marshaling progress")
+	}
+	secret.Data[SecretProgressEntryKey] = jsonProgress
+
+	if err := c.cli.Patch(ctx, secret, client.MergeFrom(base)); err != nil {
+		return errors.Wrap(err, "while patching Secret")
+	}
+
+	return nil
+}
+
+// reportWarning appends a given warning to the K8s Secret. Workflow steps can be executed in parallel,
+// so the Secret is patched with optimistic lock to not lose warnings reported by other steps.
+func (c *K8sSecretReporter) reportWarning(ctx context.Context, runnerCtx runner.Context, warning string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := c.getSecret(ctx, runnerCtx)
+		if err != nil {
+			return err
+		}
+		base := secret.DeepCopy()
+
+		var warnings []string
+		if data, found := secret.Data[SecretWarningsEntryKey]; found {
+			if err := json.Unmarshal(data, &warnings); err != nil {
+				return errors.Wrap(err, "while unmarshaling warnings")
+			}
+		}
+
+		jsonWarnings, err := json.Marshal(append(warnings, warning))
+		if err != nil {
+			return errors.Wrap(err, "while marshaling warnings")
+		}
+		secret.Data[SecretWarningsEntryKey] = jsonWarnings
+
+		// the error is not wrapped, so the conflict can be detected
+		return c.cli.Patch(ctx, secret, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+	})
+}
+
+func (c *K8sSecretReporter) getSecret(ctx context.Context, runnerCtx runner.Context) (*v1.Secret, error) {
 	secret := &v1.Secret{}
 	key := client.ObjectKey{
 		Name:      runnerCtx.Name,
@@ -37,22 +137,28 @@ func (c *K8sSecretReporter) Report(ctx context.Context, runnerCtx runner.Context
 	}
 
 	if err := c.cli.Get(ctx, key, secret); err != nil {
-		return errors.Wrap(err, "while getting Secret")
+		return nil, errors.Wrap(err, "while getting Secret")
 	}
 
 	if secret.Data == nil {
 		secret.Data = map[string][]byte{}
 	}
 
-	jsonStatus, err := json.Marshal(status)
+	return secret, nil
+}
+
+// NewK8sSecretProgressOrNoop returns K8sSecretReporter, which reports only the progress and warnings.
+// If the K8s config cannot be loaded, e.g. the runner is executed outside the cluster, the NoopReporter is returned.
+func NewK8sSecretProgressOrNoop() (runner.StatusReporter, error) {
+	k8sCfg, err := config.GetConfig()
 	if err != nil {
-		return errors.Wrap(err, "while marshaling status")
+		return noopreporter.NewNoop(), nil
 	}
-	secret.Data[SecretStatusEntryKey] = jsonStatus
 
-	if err := c.cli.Update(ctx, secret); err != nil {
-		return errors.Wrap(err, "while updating Secret")
+	k8sCli, err := client.New(k8sCfg, client.Options{})
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating K8s client")
 	}
 
-	return nil
+	return NewK8sSecretProgress(k8sCli), nil
 }
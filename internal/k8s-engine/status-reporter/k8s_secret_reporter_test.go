@@ -0,0 +1,116 @@
+package statusreporter
+
+import (
+	"context"
+	"testing"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+)
+
+func TestK8sSecretReporter_ReportProgress(t *testing.T) {
+	// given
+	ctx := context.Background()
+	runnerCtx := runner.Context{
+		Name:     "action",
+		Platform: runner.KubernetesPlatformConfig{Namespace: "default"},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "action", Namespace: "default"},
+		Data: map[string][]byte{
+			SecretStatusEntryKey: []byte(`{"message":"running"}`),
+		},
+	}
+	k8sCli := fake.NewClientBuilder().WithObjects(secret).Build()
+	reporter := NewK8sSecret(k8sCli)
+
+	// when
+	err := reporter.ReportProgress(ctx, runnerCtx, runner.Progress{Phase: "apply", Completed: 1, Total: 2})
+
+	// then
+	require.NoError(t, err)
+	got := getSecret(t, k8sCli)
+	assert.JSONEq(t, `{"phase":"apply","completed":1,"total":2}`, string(got.Data[SecretProgressEntryKey]))
+	assert.JSONEq(t, `{"message":"running"}`, string(got.Data[SecretStatusEntryKey]))
+
+	// when
+	err = reporter.Report(ctx, runnerCtx, map[string]string{"message": "finished"})
+
+	// then
+	require.NoError(t, err)
+	got = getSecret(t, k8sCli)
+	assert.JSONEq(t, `{"phase":"apply","completed":1,"total":2}`, string(got.Data[SecretProgressEntryKey]))
+	assert.JSONEq(t, `{"message":"finished"}`, string(got.Data[SecretStatusEntryKey]))
+}
+
+func TestK8sSecretReporter_ProgressOnly(t *testing.T) {
+	// given
+	ctx := context.Background()
+	runnerCtx := runner.Context{
+		Name:     "action",
+		Platform: runner.KubernetesPlatformConfig{Namespace: "default"},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "action", Namespace: "default"},
+	}
+	k8sCli := fake.NewClientBuilder().WithObjects(secret).Build()
+	reporter := NewK8sSecretProgress(k8sCli)
+
+	// when
+	err := reporter.Report(ctx, runnerCtx, map[string]string{"message": "finished"})
+	require.NoError(t, err)
+	err = reporter.ReportProgress(ctx, runnerCtx, runner.Progress{Phase: "install"})
+
+	// then
+	require.NoError(t, err)
+	got := getSecret(t, k8sCli)
+	assert.JSONEq(t, `{"phase":"install"}`, string(got.Data[SecretProgressEntryKey]))
+	assert.NotContains(t, got.Data, SecretStatusEntryKey)
+}
+
+func TestK8sSecretReporter_ProgressOnlyWarnings(t *testing.T) {
+	// given
+	ctx := context.Background()
+	runnerCtx := runner.Context{
+		Name:     "action",
+		Platform: runner.KubernetesPlatformConfig{Namespace: "default"},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "action", Namespace: "default"},
+	}
+	k8sCli := fake.NewClientBuilder().WithObjects(secret).Build()
+	reporter := NewK8sSecretProgress(k8sCli)
+
+	// when
+	err := reporter.Report(ctx, runnerCtx, warningStatus("outputs failed"))
+	require.NoError(t, err)
+	err = reporter.Report(ctx, runnerCtx, warningStatus(""))
+	require.NoError(t, err)
+	err = reporter.Report(ctx, runnerCtx, warningStatus("cleanup failed"))
+
+	// then
+	require.NoError(t, err)
+	got := getSecret(t, k8sCli)
+	assert.JSONEq(t, `["outputs failed","cleanup failed"]`, string(got.Data[SecretWarningsEntryKey]))
+	assert.NotContains(t, got.Data, SecretStatusEntryKey)
+}
+
+type warningStatus string
+
+func (s warningStatus) Warning() string {
+	return string(s)
+}
+
+func getSecret(t *testing.T, k8sCli client.Client) *v1.Secret {
+	t.Helper()
+
+	secret := &v1.Secret{}
+	require.NoError(t, k8sCli.Get(context.Background(), client.ObjectKey{Name: "action", Namespace: "default"}, secret))
+	return secret
+}
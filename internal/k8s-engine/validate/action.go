@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 
 	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"capact.io/capact/pkg/sdk/renderer"
+
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	wfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
 	"github.com/argoproj/argo-workflows/v3/workflow/validate"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // ActionValidator provides functionality to statically validate Action definition.
@@ -24,6 +27,8 @@ func NewActionValidator(wfCli *wfclientset.Clientset) *ActionValidator {
 }
 
 // Validate validates a given Action definition.
+// Validation failures are returned as renderer.PermanentError, errors from the K8s API server are returned as they are,
+// so the validation can be retried.
 func (v *ActionValidator) Validate(action *types.Action, namespace string) error {
 	if action == nil {
 		return nil
@@ -31,17 +36,24 @@ func (v *ActionValidator) Validate(action *types.Action, namespace string) error
 
 	workflow, err := getWorkflowFromAction(action)
 	if err != nil {
-		return errors.Wrap(err, "while getting workflow from Action")
+		return renderer.NewPermanentError(renderer.InvalidManifestReason, errors.Wrap(err, "while getting workflow from Action"))
 	}
 
-	wfTmplGetter := templateresolution.WrapWorkflowTemplateInterface(v.wfCli.ArgoprojV1alpha1().WorkflowTemplates(namespace))
-	cwfTmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(v.wfCli.ArgoprojV1alpha1().ClusterWorkflowTemplates())
+	wfTmplGetter := &wfTemplateGetter{
+		getter: templateresolution.WrapWorkflowTemplateInterface(v.wfCli.ArgoprojV1alpha1().WorkflowTemplates(namespace)),
+	}
+	cwfTmplGetter := &clusterWfTemplateGetter{
+		getter: templateresolution.WrapClusterWorkflowTemplateInterface(v.wfCli.ArgoprojV1alpha1().ClusterWorkflowTemplates()),
+	}
 
 	_, err = validate.ValidateWorkflow(wfTmplGetter, cwfTmplGetter, workflow, validate.ValidateOpts{
 		Lint: true,
 	})
+	if apiErr := firstError(wfTmplGetter.apiErr, cwfTmplGetter.apiErr); apiErr != nil {
+		return errors.Wrap(apiErr, "while getting workflow templates")
+	}
 	if err != nil {
-		return errors.Wrap(err, "while linting workflow")
+		return renderer.NewPermanentError(renderer.InvalidManifestReason, errors.Wrap(err, "while linting workflow"))
 	}
 
 	return nil
@@ -61,3 +73,45 @@ func getWorkflowFromAction(action *types.Action) (*wfv1.Workflow, error) {
 
 	return workflow, nil
 }
+
+// wfTemplateGetter records the K8s API server errors, other than NotFound,
+// as Argo validation reports them in the same way as invalid template references.
+type wfTemplateGetter struct {
+	getter templateresolution.WorkflowTemplateNamespacedGetter
+	apiErr error
+}
+
+func (g *wfTemplateGetter) Get(name string) (*wfv1.WorkflowTemplate, error) {
+	tmpl, err := g.getter.Get(name)
+	g.apiErr = firstError(g.apiErr, apiError(err))
+	return tmpl, err
+}
+
+// clusterWfTemplateGetter records the K8s API server errors, other than NotFound,
+// as Argo validation reports them in the same way as invalid template references.
+type clusterWfTemplateGetter struct {
+	getter templateresolution.ClusterWorkflowTemplateGetter
+	apiErr error
+}
+
+func (g *clusterWfTemplateGetter) Get(name string) (*wfv1.ClusterWorkflowTemplate, error) {
+	tmpl, err := g.getter.Get(name)
+	g.apiErr = firstError(g.apiErr, apiError(err))
+	return tmpl, err
+}
+
+func apiError(err error) error {
+	if err == nil || apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,518 @@
+package kubernetesstoragebackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"capact.io/capact/internal/ptr"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Kind describes the Kubernetes object kind used to store TypeInstance values.
+type Kind string
+
+const (
+	// SecretKind stores TypeInstance values in Kubernetes Secrets.
+	SecretKind Kind = "Secret"
+	// ConfigMapKind stores TypeInstance values in Kubernetes ConfigMaps.
+	ConfigMapKind Kind = "ConfigMap"
+)
+
+const (
+	// TypeInstanceIDAnnotation holds the ID of the TypeInstance, which owns a given Secret or ConfigMap.
+	TypeInstanceIDAnnotation = "storage.capact.io/type-instance-id"
+	// LatestResourceVersionAnnotation holds the latest TypeInstance resource version stored in a given Secret or ConfigMap.
+	LatestResourceVersionAnnotation = "storage.capact.io/latest-resource-version"
+	// LockedByAnnotation holds the ID of the owner, which locked a given TypeInstance.
+	LockedByAnnotation = "storage.capact.io/locked-by"
+
+	managedByLabelKey   = "app.kubernetes.io/managed-by"
+	managedByLabelValue = "capact"
+
+	defaultKey           = "value"
+	objectNamePrefix     = "capact-ti-"
+	firstResourceVersion = 1
+)
+
+// ContextSchema is the JSON schema of the Kubernetes storage backend context.
+const ContextSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema",
+  "type": "object",
+  "properties": {
+    "kind": {
+      "$id": "#/properties/context/properties/kind",
+      "type": "string",
+      "enum": ["Secret", "ConfigMap"],
+      "default": "Secret"
+    },
+    "namespace": {
+      "$id": "#/properties/context/properties/namespace",
+      "type": "string"
+    },
+    "name": {
+      "$id": "#/properties/context/properties/name",
+      "type": "string"
+    },
+    "key": {
+      "$id": "#/properties/context/properties/key",
+      "type": "string",
+      "default": "value"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// Context holds Kubernetes storage backend specific parameters.
+type Context struct {
+	// Kind of the object, which stores the TypeInstance value. Defaults to Secret.
+	Kind Kind `json:"kind,omitempty"`
+	// Namespace of the object. Defaults to the namespace configured for the storage backend.
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the object. Defaults to the TypeInstance ID prefixed with `capact-ti-`.
+	Name string `json:"name,omitempty"`
+	// Key under which the latest TypeInstance value is stored. Defaults to `value`.
+	// Each resource version is stored additionally under the `<key>.<resource version>` key.
+	Key string `json:"key,omitempty"`
+}
+
+// Config holds the Kubernetes storage backend configuration.
+type Config struct {
+	// DefaultNamespace is the namespace used for TypeInstances, which don't have the namespace specified in context.
+	DefaultNamespace string
+	// AllowedNamespaces holds the namespaces, in which the TypeInstance values can be stored.
+	// If empty, only the DefaultNamespace is allowed.
+	AllowedNamespaces []string
+	// MaxRevisions is the number of the latest resource versions kept in a given Secret or ConfigMap.
+	// Older resource versions are removed on update. If zero, all resource versions are kept.
+	MaxRevisions int
+}
+
+var _ pb.StorageBackendServer = &Handler{}
+
+// Handler handles incoming requests to the Kubernetes storage backend gRPC server.
+type Handler struct {
+	pb.UnimplementedStorageBackendServer
+
+	log *zap.Logger
+
+	defaultNamespace  string
+	allowedNamespaces []string
+	maxRevisions      int
+	stores            map[Kind]objectStore
+}
+
+var (
+	// NilRequestInputError describes an error with an invalid request.
+	NilRequestInputError = status.Error(codes.InvalidArgument, "request data cannot be nil")
+)
+
+// NewHandler returns new Handler.
+func NewHandler(log *zap.Logger, client kubernetes.Interface, cfg Config) *Handler {
+	allowedNamespaces := cfg.AllowedNamespaces
+	if len(allowedNamespaces) == 0 {
+		allowedNamespaces = []string{cfg.DefaultNamespace}
+	}
+
+	return &Handler{
+		log:               log,
+		defaultNamespace:  cfg.DefaultNamespace,
+		allowedNamespaces: allowedNamespaces,
+		maxRevisions:      cfg.MaxRevisions,
+		stores: map[Kind]objectStore{
+			SecretKind:    &secretStore{client: client},
+			ConfigMapKind: &configMapStore{client: client},
+		},
+	}
+}
+
+// GetValue returns a value for a given TypeInstance resource version.
+func (h *Handler) GetValue(ctx context.Context, request *pb.GetValueRequest) (*pb.GetValueResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	storageCtx, store, err := h.resolveContext(request.TypeInstanceId, request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := h.getOwnedObject(ctx, store, storageCtx, request.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := obj.Data[versionedKey(storageCtx.Key, request.ResourceVersion)]
+	if !ok {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("TypeInstance %q in revision %d was not found", request.TypeInstanceId, request.ResourceVersion))
+	}
+
+	return &pb.GetValueResponse{
+		Value: value,
+	}, nil
+}
+
+// GetLockedBy returns a locked by data for a given TypeInstance.
+func (h *Handler) GetLockedBy(ctx context.Context, request *pb.GetLockedByRequest) (*pb.GetLockedByResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	storageCtx, store, err := h.resolveContext(request.TypeInstanceId, request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := h.getOwnedObject(ctx, store, storageCtx, request.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	var lockedBy *string
+	if value := obj.Annotations[LockedByAnnotation]; value != "" {
+		lockedBy = ptr.String(value)
+	}
+
+	return &pb.GetLockedByResponse{
+		LockedBy: lockedBy,
+	}, nil
+}
+
+// OnCreate handles TypeInstance creation by creating a Secret or ConfigMap.
+// It returns the context with all default values resolved.
+func (h *Handler) OnCreate(ctx context.Context, request *pb.OnCreateRequest) (*pb.OnCreateResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	storageCtx, store, err := h.resolveContext(request.TypeInstanceId, request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &storedObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      storageCtx.Name,
+			Namespace: storageCtx.Namespace,
+			Labels: map[string]string{
+				managedByLabelKey: managedByLabelValue,
+			},
+			Annotations: map[string]string{
+				TypeInstanceIDAnnotation:        request.TypeInstanceId,
+				LatestResourceVersionAnnotation: strconv.Itoa(firstResourceVersion),
+			},
+		},
+		Data: map[string][]byte{
+			storageCtx.Key: request.Value,
+			versionedKey(storageCtx.Key, firstResourceVersion): request.Value,
+		},
+	}
+
+	h.log.Info("creating object", zap.String("kind", string(storageCtx.Kind)), zap.String("namespace", storageCtx.Namespace), zap.String("name", storageCtx.Name))
+	err = store.Create(ctx, obj)
+	switch {
+	case err == nil:
+	case apierrors.IsAlreadyExists(err):
+		return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("%s %q already exist", storageCtx.Kind, h.objectKey(storageCtx)))
+	default:
+		return nil, h.internalError(errors.Wrapf(err, "while creating %s %q", storageCtx.Kind, h.objectKey(storageCtx)))
+	}
+
+	resolvedCtx, err := json.Marshal(storageCtx)
+	if err != nil {
+		return nil, h.internalError(errors.Wrap(err, "while marshaling context"))
+	}
+
+	return &pb.OnCreateResponse{
+		Context: resolvedCtx,
+	}, nil
+}
+
+// OnUpdate handles TypeInstance update by storing a new resource version in a given Secret or ConfigMap.
+func (h *Handler) OnUpdate(ctx context.Context, request *pb.OnUpdateRequest) (*pb.OnUpdateResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	storageCtx, store, err := h.resolveContext(request.TypeInstanceId, request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := h.getOwnedObject(ctx, store, storageCtx, request.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.ensureObjectIsNotLockedByOthers(storageCtx, obj, request.OwnerId); err != nil {
+		return nil, err
+	}
+
+	key := versionedKey(storageCtx.Key, request.NewResourceVersion)
+	if _, exists := obj.Data[key]; exists {
+		return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("key %q in %s %q already exist", key, storageCtx.Kind, h.objectKey(storageCtx)))
+	}
+
+	if obj.Data == nil {
+		obj.Data = map[string][]byte{}
+	}
+	obj.Data[key] = request.NewValue
+	obj.Data[storageCtx.Key] = request.NewValue
+	pruneRevisions(obj.Data, storageCtx.Key, h.maxRevisions)
+	obj.Annotations[LatestResourceVersionAnnotation] = strconv.Itoa(int(request.NewResourceVersion))
+
+	if err := h.updateObject(ctx, store, storageCtx, obj); err != nil {
+		return nil, err
+	}
+
+	return &pb.OnUpdateResponse{}, nil
+}
+
+// OnLock handles TypeInstance locking by setting the locked by annotation on a given Secret or ConfigMap.
+// It returns an error if a given TypeInstance is already locked.
+func (h *Handler) OnLock(ctx context.Context, request *pb.OnLockRequest) (*pb.OnLockResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	storageCtx, store, err := h.resolveContext(request.TypeInstanceId, request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := h.getOwnedObject(ctx, store, storageCtx, request.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.ensureObjectIsNotLockedByOthers(storageCtx, obj, nil); err != nil {
+		return nil, err
+	}
+
+	obj.Annotations[LockedByAnnotation] = request.LockedBy
+
+	if err := h.updateObject(ctx, store, storageCtx, obj); err != nil {
+		return nil, err
+	}
+
+	return &pb.OnLockResponse{}, nil
+}
+
+// OnUnlock handles TypeInstance unlocking by removing the locked by annotation from a given Secret or ConfigMap.
+func (h *Handler) OnUnlock(ctx context.Context, request *pb.OnUnlockRequest) (*pb.OnUnlockResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	storageCtx, store, err := h.resolveContext(request.TypeInstanceId, request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := h.getOwnedObject(ctx, store, storageCtx, request.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(obj.Annotations, LockedByAnnotation)
+
+	if err := h.updateObject(ctx, store, storageCtx, obj); err != nil {
+		return nil, err
+	}
+
+	return &pb.OnUnlockResponse{}, nil
+}
+
+// OnDelete handles TypeInstance deletion by removing a given Secret or ConfigMap.
+// It checks whether a given TypeInstance is locked before doing such operation.
+func (h *Handler) OnDelete(ctx context.Context, request *pb.OnDeleteRequest) (*pb.OnDeleteResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	storageCtx, store, err := h.resolveContext(request.TypeInstanceId, request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := h.getOwnedObject(ctx, store, storageCtx, request.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.ensureObjectIsNotLockedByOthers(storageCtx, obj, request.OwnerId); err != nil {
+		return nil, err
+	}
+
+	h.log.Info("deleting object", zap.String("kind", string(storageCtx.Kind)), zap.String("namespace", storageCtx.Namespace), zap.String("name", storageCtx.Name))
+	err = store.Delete(ctx, storageCtx.Namespace, storageCtx.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, h.internalError(errors.Wrapf(err, "while deleting TypeInstance %q", request.TypeInstanceId))
+	}
+
+	return &pb.OnDeleteResponse{}, nil
+}
+
+// GetCapabilities returns the features supported by the Kubernetes storage backend.
+func (h *Handler) GetCapabilities(_ context.Context, _ *pb.GetCapabilitiesRequest) (*pb.GetCapabilitiesResponse, error) {
+	return &pb.GetCapabilitiesResponse{
+		ValueStorage:  true,
+		Locking:       true,
+		Versioning:    true,
+		ContextSchema: ptr.String(ContextSchema),
+	}, nil
+}
+
+// resolveContext unmarshals the context and sets the default values for all properties, which are not set.
+func (h *Handler) resolveContext(typeInstanceID string, contextBytes []byte) (Context, objectStore, error) {
+	var storageCtx Context
+	if len(contextBytes) > 0 {
+		err := json.Unmarshal(contextBytes, &storageCtx)
+		if err != nil {
+			return Context{}, nil, h.internalError(errors.Wrap(err, "while unmarshaling context"))
+		}
+	}
+
+	if storageCtx.Kind == "" {
+		storageCtx.Kind = SecretKind
+	}
+	if storageCtx.Namespace == "" {
+		storageCtx.Namespace = h.defaultNamespace
+	}
+	if storageCtx.Name == "" {
+		storageCtx.Name = objectNamePrefix + typeInstanceID
+	}
+	if storageCtx.Key == "" {
+		storageCtx.Key = defaultKey
+	}
+
+	store, ok := h.stores[storageCtx.Kind]
+	if !ok {
+		return Context{}, nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unsupported kind %q: expected %q or %q", storageCtx.Kind, SecretKind, ConfigMapKind))
+	}
+
+	if !h.isNamespaceAllowed(storageCtx.Namespace) {
+		return Context{}, nil, status.Error(codes.InvalidArgument, fmt.Sprintf("namespace %q is not allowed: expected one of %q", storageCtx.Namespace, h.allowedNamespaces))
+	}
+
+	if errs := validation.IsDNS1123Subdomain(storageCtx.Name); len(errs) > 0 {
+		return Context{}, nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid name %q: %v", storageCtx.Name, errs))
+	}
+	if errs := validation.IsConfigMapKey(storageCtx.Key); len(errs) > 0 {
+		return Context{}, nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid key %q: %v", storageCtx.Key, errs))
+	}
+
+	return storageCtx, store, nil
+}
+
+// getOwnedObject returns the object only if it stores data for a given TypeInstance.
+// Thanks to that, objects which are not managed by the storage backend are never modified.
+func (h *Handler) getOwnedObject(ctx context.Context, store objectStore, storageCtx Context, typeInstanceID string) (*storedObject, error) {
+	h.log.Info("getting object", zap.String("kind", string(storageCtx.Kind)), zap.String("namespace", storageCtx.Namespace), zap.String("name", storageCtx.Name))
+	obj, err := store.Get(ctx, storageCtx.Namespace, storageCtx.Name)
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("%s %q for TypeInstance %q not found", storageCtx.Kind, h.objectKey(storageCtx), typeInstanceID))
+	default:
+		return nil, h.internalError(errors.Wrapf(err, "while getting %s %q", storageCtx.Kind, h.objectKey(storageCtx)))
+	}
+
+	if owner := obj.Annotations[TypeInstanceIDAnnotation]; owner != typeInstanceID {
+		return nil, h.failedPreconditionError(fmt.Errorf("%s %q is not owned by TypeInstance %q: %q annotation has value %q", storageCtx.Kind, h.objectKey(storageCtx), typeInstanceID, TypeInstanceIDAnnotation, owner))
+	}
+
+	return obj, nil
+}
+
+func (h *Handler) updateObject(ctx context.Context, store objectStore, storageCtx Context, obj *storedObject) error {
+	h.log.Info("updating object", zap.String("kind", string(storageCtx.Kind)), zap.String("namespace", storageCtx.Namespace), zap.String("name", storageCtx.Name))
+	err := store.Update(ctx, obj)
+	switch {
+	case err == nil:
+	case apierrors.IsConflict(err):
+		return status.Error(codes.Aborted, fmt.Sprintf("%s %q was modified concurrently, try again", storageCtx.Kind, h.objectKey(storageCtx)))
+	default:
+		return h.internalError(errors.Wrapf(err, "while updating %s %q", storageCtx.Kind, h.objectKey(storageCtx)))
+	}
+
+	return nil
+}
+
+func (h *Handler) ensureObjectIsNotLockedByOthers(storageCtx Context, obj *storedObject, ownerID *string) error {
+	lockedBy := obj.Annotations[LockedByAnnotation]
+	if lockedBy == "" {
+		return nil
+	}
+	if ownerID != nil && lockedBy == *ownerID {
+		return nil
+	}
+
+	return h.failedPreconditionError(fmt.Errorf("typeInstance locked: %s %q contains %q annotation with value %q", storageCtx.Kind, h.objectKey(storageCtx), LockedByAnnotation, lockedBy))
+}
+
+func (h *Handler) isNamespaceAllowed(ns string) bool {
+	for _, allowed := range h.allowedNamespaces {
+		if ns == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) objectKey(storageCtx Context) string {
+	return fmt.Sprintf("%s/%s", storageCtx.Namespace, storageCtx.Name)
+}
+
+func (h *Handler) internalError(err error) error {
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (h *Handler) failedPreconditionError(err error) error {
+	return status.Error(codes.FailedPrecondition, err.Error())
+}
+
+func versionedKey(key string, resourceVersion uint32) string {
+	return fmt.Sprintf("%s.%d", key, resourceVersion)
+}
+
+// pruneRevisions removes the oldest resource versions stored under the `<key>.<resource version>` keys,
+// so that at most maxRevisions of them are kept. It keeps all resource versions if maxRevisions is zero.
+func pruneRevisions(data map[string][]byte, key string, maxRevisions int) {
+	if maxRevisions <= 0 {
+		return
+	}
+
+	prefix := key + "."
+	var revisions []uint32
+	for k := range data {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		rev, err := strconv.ParseUint(strings.TrimPrefix(k, prefix), 10, 32)
+		if err != nil {
+			continue
+		}
+		revisions = append(revisions, uint32(rev))
+	}
+
+	if len(revisions) <= maxRevisions {
+		return
+	}
+
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i] < revisions[j] })
+	for _, rev := range revisions[:len(revisions)-maxRevisions] {
+		delete(data, versionedKey(key, rev))
+	}
+}
@@ -0,0 +1,489 @@
+package kubernetesstoragebackend_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kubernetes_storage_backend "capact.io/capact/internal/kubernetes-storage-backend"
+	"capact.io/capact/internal/logger"
+	"capact.io/capact/internal/ptr"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	typeInstanceID   = "uuid"
+	defaultNamespace = "capact-system"
+)
+
+func TestHandler_OnCreate(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		InputContext    []byte
+		ExpectedContext string
+		ExpectedObject  func(t *testing.T, client *fake.Clientset) map[string]string
+	}{
+		{
+			Name:            "Default context",
+			InputContext:    nil,
+			ExpectedContext: `{"kind":"Secret","namespace":"capact-system","name":"capact-ti-uuid","key":"value"}`,
+			ExpectedObject: func(t *testing.T, client *fake.Clientset) map[string]string {
+				secret, err := client.CoreV1().Secrets(defaultNamespace).Get(context.Background(), "capact-ti-uuid", metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.Equal(t, typeInstanceID, secret.Annotations[kubernetes_storage_backend.TypeInstanceIDAnnotation])
+				assert.Equal(t, "1", secret.Annotations[kubernetes_storage_backend.LatestResourceVersionAnnotation])
+				return bytesToStrings(secret.Data)
+			},
+		},
+		{
+			Name:            "ConfigMap with custom name and key",
+			InputContext:    []byte(`{"kind":"ConfigMap","namespace":"app","name":"db-config","key":"config.json"}`),
+			ExpectedContext: `{"kind":"ConfigMap","namespace":"app","name":"db-config","key":"config.json"}`,
+			ExpectedObject: func(t *testing.T, client *fake.Clientset) map[string]string {
+				cm, err := client.CoreV1().ConfigMaps("app").Get(context.Background(), "db-config", metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.Equal(t, typeInstanceID, cm.Annotations[kubernetes_storage_backend.TypeInstanceIDAnnotation])
+				return cm.Data
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			client := fake.NewSimpleClientset()
+			handler := kubernetes_storage_backend.NewHandler(logger.Noop(), client, fixConfig())
+
+			// when
+			res, err := handler.OnCreate(context.Background(), &pb.OnCreateRequest{
+				TypeInstanceId: typeInstanceID,
+				Value:          []byte(`{"key":true}`),
+				Context:        testCase.InputContext,
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.ExpectedContext, string(res.Context))
+
+			data := testCase.ExpectedObject(t, client)
+			key := "value"
+			if testCase.InputContext != nil {
+				key = "config.json"
+			}
+			assert.Equal(t, map[string]string{
+				key:        `{"key":true}`,
+				key + ".1": `{"key":true}`,
+			}, data)
+		})
+	}
+}
+
+func TestHandler_OnCreate_Failures(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		InputContext         []byte
+		ExistingObjects      []runtime.Object
+		ExpectedErrorMessage string
+	}{
+		{
+			Name:                 "Already exists",
+			ExistingObjects:      []runtime.Object{fixSecret(map[string]string{"value": "foo", "value.1": "foo"}, nil)},
+			ExpectedErrorMessage: `rpc error: code = AlreadyExists desc = Secret "capact-system/capact-ti-uuid" already exist`,
+		},
+		{
+			Name:                 "Unsupported kind",
+			InputContext:         []byte(`{"kind":"Pod"}`),
+			ExpectedErrorMessage: `rpc error: code = InvalidArgument desc = unsupported kind "Pod": expected "Secret" or "ConfigMap"`,
+		},
+		{
+			Name:                 "Not allowed namespace",
+			InputContext:         []byte(`{"namespace":"kube-system"}`),
+			ExpectedErrorMessage: `rpc error: code = InvalidArgument desc = namespace "kube-system" is not allowed: expected one of ["capact-system" "app"]`,
+		},
+		{
+			Name:                 "Invalid key",
+			InputContext:         []byte(`{"key":"invalid/key"}`),
+			ExpectedErrorMessage: `rpc error: code = InvalidArgument desc = invalid key "invalid/key": [a valid config key must consist of alphanumeric characters, '-', '_' or '.' (e.g. 'key.name',  or 'KEY_NAME',  or 'key-name', regex used for validation is '[-._a-zA-Z0-9]+')]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			client := fake.NewSimpleClientset(testCase.ExistingObjects...)
+			handler := kubernetes_storage_backend.NewHandler(logger.Noop(), client, fixConfig())
+
+			// when
+			res, err := handler.OnCreate(context.Background(), &pb.OnCreateRequest{
+				TypeInstanceId: typeInstanceID,
+				Value:          []byte(`{"key":true}`),
+				Context:        testCase.InputContext,
+			})
+
+			// then
+			assert.Nil(t, res)
+			assert.EqualError(t, err, testCase.ExpectedErrorMessage)
+		})
+	}
+}
+
+func TestHandler_GetValue(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		ExistingObjects      []runtime.Object
+		ExpectedValue        []byte
+		ExpectedErrorMessage *string
+	}{
+		{
+			Name:                 "No object",
+			ExpectedErrorMessage: ptr.String(`rpc error: code = NotFound desc = Secret "capact-system/capact-ti-uuid" for TypeInstance "uuid" not found`),
+		},
+		{
+			Name: "No revision",
+			ExistingObjects: []runtime.Object{
+				fixSecret(map[string]string{"value": "foo", "value.1": "foo"}, nil),
+			},
+			ExpectedErrorMessage: ptr.String(`rpc error: code = NotFound desc = TypeInstance "uuid" in revision 2 was not found`),
+		},
+		{
+			Name: "Not owned object",
+			ExistingObjects: []runtime.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "capact-ti-uuid", Namespace: defaultNamespace}},
+			},
+			ExpectedErrorMessage: ptr.String(`rpc error: code = FailedPrecondition desc = Secret "capact-system/capact-ti-uuid" is not owned by TypeInstance "uuid": "storage.capact.io/type-instance-id" annotation has value ""`),
+		},
+		{
+			Name: "Success",
+			ExistingObjects: []runtime.Object{
+				fixSecret(map[string]string{"value": "bar", "value.1": "foo", "value.2": "bar"}, nil),
+			},
+			ExpectedValue: []byte("bar"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			client := fake.NewSimpleClientset(testCase.ExistingObjects...)
+			handler := kubernetes_storage_backend.NewHandler(logger.Noop(), client, fixConfig())
+
+			// when
+			res, err := handler.GetValue(context.Background(), &pb.GetValueRequest{
+				TypeInstanceId:  typeInstanceID,
+				ResourceVersion: 2,
+			})
+
+			// then
+			if testCase.ExpectedErrorMessage != nil {
+				assert.Nil(t, res)
+				assert.EqualError(t, err, *testCase.ExpectedErrorMessage)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedValue, res.Value)
+		})
+	}
+}
+
+func TestHandler_OnUpdate(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		ExistingObjects      []runtime.Object
+		OwnerID              *string
+		ExpectedErrorMessage *string
+	}{
+		{
+			Name: "Success",
+			ExistingObjects: []runtime.Object{
+				fixSecret(map[string]string{"value": "foo", "value.1": "foo"}, nil),
+			},
+		},
+		{
+			Name: "Success for locked TypeInstance with the same owner",
+			ExistingObjects: []runtime.Object{
+				fixSecret(map[string]string{"value": "foo", "value.1": "foo"}, ptr.String("owner")),
+			},
+			OwnerID: ptr.String("owner"),
+		},
+		{
+			Name: "Locked by others",
+			ExistingObjects: []runtime.Object{
+				fixSecret(map[string]string{"value": "foo", "value.1": "foo"}, ptr.String("other-owner")),
+			},
+			OwnerID:              ptr.String("owner"),
+			ExpectedErrorMessage: ptr.String(`rpc error: code = FailedPrecondition desc = typeInstance locked: Secret "capact-system/capact-ti-uuid" contains "storage.capact.io/locked-by" annotation with value "other-owner"`),
+		},
+		{
+			Name: "Revision already exists",
+			ExistingObjects: []runtime.Object{
+				fixSecret(map[string]string{"value": "foo", "value.1": "foo", "value.2": "foo"}, nil),
+			},
+			ExpectedErrorMessage: ptr.String(`rpc error: code = AlreadyExists desc = key "value.2" in Secret "capact-system/capact-ti-uuid" already exist`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			client := fake.NewSimpleClientset(testCase.ExistingObjects...)
+			handler := kubernetes_storage_backend.NewHandler(logger.Noop(), client, fixConfig())
+
+			// when
+			res, err := handler.OnUpdate(context.Background(), &pb.OnUpdateRequest{
+				TypeInstanceId:     typeInstanceID,
+				NewResourceVersion: 2,
+				NewValue:           []byte("bar"),
+				OwnerId:            testCase.OwnerID,
+			})
+
+			// then
+			if testCase.ExpectedErrorMessage != nil {
+				assert.Nil(t, res)
+				assert.EqualError(t, err, *testCase.ExpectedErrorMessage)
+				return
+			}
+
+			require.NoError(t, err)
+
+			secret, err := client.CoreV1().Secrets(defaultNamespace).Get(context.Background(), "capact-ti-uuid", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{
+				"value":   "bar",
+				"value.1": "foo",
+				"value.2": "bar",
+			}, bytesToStrings(secret.Data))
+			assert.Equal(t, "2", secret.Annotations[kubernetes_storage_backend.LatestResourceVersionAnnotation])
+		})
+	}
+}
+
+func TestHandler_OnUpdate_PrunesRevisions(t *testing.T) {
+	// given
+	client := fake.NewSimpleClientset(fixSecret(map[string]string{"value": "bar", "value.1": "foo", "value.2": "bar"}, nil))
+	cfg := fixConfig()
+	cfg.MaxRevisions = 2
+	handler := kubernetes_storage_backend.NewHandler(logger.Noop(), client, cfg)
+
+	// when
+	_, err := handler.OnUpdate(context.Background(), &pb.OnUpdateRequest{
+		TypeInstanceId:     typeInstanceID,
+		NewResourceVersion: 3,
+		NewValue:           []byte("baz"),
+	})
+
+	// then
+	require.NoError(t, err)
+
+	secret, err := client.CoreV1().Secrets(defaultNamespace).Get(context.Background(), "capact-ti-uuid", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"value":   "baz",
+		"value.2": "bar",
+		"value.3": "baz",
+	}, bytesToStrings(secret.Data))
+}
+
+func TestNewHandler_DefaultAllowedNamespaces(t *testing.T) {
+	// given
+	handler := kubernetes_storage_backend.NewHandler(logger.Noop(), fake.NewSimpleClientset(), kubernetes_storage_backend.Config{
+		DefaultNamespace: defaultNamespace,
+	})
+
+	// when
+	_, err := handler.OnCreate(context.Background(), &pb.OnCreateRequest{
+		TypeInstanceId: typeInstanceID,
+		Value:          []byte(`{"key":true}`),
+		Context:        []byte(`{"namespace":"app"}`),
+	})
+
+	// then
+	assert.EqualError(t, err, `rpc error: code = InvalidArgument desc = namespace "app" is not allowed: expected one of ["capact-system"]`)
+}
+
+func TestHandler_Lock(t *testing.T) {
+	// given
+	client := fake.NewSimpleClientset(fixSecret(map[string]string{"value": "foo", "value.1": "foo"}, nil))
+	handler := kubernetes_storage_backend.NewHandler(logger.Noop(), client, fixConfig())
+	ctx := context.Background()
+
+	// when
+	_, err := handler.OnLock(ctx, &pb.OnLockRequest{TypeInstanceId: typeInstanceID, LockedBy: "owner"})
+	require.NoError(t, err)
+
+	// then
+	lockedBy, err := handler.GetLockedBy(ctx, &pb.GetLockedByRequest{TypeInstanceId: typeInstanceID})
+	require.NoError(t, err)
+	assert.Equal(t, ptr.String("owner"), lockedBy.LockedBy)
+
+	_, err = handler.OnLock(ctx, &pb.OnLockRequest{TypeInstanceId: typeInstanceID, LockedBy: "other-owner"})
+	assert.EqualError(t, err, `rpc error: code = FailedPrecondition desc = typeInstance locked: Secret "capact-system/capact-ti-uuid" contains "storage.capact.io/locked-by" annotation with value "owner"`)
+
+	_, err = handler.OnDelete(ctx, &pb.OnDeleteRequest{TypeInstanceId: typeInstanceID})
+	assert.EqualError(t, err, `rpc error: code = FailedPrecondition desc = typeInstance locked: Secret "capact-system/capact-ti-uuid" contains "storage.capact.io/locked-by" annotation with value "owner"`)
+
+	// when
+	_, err = handler.OnUnlock(ctx, &pb.OnUnlockRequest{TypeInstanceId: typeInstanceID})
+	require.NoError(t, err)
+
+	// then
+	lockedBy, err = handler.GetLockedBy(ctx, &pb.GetLockedByRequest{TypeInstanceId: typeInstanceID})
+	require.NoError(t, err)
+	assert.Nil(t, lockedBy.LockedBy)
+}
+
+func TestHandler_OnDelete(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		ExistingObjects      []runtime.Object
+		OwnerID              *string
+		ExpectedErrorMessage *string
+	}{
+		{
+			Name:                 "No object",
+			ExpectedErrorMessage: ptr.String(`rpc error: code = NotFound desc = ConfigMap "capact-system/capact-ti-uuid" for TypeInstance "uuid" not found`),
+		},
+		{
+			Name: "Success",
+			ExistingObjects: []runtime.Object{
+				fixConfigMap(map[string]string{"value": "foo", "value.1": "foo"}, nil),
+			},
+		},
+		{
+			Name: "Success for locked TypeInstance with the same owner",
+			ExistingObjects: []runtime.Object{
+				fixConfigMap(map[string]string{"value": "foo", "value.1": "foo"}, ptr.String("owner")),
+			},
+			OwnerID: ptr.String("owner"),
+		},
+		{
+			Name: "Locked by others",
+			ExistingObjects: []runtime.Object{
+				fixConfigMap(map[string]string{"value": "foo", "value.1": "foo"}, ptr.String("other-owner")),
+			},
+			OwnerID:              ptr.String("owner"),
+			ExpectedErrorMessage: ptr.String(`rpc error: code = FailedPrecondition desc = typeInstance locked: ConfigMap "capact-system/capact-ti-uuid" contains "storage.capact.io/locked-by" annotation with value "other-owner"`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			client := fake.NewSimpleClientset(testCase.ExistingObjects...)
+			handler := kubernetes_storage_backend.NewHandler(logger.Noop(), client, fixConfig())
+
+			// when
+			res, err := handler.OnDelete(context.Background(), &pb.OnDeleteRequest{
+				TypeInstanceId: typeInstanceID,
+				Context:        []byte(`{"kind":"ConfigMap"}`),
+				OwnerId:        testCase.OwnerID,
+			})
+
+			// then
+			if testCase.ExpectedErrorMessage != nil {
+				assert.Nil(t, res)
+				assert.EqualError(t, err, *testCase.ExpectedErrorMessage)
+				return
+			}
+
+			require.NoError(t, err)
+			cms, err := client.CoreV1().ConfigMaps(defaultNamespace).List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, cms.Items)
+		})
+	}
+}
+
+func TestHandler_NilRequest(t *testing.T) {
+	// given
+	handler := kubernetes_storage_backend.NewHandler(logger.Noop(), fake.NewSimpleClientset(), fixConfig())
+
+	// when
+	_, err := handler.GetValue(context.Background(), nil)
+
+	// then
+	assert.Equal(t, kubernetes_storage_backend.NilRequestInputError, err)
+}
+
+func TestHandler_GetCapabilities(t *testing.T) {
+	// given
+	handler := kubernetes_storage_backend.NewHandler(logger.Noop(), fake.NewSimpleClientset(), fixConfig())
+
+	// when
+	res, err := handler.GetCapabilities(context.Background(), &pb.GetCapabilitiesRequest{})
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, res.ValueStorage)
+	assert.True(t, res.Locking)
+	assert.True(t, res.Versioning)
+	assert.False(t, res.ReadOnly)
+	require.NotNil(t, res.ContextSchema)
+
+	schema := gojsonschema.NewStringLoader(*res.ContextSchema)
+
+	result, err := gojsonschema.Validate(schema, gojsonschema.NewStringLoader(`{"kind":"ConfigMap","namespace":"default","name":"app-config"}`))
+	require.NoError(t, err)
+	assert.True(t, result.Valid(), result.Errors())
+
+	result, err = gojsonschema.Validate(schema, gojsonschema.NewStringLoader(`{"kind":"Deployment"}`))
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+}
+
+func fixConfig() kubernetes_storage_backend.Config {
+	return kubernetes_storage_backend.Config{
+		DefaultNamespace:  defaultNamespace,
+		AllowedNamespaces: []string{defaultNamespace, "app"},
+	}
+}
+
+func fixSecret(data map[string]string, lockedBy *string) *corev1.Secret {
+	secretData := map[string][]byte{}
+	for k, v := range data {
+		secretData[k] = []byte(v)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: fixObjectMeta(len(data)-1, lockedBy),
+		Data:       secretData,
+	}
+}
+
+func fixConfigMap(data map[string]string, lockedBy *string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: fixObjectMeta(len(data)-1, lockedBy),
+		Data:       data,
+	}
+}
+
+func fixObjectMeta(latestResourceVersion int, lockedBy *string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:      "capact-ti-uuid",
+		Namespace: defaultNamespace,
+		Annotations: map[string]string{
+			kubernetes_storage_backend.TypeInstanceIDAnnotation:        typeInstanceID,
+			kubernetes_storage_backend.LatestResourceVersionAnnotation: fmt.Sprintf("%d", latestResourceVersion),
+		},
+	}
+	if lockedBy != nil {
+		meta.Annotations[kubernetes_storage_backend.LockedByAnnotation] = *lockedBy
+	}
+
+	return meta
+}
+
+func bytesToStrings(in map[string][]byte) map[string]string {
+	out := map[string]string{}
+	for k, v := range in {
+		out[k] = string(v)
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package kubernetesstoragebackend
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// storedObject is a kind-agnostic representation of a Secret or ConfigMap, which holds TypeInstance data.
+type storedObject struct {
+	metav1.ObjectMeta
+	Data map[string][]byte
+}
+
+// objectStore provides access to Kubernetes objects of a given kind.
+type objectStore interface {
+	Get(ctx context.Context, ns, name string) (*storedObject, error)
+	Create(ctx context.Context, obj *storedObject) error
+	Update(ctx context.Context, obj *storedObject) error
+	Delete(ctx context.Context, ns, name string) error
+}
+
+type secretStore struct {
+	client kubernetes.Interface
+}
+
+func (s *secretStore) Get(ctx context.Context, ns, name string) (*storedObject, error) {
+	secret, err := s.client.CoreV1().Secrets(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &storedObject{
+		ObjectMeta: secret.ObjectMeta,
+		Data:       secret.Data,
+	}, nil
+}
+
+func (s *secretStore) Create(ctx context.Context, obj *storedObject) error {
+	_, err := s.client.CoreV1().Secrets(obj.Namespace).Create(ctx, s.toSecret(obj), metav1.CreateOptions{})
+	return err
+}
+
+func (s *secretStore) Update(ctx context.Context, obj *storedObject) error {
+	_, err := s.client.CoreV1().Secrets(obj.Namespace).Update(ctx, s.toSecret(obj), metav1.UpdateOptions{})
+	return err
+}
+
+func (s *secretStore) Delete(ctx context.Context, ns, name string) error {
+	return s.client.CoreV1().Secrets(ns).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (s *secretStore) toSecret(obj *storedObject) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: obj.ObjectMeta,
+		Type:       corev1.SecretTypeOpaque,
+		Data:       obj.Data,
+	}
+}
+
+type configMapStore struct {
+	client kubernetes.Interface
+}
+
+func (s *configMapStore) Get(ctx context.Context, ns, name string) (*storedObject, error) {
+	cm, err := s.client.CoreV1().ConfigMaps(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string][]byte{}
+	for k, v := range cm.Data {
+		data[k] = []byte(v)
+	}
+
+	return &storedObject{
+		ObjectMeta: cm.ObjectMeta,
+		Data:       data,
+	}, nil
+}
+
+func (s *configMapStore) Create(ctx context.Context, obj *storedObject) error {
+	_, err := s.client.CoreV1().ConfigMaps(obj.Namespace).Create(ctx, s.toConfigMap(obj), metav1.CreateOptions{})
+	return err
+}
+
+func (s *configMapStore) Update(ctx context.Context, obj *storedObject) error {
+	_, err := s.client.CoreV1().ConfigMaps(obj.Namespace).Update(ctx, s.toConfigMap(obj), metav1.UpdateOptions{})
+	return err
+}
+
+func (s *configMapStore) Delete(ctx context.Context, ns, name string) error {
+	return s.client.CoreV1().ConfigMaps(ns).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// toConfigMap stores the values in the `data` field, as TypeInstance values are JSON documents.
+// Thanks to that, they are mounted as regular text files.
+func (s *configMapStore) toConfigMap(obj *storedObject) *corev1.ConfigMap {
+	data := map[string]string{}
+	for k, v := range obj.Data {
+		data[k] = string(v)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: obj.ObjectMeta,
+		Data:       data,
+	}
+}
@@ -0,0 +1,113 @@
+package localstoragebackend
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EncryptionKeySize is the required size of the encryption key. The values are encrypted with AES-256-GCM.
+const EncryptionKeySize = 32
+
+// Cipher encrypts and decrypts stored TypeInstance values.
+// The additional data is authenticated, but not encrypted. It binds a given ciphertext to the place it is stored in,
+// so the ciphertext cannot be decrypted if it is moved to another TypeInstance or revision.
+type Cipher interface {
+	Encrypt(plaintext, additionalData []byte) ([]byte, error)
+	Decrypt(ciphertext, additionalData []byte) ([]byte, error)
+}
+
+// LoadEncryptionKey returns the encryption key from a base64 encoded value or a file with base64 encoded value.
+// It returns nil if both inputs are empty.
+func LoadEncryptionKey(encodedKey, keyFilePath string) ([]byte, error) {
+	if encodedKey != "" && keyFilePath != "" {
+		return nil, errors.New("both encryption key and encryption key file must not be provided")
+	}
+
+	if keyFilePath != "" {
+		content, err := ioutil.ReadFile(keyFilePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "while reading encryption key file")
+		}
+		encodedKey = string(content)
+	}
+
+	encodedKey = strings.TrimSpace(encodedKey)
+	if encodedKey == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "while decoding base64 encryption key")
+	}
+
+	return key, nil
+}
+
+// NewCipher returns Cipher for a given key. If the key is empty, the values are stored in plain text.
+func NewCipher(key []byte) (Cipher, error) {
+	if len(key) == 0 {
+		return plaintextCipher{}, nil
+	}
+
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("invalid encryption key size: expected: %d bytes, actual: %d bytes", EncryptionKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating AES cipher")
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating GCM cipher")
+	}
+
+	return &aesGCMCipher{aead: gcm}, nil
+}
+
+// aesGCMCipher prepends the random nonce to the ciphertext.
+type aesGCMCipher struct {
+	aead cipher.AEAD
+}
+
+func (c *aesGCMCipher) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "while generating nonce")
+	}
+
+	return c.aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func (c *aesGCMCipher) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	plaintext, err := c.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], additionalData)
+	if err != nil {
+		return nil, errors.Wrap(err, "while decrypting value")
+	}
+	return plaintext, nil
+}
+
+type plaintextCipher struct{}
+
+func (plaintextCipher) Encrypt(plaintext, _ []byte) ([]byte, error) {
+	return plaintext, nil
+}
+
+func (plaintextCipher) Decrypt(ciphertext, _ []byte) ([]byte, error) {
+	return ciphertext, nil
+}
@@ -0,0 +1,132 @@
+package localstoragebackend_test
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	local_storage_backend "capact.io/capact/internal/local-storage-backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCipher_EncryptDecrypt(t *testing.T) {
+	// given
+	key := []byte("0123456789abcdef0123456789abcdef")
+	cipher, err := local_storage_backend.NewCipher(key)
+	require.NoError(t, err)
+
+	plaintext := []byte(`{"password":"secret"}`)
+	additionalData := []byte("id/1")
+
+	// when
+	encrypted, err := cipher.Encrypt(plaintext, additionalData)
+	require.NoError(t, err)
+
+	decrypted, err := cipher.Decrypt(encrypted, additionalData)
+	require.NoError(t, err)
+
+	// then
+	assert.NotContains(t, string(encrypted), "secret")
+	assert.Equal(t, plaintext, decrypted)
+}
+
+func TestCipher_DecryptWithDifferentKey(t *testing.T) {
+	// given
+	cipher, err := local_storage_backend.NewCipher([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	otherCipher, err := local_storage_backend.NewCipher([]byte("fedcba9876543210fedcba9876543210"))
+	require.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt([]byte("value"), []byte("id/1"))
+	require.NoError(t, err)
+
+	// when
+	_, err = otherCipher.Decrypt(encrypted, []byte("id/1"))
+
+	// then
+	assert.EqualError(t, err, "while decrypting value: cipher: message authentication failed")
+}
+
+func TestCipher_DecryptWithDifferentAdditionalData(t *testing.T) {
+	// given
+	cipher, err := local_storage_backend.NewCipher([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt([]byte("value"), []byte("id/1"))
+	require.NoError(t, err)
+
+	// when
+	_, err = cipher.Decrypt(encrypted, []byte("id/2"))
+
+	// then
+	assert.EqualError(t, err, "while decrypting value: cipher: message authentication failed")
+}
+
+func TestNewCipher_InvalidKeySize(t *testing.T) {
+	// when
+	_, err := local_storage_backend.NewCipher([]byte("too-short"))
+
+	// then
+	assert.EqualError(t, err, "invalid encryption key size: expected: 32 bytes, actual: 9 bytes")
+}
+
+func TestLoadEncryptionKey(t *testing.T) {
+	// given
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encodedKey := base64.StdEncoding.EncodeToString(key)
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte(encodedKey+"\n"), 0600))
+
+	testCases := []struct {
+		Name                 string
+		EncodedKey           string
+		KeyFile              string
+		ExpectedKey          []byte
+		ExpectedErrorMessage string
+	}{
+		{
+			Name:        "No key",
+			ExpectedKey: nil,
+		},
+		{
+			Name:        "Key from value",
+			EncodedKey:  encodedKey,
+			ExpectedKey: key,
+		},
+		{
+			Name:        "Key from file",
+			KeyFile:     keyFile,
+			ExpectedKey: key,
+		},
+		{
+			Name:                 "Both value and file",
+			EncodedKey:           encodedKey,
+			KeyFile:              keyFile,
+			ExpectedErrorMessage: "both encryption key and encryption key file must not be provided",
+		},
+		{
+			Name:                 "Invalid base64",
+			EncodedKey:           "not base64!",
+			ExpectedErrorMessage: "while decoding base64 encryption key: illegal base64 data at input byte 3",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// when
+			out, err := local_storage_backend.LoadEncryptionKey(testCase.EncodedKey, testCase.KeyFile)
+
+			// then
+			if testCase.ExpectedErrorMessage != "" {
+				assert.EqualError(t, err, testCase.ExpectedErrorMessage)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedKey, out)
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package localstoragebackend
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"capact.io/capact/internal/ptr"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The data is stored in the following layout:
+//
+//	typeInstances (bucket)
+//	└── {TypeInstance ID} (bucket)
+//	    ├── locked_by: {owner ID}
+//	    └── revisions (bucket)
+//	        └── {resource version as big endian uint32}: {encrypted value}
+var (
+	typeInstancesBucket = []byte("typeInstances")
+	revisionsBucket     = []byte("revisions")
+	lockedByKey         = []byte("locked_by")
+)
+
+const firstResourceVersion = 1
+
+var (
+	// NilRequestInputError describes an error with an invalid request.
+	NilRequestInputError = status.Error(codes.InvalidArgument, "request data cannot be nil")
+)
+
+var _ pb.StorageBackendServer = &Handler{}
+
+// Handler handles incoming requests to the local storage backend gRPC server.
+// It stores TypeInstance values in an embedded BoltDB file.
+type Handler struct {
+	pb.UnimplementedStorageBackendServer
+
+	log    *zap.Logger
+	db     *bolt.DB
+	cipher Cipher
+}
+
+// NewHandler returns new Handler.
+func NewHandler(log *zap.Logger, db *bolt.DB, cipher Cipher) (*Handler, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(typeInstancesBucket)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating root bucket")
+	}
+
+	return &Handler{
+		log:    log,
+		db:     db,
+		cipher: cipher,
+	}, nil
+}
+
+// GetValue returns a value for a given TypeInstance resource version.
+func (h *Handler) GetValue(_ context.Context, request *pb.GetValueRequest) (*pb.GetValueResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	h.log.Info("getting value", zap.String("typeInstanceID", request.TypeInstanceId), zap.Uint32("resourceVersion", request.ResourceVersion))
+
+	var encrypted []byte
+	err := h.db.View(func(tx *bolt.Tx) error {
+		notFoundErr := status.Error(codes.NotFound, fmt.Sprintf("TypeInstance %q in revision %d was not found", request.TypeInstanceId, request.ResourceVersion))
+
+		tiBucket := h.typeInstanceBucket(tx, request.TypeInstanceId)
+		if tiBucket == nil {
+			return notFoundErr
+		}
+
+		value, found := getKey(tiBucket.Bucket(revisionsBucket), revisionKey(request.ResourceVersion))
+		if !found {
+			return notFoundErr
+		}
+		encrypted = value
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := h.cipher.Decrypt(encrypted, additionalData(request.TypeInstanceId, request.ResourceVersion))
+	if err != nil {
+		return nil, h.internalError(errors.Wrapf(err, "while decrypting TypeInstance %q in revision %d", request.TypeInstanceId, request.ResourceVersion))
+	}
+
+	return &pb.GetValueResponse{
+		Value: value,
+	}, nil
+}
+
+// GetLockedBy returns a locked by data for a given TypeInstance.
+func (h *Handler) GetLockedBy(_ context.Context, request *pb.GetLockedByRequest) (*pb.GetLockedByResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	var lockedBy *string
+	err := h.db.View(func(tx *bolt.Tx) error {
+		tiBucket, err := h.existingTypeInstanceBucket(tx, request.TypeInstanceId)
+		if err != nil {
+			return err
+		}
+
+		if value := tiBucket.Get(lockedByKey); len(value) > 0 {
+			lockedBy = ptr.String(string(value))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetLockedByResponse{
+		LockedBy: lockedBy,
+	}, nil
+}
+
+// OnCreate handles TypeInstance creation by storing its first resource version.
+func (h *Handler) OnCreate(_ context.Context, request *pb.OnCreateRequest) (*pb.OnCreateResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	encrypted, err := h.encrypt(request.TypeInstanceId, firstResourceVersion, request.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Info("creating TypeInstance", zap.String("typeInstanceID", request.TypeInstanceId))
+	err = h.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(typeInstancesBucket)
+		if root.Bucket([]byte(request.TypeInstanceId)) != nil {
+			return status.Error(codes.AlreadyExists, fmt.Sprintf("TypeInstance %q already exist", request.TypeInstanceId))
+		}
+
+		tiBucket, err := root.CreateBucket([]byte(request.TypeInstanceId))
+		if err != nil {
+			return h.internalError(errors.Wrapf(err, "while creating bucket for TypeInstance %q", request.TypeInstanceId))
+		}
+
+		revisions, err := tiBucket.CreateBucket(revisionsBucket)
+		if err != nil {
+			return h.internalError(errors.Wrapf(err, "while creating revisions bucket for TypeInstance %q", request.TypeInstanceId))
+		}
+
+		return h.putRevision(revisions, request.TypeInstanceId, firstResourceVersion, encrypted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.OnCreateResponse{}, nil
+}
+
+// OnUpdate handles TypeInstance update by storing a new resource version.
+func (h *Handler) OnUpdate(_ context.Context, request *pb.OnUpdateRequest) (*pb.OnUpdateResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	encrypted, err := h.encrypt(request.TypeInstanceId, request.NewResourceVersion, request.NewValue)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Info("updating TypeInstance", zap.String("typeInstanceID", request.TypeInstanceId), zap.Uint32("resourceVersion", request.NewResourceVersion))
+	err = h.db.Update(func(tx *bolt.Tx) error {
+		tiBucket, err := h.existingTypeInstanceBucket(tx, request.TypeInstanceId)
+		if err != nil {
+			return err
+		}
+
+		if err := h.ensureTypeInstanceIsNotLockedByOthers(tiBucket, request.TypeInstanceId, request.OwnerId); err != nil {
+			return err
+		}
+
+		revisions := tiBucket.Bucket(revisionsBucket)
+		if _, found := getKey(revisions, revisionKey(request.NewResourceVersion)); found {
+			return status.Error(codes.AlreadyExists, fmt.Sprintf("TypeInstance %q in revision %d already exist", request.TypeInstanceId, request.NewResourceVersion))
+		}
+
+		return h.putRevision(revisions, request.TypeInstanceId, request.NewResourceVersion, encrypted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.OnUpdateResponse{}, nil
+}
+
+// OnLock handles TypeInstance locking by storing the lock owner.
+// It returns an error if a given TypeInstance is already locked.
+func (h *Handler) OnLock(_ context.Context, request *pb.OnLockRequest) (*pb.OnLockResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	h.log.Info("locking TypeInstance", zap.String("typeInstanceID", request.TypeInstanceId), zap.String("lockedBy", request.LockedBy))
+	err := h.db.Update(func(tx *bolt.Tx) error {
+		tiBucket, err := h.existingTypeInstanceBucket(tx, request.TypeInstanceId)
+		if err != nil {
+			return err
+		}
+
+		if err := h.ensureTypeInstanceIsNotLockedByOthers(tiBucket, request.TypeInstanceId, nil); err != nil {
+			return err
+		}
+
+		if err := tiBucket.Put(lockedByKey, []byte(request.LockedBy)); err != nil {
+			return h.internalError(errors.Wrapf(err, "while locking TypeInstance %q", request.TypeInstanceId))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.OnLockResponse{}, nil
+}
+
+// OnUnlock handles TypeInstance unlocking by removing the lock owner.
+func (h *Handler) OnUnlock(_ context.Context, request *pb.OnUnlockRequest) (*pb.OnUnlockResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	h.log.Info("unlocking TypeInstance", zap.String("typeInstanceID", request.TypeInstanceId))
+	err := h.db.Update(func(tx *bolt.Tx) error {
+		tiBucket, err := h.existingTypeInstanceBucket(tx, request.TypeInstanceId)
+		if err != nil {
+			return err
+		}
+
+		if err := tiBucket.Delete(lockedByKey); err != nil {
+			return h.internalError(errors.Wrapf(err, "while unlocking TypeInstance %q", request.TypeInstanceId))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.OnUnlockResponse{}, nil
+}
+
+// OnDelete handles TypeInstance deletion by removing all its resource versions.
+// It checks whether a given TypeInstance is locked before doing such operation.
+func (h *Handler) OnDelete(_ context.Context, request *pb.OnDeleteRequest) (*pb.OnDeleteResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	h.log.Info("deleting TypeInstance", zap.String("typeInstanceID", request.TypeInstanceId))
+	err := h.db.Update(func(tx *bolt.Tx) error {
+		tiBucket, err := h.existingTypeInstanceBucket(tx, request.TypeInstanceId)
+		if err != nil {
+			return err
+		}
+
+		if err := h.ensureTypeInstanceIsNotLockedByOthers(tiBucket, request.TypeInstanceId, request.OwnerId); err != nil {
+			return err
+		}
+
+		if err := tx.Bucket(typeInstancesBucket).DeleteBucket([]byte(request.TypeInstanceId)); err != nil {
+			return h.internalError(errors.Wrapf(err, "while deleting TypeInstance %q", request.TypeInstanceId))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.OnDeleteResponse{}, nil
+}
+
+// GetCapabilities returns the features supported by the local storage backend.
+// The backend doesn't accept any context, so the context schema is not set.
+func (h *Handler) GetCapabilities(_ context.Context, _ *pb.GetCapabilitiesRequest) (*pb.GetCapabilitiesResponse, error) {
+	return &pb.GetCapabilitiesResponse{
+		ValueStorage: true,
+		Locking:      true,
+		Versioning:   true,
+	}, nil
+}
+
+func (h *Handler) typeInstanceBucket(tx *bolt.Tx, typeInstanceID string) *bolt.Bucket {
+	return tx.Bucket(typeInstancesBucket).Bucket([]byte(typeInstanceID))
+}
+
+func (h *Handler) existingTypeInstanceBucket(tx *bolt.Tx, typeInstanceID string) (*bolt.Bucket, error) {
+	tiBucket := h.typeInstanceBucket(tx, typeInstanceID)
+	if tiBucket == nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("TypeInstance %q not found", typeInstanceID))
+	}
+
+	return tiBucket, nil
+}
+
+func (h *Handler) putRevision(revisions *bolt.Bucket, typeInstanceID string, resourceVersion uint32, value []byte) error {
+	if err := revisions.Put(revisionKey(resourceVersion), value); err != nil {
+		return h.internalError(errors.Wrapf(err, "while putting TypeInstance %q in revision %d", typeInstanceID, resourceVersion))
+	}
+
+	return nil
+}
+
+func (h *Handler) encrypt(typeInstanceID string, resourceVersion uint32, value []byte) ([]byte, error) {
+	encrypted, err := h.cipher.Encrypt(value, additionalData(typeInstanceID, resourceVersion))
+	if err != nil {
+		return nil, h.internalError(errors.Wrapf(err, "while encrypting TypeInstance %q in revision %d", typeInstanceID, resourceVersion))
+	}
+
+	return encrypted, nil
+}
+
+func (h *Handler) ensureTypeInstanceIsNotLockedByOthers(tiBucket *bolt.Bucket, typeInstanceID string, ownerID *string) error {
+	lockedBy := string(tiBucket.Get(lockedByKey))
+	if lockedBy == "" {
+		return nil
+	}
+	if ownerID != nil && lockedBy == *ownerID {
+		return nil
+	}
+
+	return h.failedPreconditionError(fmt.Errorf("typeInstance %q locked by %q", typeInstanceID, lockedBy))
+}
+
+func (h *Handler) internalError(err error) error {
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (h *Handler) failedPreconditionError(err error) error {
+	return status.Error(codes.FailedPrecondition, err.Error())
+}
+
+// additionalData returns the `<TypeInstance ID>/<resource version>` string authenticated together with the encrypted value,
+// so a value copied to another TypeInstance or revision fails to decrypt.
+func additionalData(typeInstanceID string, resourceVersion uint32) []byte {
+	return []byte(fmt.Sprintf("%s/%d", typeInstanceID, resourceVersion))
+}
+
+// revisionKey returns the big endian representation of the resource version, so the revisions are sorted by the version.
+func revisionKey(resourceVersion uint32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, resourceVersion)
+	return key
+}
+
+// getKey returns a copy of the value for a given key. It distinguishes a missing key from an empty value.
+func getKey(bucket *bolt.Bucket, key []byte) ([]byte, bool) {
+	k, v := bucket.Cursor().Seek(key)
+	if !bytes.Equal(k, key) {
+		return nil, false
+	}
+
+	// the value is valid only during the transaction
+	return append([]byte{}, v...), true
+}
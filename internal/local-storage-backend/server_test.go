@@ -0,0 +1,308 @@
+package localstoragebackend_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	local_storage_backend "capact.io/capact/internal/local-storage-backend"
+	"capact.io/capact/internal/logger"
+	"capact.io/capact/internal/ptr"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+const typeInstanceID = "uuid"
+
+func TestHandler_CreateGetUpdate(t *testing.T) {
+	// given
+	handler := setupHandler(t)
+	ctx := context.Background()
+
+	// when
+	_, err := handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: typeInstanceID, Value: []byte(`{"key":1}`)})
+	require.NoError(t, err)
+
+	_, err = handler.OnUpdate(ctx, &pb.OnUpdateRequest{TypeInstanceId: typeInstanceID, NewResourceVersion: 2, NewValue: []byte(`{"key":2}`)})
+	require.NoError(t, err)
+
+	// then
+	first, err := handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: typeInstanceID, ResourceVersion: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"key":1}`), first.Value)
+
+	second, err := handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: typeInstanceID, ResourceVersion: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"key":2}`), second.Value)
+}
+
+func TestHandler_ValueIsEncryptedAtRest(t *testing.T) {
+	// given
+	dbPath := filepath.Join(t.TempDir(), "storage.db")
+	db := openDB(t, dbPath)
+
+	cipher, err := local_storage_backend.NewCipher([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	handler, err := local_storage_backend.NewHandler(logger.Noop(), db, cipher)
+	require.NoError(t, err)
+
+	// when
+	_, err = handler.OnCreate(context.Background(), &pb.OnCreateRequest{TypeInstanceId: typeInstanceID, Value: []byte(`{"password":"secret"}`)})
+	require.NoError(t, err)
+
+	// then
+	err = db.View(func(tx *bolt.Tx) error {
+		stored := tx.Bucket([]byte("typeInstances")).Bucket([]byte(typeInstanceID)).Bucket([]byte("revisions")).Get([]byte{0, 0, 0, 1})
+		assert.NotEmpty(t, stored)
+		assert.NotContains(t, string(stored), "secret")
+		return nil
+	})
+	require.NoError(t, err)
+
+	out, err := handler.GetValue(context.Background(), &pb.GetValueRequest{TypeInstanceId: typeInstanceID, ResourceVersion: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"password":"secret"}`), out.Value)
+}
+
+func TestHandler_ValueMovedToOtherRevisionFailsToDecrypt(t *testing.T) {
+	// given
+	db := openDB(t, filepath.Join(t.TempDir(), "storage.db"))
+
+	cipher, err := local_storage_backend.NewCipher([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	handler, err := local_storage_backend.NewHandler(logger.Noop(), db, cipher)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: typeInstanceID, Value: []byte(`{"key":1}`)})
+	require.NoError(t, err)
+	_, err = handler.OnUpdate(ctx, &pb.OnUpdateRequest{TypeInstanceId: typeInstanceID, NewResourceVersion: 2, NewValue: []byte(`{"key":2}`)})
+	require.NoError(t, err)
+
+	// when
+	err = db.Update(func(tx *bolt.Tx) error {
+		revisions := tx.Bucket([]byte("typeInstances")).Bucket([]byte(typeInstanceID)).Bucket([]byte("revisions"))
+		first := append([]byte{}, revisions.Get([]byte{0, 0, 0, 1})...)
+		return revisions.Put([]byte{0, 0, 0, 2}, first)
+	})
+	require.NoError(t, err)
+
+	// then
+	_, err = handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: typeInstanceID, ResourceVersion: 2})
+	assert.EqualError(t, err, `rpc error: code = Internal desc = while decrypting TypeInstance "uuid" in revision 2: while decrypting value: cipher: message authentication failed`)
+}
+
+func TestHandler_GetValue(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		ResourceVersion      uint32
+		ExpectedValue        []byte
+		ExpectedErrorMessage *string
+	}{
+		{
+			Name:            "Success",
+			ResourceVersion: 1,
+			ExpectedValue:   []byte{},
+		},
+		{
+			Name:                 "No revision",
+			ResourceVersion:      2,
+			ExpectedErrorMessage: ptr.String(`rpc error: code = NotFound desc = TypeInstance "uuid" in revision 2 was not found`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			handler := setupHandler(t)
+			ctx := context.Background()
+
+			// empty value is also a valid one
+			_, err := handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: typeInstanceID, Value: []byte{}})
+			require.NoError(t, err)
+
+			// when
+			res, err := handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: typeInstanceID, ResourceVersion: testCase.ResourceVersion})
+
+			// then
+			if testCase.ExpectedErrorMessage != nil {
+				assert.Nil(t, res)
+				assert.EqualError(t, err, *testCase.ExpectedErrorMessage)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedValue, res.Value)
+		})
+	}
+}
+
+func TestHandler_Failures(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		Call                 func(ctx context.Context, handler *local_storage_backend.Handler) error
+		ExpectedErrorMessage string
+	}{
+		{
+			Name: "Create already existing TypeInstance",
+			Call: func(ctx context.Context, handler *local_storage_backend.Handler) error {
+				_, err := handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: "existing"})
+				return err
+			},
+			ExpectedErrorMessage: `rpc error: code = AlreadyExists desc = TypeInstance "existing" already exist`,
+		},
+		{
+			Name: "Update existing revision",
+			Call: func(ctx context.Context, handler *local_storage_backend.Handler) error {
+				_, err := handler.OnUpdate(ctx, &pb.OnUpdateRequest{TypeInstanceId: "existing", NewResourceVersion: 1})
+				return err
+			},
+			ExpectedErrorMessage: `rpc error: code = AlreadyExists desc = TypeInstance "existing" in revision 1 already exist`,
+		},
+		{
+			Name: "Update not existing TypeInstance",
+			Call: func(ctx context.Context, handler *local_storage_backend.Handler) error {
+				_, err := handler.OnUpdate(ctx, &pb.OnUpdateRequest{TypeInstanceId: "other", NewResourceVersion: 2})
+				return err
+			},
+			ExpectedErrorMessage: `rpc error: code = NotFound desc = TypeInstance "other" not found`,
+		},
+		{
+			Name: "Delete not existing TypeInstance",
+			Call: func(ctx context.Context, handler *local_storage_backend.Handler) error {
+				_, err := handler.OnDelete(ctx, &pb.OnDeleteRequest{TypeInstanceId: "other"})
+				return err
+			},
+			ExpectedErrorMessage: `rpc error: code = NotFound desc = TypeInstance "other" not found`,
+		},
+		{
+			Name: "Get locked by for not existing TypeInstance",
+			Call: func(ctx context.Context, handler *local_storage_backend.Handler) error {
+				_, err := handler.GetLockedBy(ctx, &pb.GetLockedByRequest{TypeInstanceId: "other"})
+				return err
+			},
+			ExpectedErrorMessage: `rpc error: code = NotFound desc = TypeInstance "other" not found`,
+		},
+		{
+			Name: "Nil request",
+			Call: func(ctx context.Context, handler *local_storage_backend.Handler) error {
+				_, err := handler.OnLock(ctx, nil)
+				return err
+			},
+			ExpectedErrorMessage: `rpc error: code = InvalidArgument desc = request data cannot be nil`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			handler := setupHandler(t)
+			ctx := context.Background()
+
+			_, err := handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: "existing", Value: []byte("foo")})
+			require.NoError(t, err)
+
+			// when
+			err = testCase.Call(ctx, handler)
+
+			// then
+			assert.EqualError(t, err, testCase.ExpectedErrorMessage)
+		})
+	}
+}
+
+func TestHandler_Lock(t *testing.T) {
+	// given
+	handler := setupHandler(t)
+	ctx := context.Background()
+
+	_, err := handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: typeInstanceID, Value: []byte("foo")})
+	require.NoError(t, err)
+
+	lockedBy, err := handler.GetLockedBy(ctx, &pb.GetLockedByRequest{TypeInstanceId: typeInstanceID})
+	require.NoError(t, err)
+	assert.Nil(t, lockedBy.LockedBy)
+
+	// when
+	_, err = handler.OnLock(ctx, &pb.OnLockRequest{TypeInstanceId: typeInstanceID, LockedBy: "owner"})
+	require.NoError(t, err)
+
+	// then
+	lockedBy, err = handler.GetLockedBy(ctx, &pb.GetLockedByRequest{TypeInstanceId: typeInstanceID})
+	require.NoError(t, err)
+	assert.Equal(t, ptr.String("owner"), lockedBy.LockedBy)
+
+	expLockedErr := `rpc error: code = FailedPrecondition desc = typeInstance "uuid" locked by "owner"`
+
+	_, err = handler.OnLock(ctx, &pb.OnLockRequest{TypeInstanceId: typeInstanceID, LockedBy: "other-owner"})
+	assert.EqualError(t, err, expLockedErr)
+
+	_, err = handler.OnUpdate(ctx, &pb.OnUpdateRequest{TypeInstanceId: typeInstanceID, NewResourceVersion: 2, OwnerId: ptr.String("other-owner")})
+	assert.EqualError(t, err, expLockedErr)
+
+	_, err = handler.OnDelete(ctx, &pb.OnDeleteRequest{TypeInstanceId: typeInstanceID})
+	assert.EqualError(t, err, expLockedErr)
+
+	_, err = handler.OnUpdate(ctx, &pb.OnUpdateRequest{TypeInstanceId: typeInstanceID, NewResourceVersion: 2, NewValue: []byte("bar"), OwnerId: ptr.String("owner")})
+	assert.NoError(t, err)
+
+	// when
+	_, err = handler.OnUnlock(ctx, &pb.OnUnlockRequest{TypeInstanceId: typeInstanceID})
+	require.NoError(t, err)
+
+	// then
+	lockedBy, err = handler.GetLockedBy(ctx, &pb.GetLockedByRequest{TypeInstanceId: typeInstanceID})
+	require.NoError(t, err)
+	assert.Nil(t, lockedBy.LockedBy)
+
+	_, err = handler.OnDelete(ctx, &pb.OnDeleteRequest{TypeInstanceId: typeInstanceID})
+	require.NoError(t, err)
+
+	_, err = handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: typeInstanceID, ResourceVersion: 1})
+	assert.EqualError(t, err, `rpc error: code = NotFound desc = TypeInstance "uuid" in revision 1 was not found`)
+}
+
+func TestHandler_GetCapabilities(t *testing.T) {
+	// given
+	handler := setupHandler(t)
+
+	// when
+	res, err := handler.GetCapabilities(context.Background(), &pb.GetCapabilitiesRequest{})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, &pb.GetCapabilitiesResponse{
+		ValueStorage: true,
+		Locking:      true,
+		Versioning:   true,
+	}, res)
+}
+
+func setupHandler(t *testing.T) *local_storage_backend.Handler {
+	t.Helper()
+
+	db := openDB(t, filepath.Join(t.TempDir(), "storage.db"))
+
+	cipher, err := local_storage_backend.NewCipher(nil)
+	require.NoError(t, err)
+
+	handler, err := local_storage_backend.NewHandler(logger.Noop(), db, cipher)
+	require.NoError(t, err)
+
+	return handler
+}
+
+func openDB(t *testing.T, path string) *bolt.DB {
+	t.Helper()
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, db.Close())
+	})
+
+	return db
+}
@@ -19,6 +19,11 @@ func Bool(in bool) *bool {
 	return &in
 }
 
+// Int returns pointer to a given input int value.
+func Int(in int) *int {
+	return &in
+}
+
 // Int32 returns pointer to a given input int32 value.
 func Int32(in int32) *int32 {
 	return &in
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"time"
 
 	"capact.io/capact/internal/ptr"
 	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
@@ -60,6 +62,8 @@ type Handler struct {
 const (
 	lockedByField        = "locked_by"
 	firstResourceVersion = 1
+
+	watchValuePollInterval = 5 * time.Second
 )
 
 var (
@@ -268,6 +272,103 @@ func (h *Handler) OnDelete(_ context.Context, request *pb.OnDeleteRequest) (*pb.
 	return &pb.OnDeleteResponse{}, nil
 }
 
+// GetValues returns values for given TypeInstances in the same order as requested.
+func (h *Handler) GetValues(ctx context.Context, request *pb.GetValuesRequest) (*pb.GetValuesResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	return pb.GetValuesSequentially(ctx, request, h.GetValue)
+}
+
+// OnLockBatch handles locking of multiple TypeInstances. It checks whether all TypeInstances can be locked
+// before setting any secret entry. If setting one of the entries fails, the already locked TypeInstances are unlocked.
+func (h *Handler) OnLockBatch(ctx context.Context, request *pb.OnLockBatchRequest) (*pb.OnLockBatchResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	for _, item := range request.Requests {
+		provider, err := h.getProviderFromContext(item.Context)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := h.ensureSecretIsNotLocked(provider, item.TypeInstanceId); err != nil {
+			return nil, err
+		}
+	}
+
+	return pb.OnLockBatchSequentially(ctx, request, h.OnLock, h.OnUnlock)
+}
+
+// OnUnlockBatch handles unlocking of multiple TypeInstances.
+func (h *Handler) OnUnlockBatch(ctx context.Context, request *pb.OnUnlockBatchRequest) (*pb.OnUnlockBatchResponse, error) {
+	if request == nil {
+		return nil, NilRequestInputError
+	}
+
+	return pb.OnUnlockBatchSequentially(ctx, request, h.OnUnlock)
+}
+
+// WatchValue streams a value for a given TypeInstance. The value is sent immediately and then each time it changes.
+// As the providers don't support watching, the secret is polled periodically.
+func (h *Handler) WatchValue(request *pb.WatchValueRequest, stream pb.StorageBackend_WatchValueServer) error {
+	if request == nil {
+		return NilRequestInputError
+	}
+
+	return pb.WatchValueByPolling(stream.Context(), request, watchValuePollInterval, h.GetValue, stream.Send)
+}
+
+// GetCapabilities returns the features supported by the Secret storage backend.
+// The context schema allows only the configured providers.
+func (h *Handler) GetCapabilities(_ context.Context, _ *pb.GetCapabilitiesRequest) (*pb.GetCapabilitiesResponse, error) {
+	contextSchema, err := h.contextSchema()
+	if err != nil {
+		return nil, h.internalError(errors.Wrap(err, "while generating context schema"))
+	}
+
+	return &pb.GetCapabilitiesResponse{
+		ValueStorage:  true,
+		Locking:       true,
+		Versioning:    true,
+		ContextSchema: ptr.String(contextSchema),
+	}, nil
+}
+
+func (h *Handler) contextSchema() (string, error) {
+	providerNames := make([]string, 0, h.providers.Count())
+	for name := range h.providers {
+		providerNames = append(providerNames, name)
+	}
+	sort.Strings(providerNames)
+
+	schema := map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"provider": map[string]interface{}{
+				"$id":  "#/properties/context/properties/provider",
+				"type": "string",
+				"enum": providerNames,
+			},
+		},
+		"additionalProperties": false,
+	}
+	// the provider can be omitted only if there is a default one
+	if h.providers.Count() != 1 {
+		schema["required"] = []string{"provider"}
+	}
+
+	out, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
 func (h *Handler) getProviderFromContext(contextBytes []byte) (tellercore.Provider, error) {
 	if len(contextBytes) == 0 {
 		provider, err := h.providers.GetDefault()
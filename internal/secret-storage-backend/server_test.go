@@ -656,6 +656,284 @@ func TestHandler_OnDelete(t *testing.T) {
 	}
 }
 
+func TestHandler_GetValues(t *testing.T) {
+	// given
+	providerName := "fake"
+	reqContext := []byte(fmt.Sprintf(`{"provider":"%s"}`, providerName))
+	provider := newFakeProvider(map[string]map[string]string{
+		"/capact/uuid1": {
+			"1": `{"key":1}`,
+		},
+		"/capact/uuid2": {
+			"3": `{"key":3}`,
+		},
+	})
+
+	srv, listener := setupServerAndListener(t, map[string]tellercore.Provider{
+		providerName: provider,
+	})
+	defer srv.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", dialOpts(listener)...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := storage_backend.NewStorageBackendClient(conn)
+
+	// when
+	res, err := client.GetValues(ctx, &storage_backend.GetValuesRequest{
+		Requests: []*storage_backend.GetValueRequest{
+			{TypeInstanceId: "uuid2", ResourceVersion: 3, Context: reqContext},
+			{TypeInstanceId: "uuid1", ResourceVersion: 1, Context: reqContext},
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, res.Responses, 2)
+	assert.Equal(t, []byte(`{"key":3}`), res.Responses[0].Value)
+	assert.Equal(t, []byte(`{"key":1}`), res.Responses[1].Value)
+
+	// when
+	res, err = client.GetValues(ctx, &storage_backend.GetValuesRequest{
+		Requests: []*storage_backend.GetValueRequest{
+			{TypeInstanceId: "uuid1", ResourceVersion: 1, Context: reqContext},
+			{TypeInstanceId: "uuid1", ResourceVersion: 2, Context: reqContext},
+		},
+	})
+
+	// then
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "rpc error: code = NotFound desc = TypeInstance \"uuid1\": TypeInstance \"uuid1\" in revision 2 was not found")
+}
+
+func TestHandler_OnLockBatch(t *testing.T) {
+	// given
+	providerName := "fake"
+	reqContext := []byte(fmt.Sprintf(`{"provider":"%s"}`, providerName))
+	req := &storage_backend.OnLockBatchRequest{
+		Requests: []*storage_backend.OnLockRequest{
+			{TypeInstanceId: "uuid1", LockedBy: "foo/sample", Context: reqContext},
+			{TypeInstanceId: "uuid2", LockedBy: "foo/sample", Context: reqContext},
+		},
+	}
+
+	testCases := []struct {
+		Name                  string
+		InputProvider         *fakeProvider
+		ExpectedProviderState map[string]map[string]string
+		ExpectedErrorMessage  *string
+	}{
+		{
+			Name: "Not locked",
+			InputProvider: newFakeProvider(map[string]map[string]string{
+				"/capact/uuid1": {
+					"1": "original",
+				},
+			}),
+			ExpectedProviderState: map[string]map[string]string{
+				"/capact/uuid1": {
+					"1":         "original",
+					"locked_by": "foo/sample",
+				},
+				"/capact/uuid2": {
+					"locked_by": "foo/sample",
+				},
+			},
+		},
+		{
+			Name: "One already locked",
+			InputProvider: newFakeProvider(map[string]map[string]string{
+				"/capact/uuid1": {
+					"1": "original",
+				},
+				"/capact/uuid2": {
+					"1":         "original",
+					"locked_by": "previous",
+				},
+			}),
+			ExpectedProviderState: map[string]map[string]string{
+				"/capact/uuid1": {
+					"1": "original",
+				},
+				"/capact/uuid2": {
+					"1":         "original",
+					"locked_by": "previous",
+				},
+			},
+			ExpectedErrorMessage: ptr.String("rpc error: code = FailedPrecondition desc = typeInstance locked: path \"/capact/uuid2\" contains \"locked_by\" property with value \"previous\""),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			srv, listener := setupServerAndListener(t, map[string]tellercore.Provider{
+				providerName: testCase.InputProvider,
+			})
+			defer srv.Stop()
+
+			ctx := context.Background()
+			conn, err := grpc.DialContext(ctx, "", dialOpts(listener)...)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			client := storage_backend.NewStorageBackendClient(conn)
+
+			// when
+			res, err := client.OnLockBatch(ctx, req)
+
+			// then
+			assert.Equal(t, testCase.ExpectedProviderState, testCase.InputProvider.secrets)
+
+			if testCase.ExpectedErrorMessage != nil {
+				assert.Nil(t, res)
+				require.Error(t, err)
+				assert.EqualError(t, err, *testCase.ExpectedErrorMessage)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+
+			// when
+			_, err = client.OnUnlockBatch(ctx, &storage_backend.OnUnlockBatchRequest{
+				Requests: []*storage_backend.OnUnlockRequest{
+					{TypeInstanceId: "uuid1", Context: reqContext},
+					{TypeInstanceId: "uuid2", Context: reqContext},
+				},
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.NotContains(t, testCase.InputProvider.secrets["/capact/uuid1"], "locked_by")
+			assert.NotContains(t, testCase.InputProvider.secrets["/capact/uuid2"], "locked_by")
+		})
+	}
+}
+
+func TestHandler_WatchValue(t *testing.T) {
+	// given
+	providerName := "fake"
+	reqContext := []byte(fmt.Sprintf(`{"provider":"%s"}`, providerName))
+	provider := newFakeProvider(map[string]map[string]string{
+		"/capact/uuid": {
+			"1": `{"key":true}`,
+		},
+	})
+
+	srv, listener := setupServerAndListener(t, map[string]tellercore.Provider{
+		providerName: provider,
+	})
+	defer srv.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "", dialOpts(listener)...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := storage_backend.NewStorageBackendClient(conn)
+
+	// when
+	stream, err := client.WatchValue(ctx, &storage_backend.WatchValueRequest{
+		TypeInstanceId:  "uuid",
+		ResourceVersion: 1,
+		Context:         reqContext,
+	})
+	require.NoError(t, err)
+	res, err := stream.Recv()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"key":true}`), res.Value)
+
+	// when
+	stream, err = client.WatchValue(ctx, &storage_backend.WatchValueRequest{
+		TypeInstanceId:  "uuid",
+		ResourceVersion: 2,
+		Context:         reqContext,
+	})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+
+	// then
+	assert.EqualError(t, err, "rpc error: code = NotFound desc = TypeInstance \"uuid\" in revision 2 was not found")
+}
+
+func TestHandler_GetCapabilities(t *testing.T) {
+	testCases := []struct {
+		Name                  string
+		InputProviders        map[string]tellercore.Provider
+		ExpectedContextSchema string
+	}{
+		{
+			Name: "Single provider",
+			InputProviders: map[string]tellercore.Provider{
+				"fake": newFakeProvider(nil),
+			},
+			ExpectedContextSchema: `{
+				"$schema": "http://json-schema.org/draft-07/schema",
+				"type": "object",
+				"properties": {
+					"provider": {
+						"$id": "#/properties/context/properties/provider",
+						"type": "string",
+						"enum": ["fake"]
+					}
+				},
+				"additionalProperties": false
+			}`,
+		},
+		{
+			Name: "Multiple providers",
+			InputProviders: map[string]tellercore.Provider{
+				"fake":   newFakeProvider(nil),
+				"dotenv": newFakeProvider(nil),
+			},
+			ExpectedContextSchema: `{
+				"$schema": "http://json-schema.org/draft-07/schema",
+				"type": "object",
+				"required": ["provider"],
+				"properties": {
+					"provider": {
+						"$id": "#/properties/context/properties/provider",
+						"type": "string",
+						"enum": ["dotenv", "fake"]
+					}
+				},
+				"additionalProperties": false
+			}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			srv, listener := setupServerAndListener(t, testCase.InputProviders)
+			defer srv.Stop()
+
+			ctx := context.Background()
+			conn, err := grpc.DialContext(ctx, "", dialOpts(listener)...)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			client := storage_backend.NewStorageBackendClient(conn)
+
+			// when
+			res, err := client.GetCapabilities(ctx, &storage_backend.GetCapabilitiesRequest{})
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, res.ValueStorage)
+			assert.True(t, res.Locking)
+			assert.True(t, res.Versioning)
+			assert.False(t, res.ReadOnly)
+			require.NotNil(t, res.ContextSchema)
+			assert.JSONEq(t, testCase.ExpectedContextSchema, *res.ContextSchema)
+		})
+	}
+}
+
 func TestHandler_GetProviderFromContext(t *testing.T) {
 	// given
 	testCases := []struct {
@@ -18,15 +18,15 @@ type Action struct {
 	ActionRef *ManifestReference `json:"actionRef"`
 	// Indicates if user approved this Action to run
 	Run bool `json:"run"`
-	// Indicates if user canceled the workflow. CURRENTLY NOT SUPPORTED.
+	// Indicates if user canceled the workflow
 	Cancel bool `json:"cancel"`
 	// Specifies whether the Action performs server-side test without actually running the Action.
 	// For now it only lints the rendered Argo manifests and does not execute any workflow.
 	DryRun         bool        `json:"dryRun"`
 	RenderedAction interface{} `json:"renderedAction"`
-	// CURRENTLY NOT IMPLEMENTED.
+	// Properties related to Action advanced rendering mode.
 	RenderingAdvancedMode *ActionRenderingAdvancedMode `json:"renderingAdvancedMode"`
-	// CURRENTLY NOT IMPLEMENTED.
+	// Rendered Action provided by user, which overrides the one rendered by Engine.
 	RenderedActionOverride interface{}   `json:"renderedActionOverride"`
 	Status                 *ActionStatus `json:"status"`
 }
@@ -40,9 +40,9 @@ type ActionDetailsInput struct {
 	// Specifies whether the Action performs server-side test without actually running the Action
 	// For now it only lints the rendered Argo manifests and does not execute any workflow.
 	DryRun *bool `json:"dryRun"`
-	// Enables advanced rendering mode for Action. CURRENTLY NOT IMPLEMENTED.
+	// Enables advanced rendering mode for Action. In this mode, rendering stops on each iteration with optional input TypeInstances.
 	AdvancedRendering *bool `json:"advancedRendering"`
-	// Used to override the rendered action. CURRENTLY NOT IMPLEMENTED.
+	// Used to override the rendered action. It must use the same runner Interface and TypeInstance steps as the rendered one.
 	RenderedActionOverride *JSON `json:"renderedActionOverride"`
 }
 
@@ -77,7 +77,7 @@ type ActionOutput struct {
 	TypeInstances []*OutputTypeInstanceDetails `json:"typeInstances"`
 }
 
-// Properties related to Action advanced rendering. CURRENTLY NOT IMPLEMENTED.
+// Properties related to Action advanced rendering.
 type ActionRenderingAdvancedMode struct {
 	Enabled bool `json:"enabled"`
 	// Optional TypeInstances for current rendering iteration
@@ -89,12 +89,14 @@ type ActionStatus struct {
 	Phase     ActionStatusPhase `json:"phase"`
 	Timestamp Timestamp         `json:"timestamp"`
 	Message   *string           `json:"message"`
-	Runner    *RunnerStatus     `json:"runner"`
-	// CURRENTLY NOT IMPLEMENTED.
+	// Machine-readable reason why the Action failed
+	Reason *ActionFailureReason `json:"reason"`
+	Runner *RunnerStatus        `json:"runner"`
+	// User who created the Action
 	CreatedBy *UserInfo `json:"createdBy"`
-	// CURRENTLY NOT IMPLEMENTED.
+	// User who approved the Action to run
 	RunBy *UserInfo `json:"runBy"`
-	// CURRENTLY NOT IMPLEMENTED.
+	// User who canceled the Action
 	CanceledBy *UserInfo `json:"canceledBy"`
 }
 
@@ -136,6 +138,11 @@ type DefaultInjectForInterfaceInput struct {
 }
 
 // Client input for Input TypeInstance
+type ImplementationAttributeWeightInput struct {
+	Attribute *ManifestReferenceInput `json:"attribute"`
+	Weight    int                     `json:"weight"`
+}
+
 type InputTypeInstanceData struct {
 	Name string `json:"name"`
 	ID   string `json:"id"`
@@ -180,11 +187,13 @@ type OutputTypeInstanceDetails struct {
 type Policy struct {
 	Interface    *InterfacePolicy    `json:"interface"`
 	TypeInstance *TypeInstancePolicy `json:"typeInstance"`
+	Retry        *RetryPolicy        `json:"retry"`
 }
 
 type PolicyInput struct {
 	Interface    *InterfacePolicyInput    `json:"interface"`
 	TypeInstance *TypeInstancePolicyInput `json:"typeInstance"`
+	Retry        *RetryPolicyInput        `json:"retry"`
 }
 
 type PolicyRuleImplementationConstraintsInput struct {
@@ -196,6 +205,17 @@ type PolicyRuleImplementationConstraintsInput struct {
 	Path *string `json:"path"`
 }
 
+type PolicyRuleImplementationSelectionInput struct {
+	// Strategy used to select a single Implementation from all Implementations matching the rule.
+	Strategy ImplementationSelectionStrategy `json:"strategy"`
+	// Attributes ordered from the most to the least preferred one. Used by the ATTRIBUTE_PREFERENCE strategy.
+	Attributes []*ManifestReferenceInput `json:"attributes"`
+	// SemVer constraint the application version must satisfy. Used by the HIGHEST_APP_VERSION strategy.
+	AppVersionConstraint *string `json:"appVersionConstraint"`
+	// Attribute weights. Used by the WEIGHTED_SCORE strategy.
+	Weights []*ImplementationAttributeWeightInput `json:"weights"`
+}
+
 type PolicyRuleInjectDataInput struct {
 	RequiredTypeInstances   []*RequiredTypeInstanceReferenceInput   `json:"requiredTypeInstances"`
 	AdditionalParameters    []*AdditionalParameterInput             `json:"additionalParameters"`
@@ -204,6 +224,7 @@ type PolicyRuleInjectDataInput struct {
 
 type PolicyRuleInput struct {
 	ImplementationConstraints *PolicyRuleImplementationConstraintsInput `json:"implementationConstraints"`
+	ImplementationSelection   *PolicyRuleImplementationSelectionInput   `json:"implementationSelection"`
 	Inject                    *PolicyRuleInjectDataInput                `json:"inject"`
 }
 
@@ -212,6 +233,24 @@ type RequiredTypeInstanceReferenceInput struct {
 	Description *string `json:"description"`
 }
 
+type RetryBackoffInput struct {
+	// Delay before the first retry, e.g. 30s or 2m.
+	Duration string `json:"duration"`
+	// Multiplies the delay after each retry.
+	Factor *int `json:"factor"`
+	// Maximum time spent on retries, counting from the first attempt.
+	MaxDuration *string `json:"maxDuration"`
+}
+
+type RetryPolicyInput struct {
+	// Maximum number of retries. It doesn't include the first attempt.
+	Limit int `json:"limit"`
+	// Describes which failures are retried. Defaults to ON_FAILURE.
+	RetryOn *RetryOn `json:"retryOn"`
+	// Delay configuration between retries.
+	Backoff *RetryBackoffInput `json:"backoff"`
+}
+
 type RulesForInterface struct {
 	Interface *ManifestReferenceWithOptionalRevision `json:"interface"`
 	OneOf     []*PolicyRule                          `json:"oneOf"`
@@ -232,10 +271,24 @@ type RulesForTypeInstanceInput struct {
 	Backend *TypeInstanceBackendRuleInput `json:"backend"`
 }
 
+// Progress of a long-running Runner operation
+type RunnerProgress struct {
+	// Runner specific name of the current operation step
+	Phase *string `json:"phase"`
+	// Details about the current operation step
+	Message *string `json:"message"`
+	// Number of already completed units of work, e.g. created resources
+	Completed int `json:"completed"`
+	// Number of all units of work. Not set if the total number is unknown
+	Total *int `json:"total"`
+}
+
 // Additional Action status from the Runner
 type RunnerStatus struct {
 	// Status of a given Runner e.g. Argo Workflow Runner status object with argoWorkflowRef field
 	Status interface{} `json:"status"`
+	// Last progress reported by a long-running Runner
+	Progress *RunnerProgress `json:"progress"`
 }
 
 type TypeInstanceBackendDetails struct {
@@ -268,6 +321,60 @@ type UserInfo struct {
 	Extra    interface{} `json:"extra"`
 }
 
+// Reason why the Action failed
+type ActionFailureReason string
+
+const (
+	ActionFailureReasonInterfaceNotFound      ActionFailureReason = "INTERFACE_NOT_FOUND"
+	ActionFailureReasonImplementationNotFound ActionFailureReason = "IMPLEMENTATION_NOT_FOUND"
+	ActionFailureReasonInvalidInput           ActionFailureReason = "INVALID_INPUT"
+	ActionFailureReasonInvalidPolicy          ActionFailureReason = "INVALID_POLICY"
+	ActionFailureReasonInvalidManifest        ActionFailureReason = "INVALID_MANIFEST"
+	ActionFailureReasonUnsupportedRunner      ActionFailureReason = "UNSUPPORTED_RUNNER"
+	ActionFailureReasonRetriesExceeded        ActionFailureReason = "RETRIES_EXCEEDED"
+	ActionFailureReasonRunnerFailed           ActionFailureReason = "RUNNER_FAILED"
+)
+
+var AllActionFailureReason = []ActionFailureReason{
+	ActionFailureReasonInterfaceNotFound,
+	ActionFailureReasonImplementationNotFound,
+	ActionFailureReasonInvalidInput,
+	ActionFailureReasonInvalidPolicy,
+	ActionFailureReasonInvalidManifest,
+	ActionFailureReasonUnsupportedRunner,
+	ActionFailureReasonRetriesExceeded,
+	ActionFailureReasonRunnerFailed,
+}
+
+func (e ActionFailureReason) IsValid() bool {
+	switch e {
+	case ActionFailureReasonInterfaceNotFound, ActionFailureReasonImplementationNotFound, ActionFailureReasonInvalidInput, ActionFailureReasonInvalidPolicy, ActionFailureReasonInvalidManifest, ActionFailureReasonUnsupportedRunner, ActionFailureReasonRetriesExceeded, ActionFailureReasonRunnerFailed:
+		return true
+	}
+	return false
+}
+
+func (e ActionFailureReason) String() string {
+	return string(e)
+}
+
+func (e *ActionFailureReason) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ActionFailureReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ActionFailureReason", str)
+	}
+	return nil
+}
+
+func (e ActionFailureReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // Current phase of the Action
 type ActionStatusPhase string
 
@@ -323,3 +430,95 @@ func (e *ActionStatusPhase) UnmarshalGQL(v interface{}) error {
 func (e ActionStatusPhase) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type ImplementationSelectionStrategy string
+
+const (
+	ImplementationSelectionStrategyFirst               ImplementationSelectionStrategy = "FIRST"
+	ImplementationSelectionStrategyAttributePreference ImplementationSelectionStrategy = "ATTRIBUTE_PREFERENCE"
+	ImplementationSelectionStrategyNewestRevision      ImplementationSelectionStrategy = "NEWEST_REVISION"
+	ImplementationSelectionStrategyHighestAppVersion   ImplementationSelectionStrategy = "HIGHEST_APP_VERSION"
+	ImplementationSelectionStrategyWeightedScore       ImplementationSelectionStrategy = "WEIGHTED_SCORE"
+)
+
+var AllImplementationSelectionStrategy = []ImplementationSelectionStrategy{
+	ImplementationSelectionStrategyFirst,
+	ImplementationSelectionStrategyAttributePreference,
+	ImplementationSelectionStrategyNewestRevision,
+	ImplementationSelectionStrategyHighestAppVersion,
+	ImplementationSelectionStrategyWeightedScore,
+}
+
+func (e ImplementationSelectionStrategy) IsValid() bool {
+	switch e {
+	case ImplementationSelectionStrategyFirst, ImplementationSelectionStrategyAttributePreference, ImplementationSelectionStrategyNewestRevision, ImplementationSelectionStrategyHighestAppVersion, ImplementationSelectionStrategyWeightedScore:
+		return true
+	}
+	return false
+}
+
+func (e ImplementationSelectionStrategy) String() string {
+	return string(e)
+}
+
+func (e *ImplementationSelectionStrategy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImplementationSelectionStrategy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImplementationSelectionStrategy", str)
+	}
+	return nil
+}
+
+func (e ImplementationSelectionStrategy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RetryOn string
+
+const (
+	RetryOnAlways           RetryOn = "ALWAYS"
+	RetryOnOnFailure        RetryOn = "ON_FAILURE"
+	RetryOnOnError          RetryOn = "ON_ERROR"
+	RetryOnOnTransientError RetryOn = "ON_TRANSIENT_ERROR"
+)
+
+var AllRetryOn = []RetryOn{
+	RetryOnAlways,
+	RetryOnOnFailure,
+	RetryOnOnError,
+	RetryOnOnTransientError,
+}
+
+func (e RetryOn) IsValid() bool {
+	switch e {
+	case RetryOnAlways, RetryOnOnFailure, RetryOnOnError, RetryOnOnTransientError:
+		return true
+	}
+	return false
+}
+
+func (e RetryOn) String() string {
+	return string(e)
+}
+
+func (e *RetryOn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RetryOn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RetryOn", str)
+	}
+	return nil
+}
+
+func (e RetryOn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
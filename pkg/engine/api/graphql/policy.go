@@ -11,6 +11,7 @@ type InterfacePolicy struct {
 // PolicyRule represents a single policy rule.
 type PolicyRule struct {
 	ImplementationConstraints *PolicyRuleImplementationConstraints `json:"implementationConstraints,omitempty"`
+	ImplementationSelection   *PolicyRuleImplementationSelection   `json:"implementationSelection,omitempty"`
 	Inject                    *PolicyRuleInjectData                `json:"inject,omitempty"`
 }
 
@@ -25,6 +26,25 @@ type PolicyRuleImplementationConstraints struct {
 	Path *string `json:"path,omitempty"`
 }
 
+// PolicyRuleImplementationSelection describes how a single Implementation is selected
+// from all Implementations matching the rule.
+type PolicyRuleImplementationSelection struct {
+	// Strategy used to select a single Implementation from all Implementations matching the rule.
+	Strategy ImplementationSelectionStrategy `json:"strategy"`
+	// Attributes ordered from the most to the least preferred one. Used by the ATTRIBUTE_PREFERENCE strategy.
+	Attributes []*ManifestReferenceWithOptionalRevision `json:"attributes,omitempty"`
+	// SemVer constraint the application version must satisfy. Used by the HIGHEST_APP_VERSION strategy.
+	AppVersionConstraint *string `json:"appVersionConstraint,omitempty"`
+	// Attribute weights. Used by the WEIGHTED_SCORE strategy.
+	Weights []*ImplementationAttributeWeight `json:"weights,omitempty"`
+}
+
+// ImplementationAttributeWeight represents the weight of a given Attribute.
+type ImplementationAttributeWeight struct {
+	Attribute *ManifestReferenceWithOptionalRevision `json:"attribute"`
+	Weight    int                                    `json:"weight"`
+}
+
 // ManifestReferenceWithOptionalRevision is used to represent a manifest reference with an optional revision property.
 type ManifestReferenceWithOptionalRevision struct {
 	Path     string  `json:"path"`
@@ -49,3 +69,23 @@ type AdditionalTypeInstanceReference struct {
 	Name string `json:"name"`
 	ID   string `json:"id"`
 }
+
+// RetryPolicy describes how failed runner Jobs and workflow steps are retried.
+type RetryPolicy struct {
+	// Maximum number of retries. It doesn't include the first attempt.
+	Limit int `json:"limit"`
+	// Describes which failures are retried.
+	RetryOn *RetryOn `json:"retryOn,omitempty"`
+	// Delay configuration between retries.
+	Backoff *RetryBackoff `json:"backoff,omitempty"`
+}
+
+// RetryBackoff describes the delay between retries.
+type RetryBackoff struct {
+	// Delay before the first retry, e.g. 30s or 2m.
+	Duration string `json:"duration"`
+	// Multiplies the delay after each retry.
+	Factor *int `json:"factor,omitempty"`
+	// Maximum time spent on retries, counting from the first attempt.
+	MaxDuration *string `json:"maxDuration,omitempty"`
+}
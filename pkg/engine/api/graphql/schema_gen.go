@@ -77,6 +77,7 @@ type ComplexityRoot struct {
 		CreatedBy  func(childComplexity int) int
 		Message    func(childComplexity int) int
 		Phase      func(childComplexity int) int
+		Reason     func(childComplexity int) int
 		RunBy      func(childComplexity int) int
 		Runner     func(childComplexity int) int
 		Timestamp  func(childComplexity int) int
@@ -100,6 +101,11 @@ type ComplexityRoot struct {
 		RequiredTypeInstances func(childComplexity int) int
 	}
 
+	ImplementationAttributeWeight struct {
+		Attribute func(childComplexity int) int
+		Weight    func(childComplexity int) int
+	}
+
 	InputTypeInstanceDetails struct {
 		ID   func(childComplexity int) int
 		Name func(childComplexity int) int
@@ -143,11 +149,13 @@ type ComplexityRoot struct {
 
 	Policy struct {
 		Interface    func(childComplexity int) int
+		Retry        func(childComplexity int) int
 		TypeInstance func(childComplexity int) int
 	}
 
 	PolicyRule struct {
 		ImplementationConstraints func(childComplexity int) int
+		ImplementationSelection   func(childComplexity int) int
 		Inject                    func(childComplexity int) int
 	}
 
@@ -157,6 +165,13 @@ type ComplexityRoot struct {
 		Requires   func(childComplexity int) int
 	}
 
+	PolicyRuleImplementationSelection struct {
+		AppVersionConstraint func(childComplexity int) int
+		Attributes           func(childComplexity int) int
+		Strategy             func(childComplexity int) int
+		Weights              func(childComplexity int) int
+	}
+
 	PolicyRuleInjectData struct {
 		AdditionalParameters    func(childComplexity int) int
 		AdditionalTypeInstances func(childComplexity int) int
@@ -174,6 +189,18 @@ type ComplexityRoot struct {
 		ID          func(childComplexity int) int
 	}
 
+	RetryBackoff struct {
+		Duration    func(childComplexity int) int
+		Factor      func(childComplexity int) int
+		MaxDuration func(childComplexity int) int
+	}
+
+	RetryPolicy struct {
+		Backoff func(childComplexity int) int
+		Limit   func(childComplexity int) int
+		RetryOn func(childComplexity int) int
+	}
+
 	RulesForInterface struct {
 		Interface func(childComplexity int) int
 		OneOf     func(childComplexity int) int
@@ -184,8 +211,16 @@ type ComplexityRoot struct {
 		TypeRef func(childComplexity int) int
 	}
 
+	RunnerProgress struct {
+		Completed func(childComplexity int) int
+		Message   func(childComplexity int) int
+		Phase     func(childComplexity int) int
+		Total     func(childComplexity int) int
+	}
+
 	RunnerStatus struct {
-		Status func(childComplexity int) int
+		Progress func(childComplexity int) int
+		Status   func(childComplexity int) int
 	}
 
 	TypeInstanceBackendDetails struct {
@@ -393,6 +428,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ActionStatus.Phase(childComplexity), true
 
+	case "ActionStatus.reason":
+		if e.complexity.ActionStatus.Reason == nil {
+			break
+		}
+
+		return e.complexity.ActionStatus.Reason(childComplexity), true
+
 	case "ActionStatus.runBy":
 		if e.complexity.ActionStatus.RunBy == nil {
 			break
@@ -456,6 +498,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DefaultInjectForInterface.RequiredTypeInstances(childComplexity), true
 
+	case "ImplementationAttributeWeight.attribute":
+		if e.complexity.ImplementationAttributeWeight.Attribute == nil {
+			break
+		}
+
+		return e.complexity.ImplementationAttributeWeight.Attribute(childComplexity), true
+
+	case "ImplementationAttributeWeight.weight":
+		if e.complexity.ImplementationAttributeWeight.Weight == nil {
+			break
+		}
+
+		return e.complexity.ImplementationAttributeWeight.Weight(childComplexity), true
+
 	case "InputTypeInstanceDetails.id":
 		if e.complexity.InputTypeInstanceDetails.ID == nil {
 			break
@@ -638,6 +694,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Policy.Interface(childComplexity), true
 
+	case "Policy.retry":
+		if e.complexity.Policy.Retry == nil {
+			break
+		}
+
+		return e.complexity.Policy.Retry(childComplexity), true
+
 	case "Policy.typeInstance":
 		if e.complexity.Policy.TypeInstance == nil {
 			break
@@ -652,6 +715,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PolicyRule.ImplementationConstraints(childComplexity), true
 
+	case "PolicyRule.implementationSelection":
+		if e.complexity.PolicyRule.ImplementationSelection == nil {
+			break
+		}
+
+		return e.complexity.PolicyRule.ImplementationSelection(childComplexity), true
+
 	case "PolicyRule.inject":
 		if e.complexity.PolicyRule.Inject == nil {
 			break
@@ -680,6 +750,34 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PolicyRuleImplementationConstraints.Requires(childComplexity), true
 
+	case "PolicyRuleImplementationSelection.appVersionConstraint":
+		if e.complexity.PolicyRuleImplementationSelection.AppVersionConstraint == nil {
+			break
+		}
+
+		return e.complexity.PolicyRuleImplementationSelection.AppVersionConstraint(childComplexity), true
+
+	case "PolicyRuleImplementationSelection.attributes":
+		if e.complexity.PolicyRuleImplementationSelection.Attributes == nil {
+			break
+		}
+
+		return e.complexity.PolicyRuleImplementationSelection.Attributes(childComplexity), true
+
+	case "PolicyRuleImplementationSelection.strategy":
+		if e.complexity.PolicyRuleImplementationSelection.Strategy == nil {
+			break
+		}
+
+		return e.complexity.PolicyRuleImplementationSelection.Strategy(childComplexity), true
+
+	case "PolicyRuleImplementationSelection.weights":
+		if e.complexity.PolicyRuleImplementationSelection.Weights == nil {
+			break
+		}
+
+		return e.complexity.PolicyRuleImplementationSelection.Weights(childComplexity), true
+
 	case "PolicyRuleInjectData.additionalParameters":
 		if e.complexity.PolicyRuleInjectData.AdditionalParameters == nil {
 			break
@@ -746,6 +844,48 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.RequiredTypeInstanceReference.ID(childComplexity), true
 
+	case "RetryBackoff.duration":
+		if e.complexity.RetryBackoff.Duration == nil {
+			break
+		}
+
+		return e.complexity.RetryBackoff.Duration(childComplexity), true
+
+	case "RetryBackoff.factor":
+		if e.complexity.RetryBackoff.Factor == nil {
+			break
+		}
+
+		return e.complexity.RetryBackoff.Factor(childComplexity), true
+
+	case "RetryBackoff.maxDuration":
+		if e.complexity.RetryBackoff.MaxDuration == nil {
+			break
+		}
+
+		return e.complexity.RetryBackoff.MaxDuration(childComplexity), true
+
+	case "RetryPolicy.backoff":
+		if e.complexity.RetryPolicy.Backoff == nil {
+			break
+		}
+
+		return e.complexity.RetryPolicy.Backoff(childComplexity), true
+
+	case "RetryPolicy.limit":
+		if e.complexity.RetryPolicy.Limit == nil {
+			break
+		}
+
+		return e.complexity.RetryPolicy.Limit(childComplexity), true
+
+	case "RetryPolicy.retryOn":
+		if e.complexity.RetryPolicy.RetryOn == nil {
+			break
+		}
+
+		return e.complexity.RetryPolicy.RetryOn(childComplexity), true
+
 	case "RulesForInterface.interface":
 		if e.complexity.RulesForInterface.Interface == nil {
 			break
@@ -774,6 +914,41 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.RulesForTypeInstance.TypeRef(childComplexity), true
 
+	case "RunnerProgress.completed":
+		if e.complexity.RunnerProgress.Completed == nil {
+			break
+		}
+
+		return e.complexity.RunnerProgress.Completed(childComplexity), true
+
+	case "RunnerProgress.message":
+		if e.complexity.RunnerProgress.Message == nil {
+			break
+		}
+
+		return e.complexity.RunnerProgress.Message(childComplexity), true
+
+	case "RunnerProgress.phase":
+		if e.complexity.RunnerProgress.Phase == nil {
+			break
+		}
+
+		return e.complexity.RunnerProgress.Phase(childComplexity), true
+
+	case "RunnerProgress.total":
+		if e.complexity.RunnerProgress.Total == nil {
+			break
+		}
+
+		return e.complexity.RunnerProgress.Total(childComplexity), true
+
+	case "RunnerStatus.progress":
+		if e.complexity.RunnerStatus.Progress == nil {
+			break
+		}
+
+		return e.complexity.RunnerStatus.Progress(childComplexity), true
+
 	case "RunnerStatus.status":
 		if e.complexity.RunnerStatus.Status == nil {
 			break
@@ -975,12 +1150,12 @@ input ActionDetailsInput {
   dryRun: Boolean = false
 
   """
-  Enables advanced rendering mode for Action. CURRENTLY NOT IMPLEMENTED.
+  Enables advanced rendering mode for Action. In this mode, rendering stops on each iteration with optional input TypeInstances.
   """
   advancedRendering: Boolean = false
 
   """
-  Used to override the rendered action. CURRENTLY NOT IMPLEMENTED.
+  Used to override the rendered action. It must use the same runner Interface and TypeInstance steps as the rendered one.
   """
   renderedActionOverride: JSON
 }
@@ -1046,7 +1221,7 @@ type Action {
   run: Boolean!
 
   """
-  Indicates if user canceled the workflow. CURRENTLY NOT SUPPORTED.
+  Indicates if user canceled the workflow
   """
   cancel: Boolean!
 
@@ -1059,11 +1234,11 @@ type Action {
   renderedAction: Any
 
   """
-  CURRENTLY NOT IMPLEMENTED.
+  Properties related to Action advanced rendering mode.
   """
   renderingAdvancedMode: ActionRenderingAdvancedMode
   """
-  CURRENTLY NOT IMPLEMENTED.
+  Rendered Action provided by user, which overrides the one rendered by Engine.
   """
   renderedActionOverride: Any
 
@@ -1071,7 +1246,7 @@ type Action {
 }
 
 """
-Properties related to Action advanced rendering. CURRENTLY NOT IMPLEMENTED.
+Properties related to Action advanced rendering.
 """
 type ActionRenderingAdvancedMode {
   enabled: Boolean!
@@ -1089,18 +1264,22 @@ type ActionStatus {
   phase: ActionStatusPhase!
   timestamp: Timestamp!
   message: String
+  """
+  Machine-readable reason why the Action failed
+  """
+  reason: ActionFailureReason
   runner: RunnerStatus
 
   """
-  CURRENTLY NOT IMPLEMENTED.
+  User who created the Action
   """
   createdBy: UserInfo
   """
-  CURRENTLY NOT IMPLEMENTED.
+  User who approved the Action to run
   """
   runBy: UserInfo
   """
-  CURRENTLY NOT IMPLEMENTED.
+  User who canceled the Action
   """
   canceledBy: UserInfo
 }
@@ -1113,6 +1292,32 @@ type RunnerStatus {
   Status of a given Runner e.g. Argo Workflow Runner status object with argoWorkflowRef field
   """
   status: Any
+  """
+  Last progress reported by a long-running Runner
+  """
+  progress: RunnerProgress
+}
+
+"""
+Progress of a long-running Runner operation
+"""
+type RunnerProgress {
+  """
+  Runner specific name of the current operation step
+  """
+  phase: String
+  """
+  Details about the current operation step
+  """
+  message: String
+  """
+  Number of already completed units of work, e.g. created resources
+  """
+  completed: Int!
+  """
+  Number of all units of work. Not set if the total number is unknown
+  """
+  total: Int
 }
 
 """
@@ -1196,6 +1401,20 @@ input AdvancedModeContinueRenderingInput {
   typeInstances: [InputTypeInstanceData!]
 }
 
+"""
+Reason why the Action failed
+"""
+enum ActionFailureReason {
+  INTERFACE_NOT_FOUND
+  IMPLEMENTATION_NOT_FOUND
+  INVALID_INPUT
+  INVALID_POLICY
+  INVALID_MANIFEST
+  UNSUPPORTED_RUNNER
+  RETRIES_EXCEEDED
+  RUNNER_FAILED
+}
+
 """
 Current phase of the Action
 """
@@ -1214,6 +1433,7 @@ enum ActionStatusPhase {
 input PolicyInput {
   interface: InterfacePolicyInput
   typeInstance: TypeInstancePolicyInput
+  retry: RetryPolicyInput
 }
 
 # TypeInstance Policy Input
@@ -1252,6 +1472,7 @@ input RulesForInterfaceInput {
 
 input PolicyRuleInput {
   implementationConstraints: PolicyRuleImplementationConstraintsInput
+  implementationSelection: PolicyRuleImplementationSelectionInput
   inject: PolicyRuleInjectDataInput
 }
 
@@ -1283,9 +1504,91 @@ input PolicyRuleImplementationConstraintsInput {
   path: NodePath
 }
 
+input PolicyRuleImplementationSelectionInput {
+  """
+  Strategy used to select a single Implementation from all Implementations matching the rule.
+  """
+  strategy: ImplementationSelectionStrategy!
+
+  """
+  Attributes ordered from the most to the least preferred one. Used by the ATTRIBUTE_PREFERENCE strategy.
+  """
+  attributes: [ManifestReferenceInput!]
+
+  """
+  SemVer constraint the application version must satisfy. Used by the HIGHEST_APP_VERSION strategy.
+  """
+  appVersionConstraint: String
+
+  """
+  Attribute weights. Used by the WEIGHTED_SCORE strategy.
+  """
+  weights: [ImplementationAttributeWeightInput!]
+}
+
+input ImplementationAttributeWeightInput {
+  attribute: ManifestReferenceInput!
+  weight: Int!
+}
+
+input RetryPolicyInput {
+  """
+  Maximum number of retries. It doesn't include the first attempt.
+  """
+  limit: Int!
+
+  """
+  Describes which failures are retried. Defaults to ON_FAILURE.
+  """
+  retryOn: RetryOn
+
+  """
+  Delay configuration between retries.
+  """
+  backoff: RetryBackoffInput
+}
+
+input RetryBackoffInput {
+  """
+  Delay before the first retry, e.g. 30s or 2m.
+  """
+  duration: String!
+
+  """
+  Multiplies the delay after each retry.
+  """
+  factor: Int
+
+  """
+  Maximum time spent on retries, counting from the first attempt.
+  """
+  maxDuration: String
+}
+
 type Policy {
   interface: InterfacePolicy
   typeInstance: TypeInstancePolicy
+  retry: RetryPolicy
+}
+
+# Retry Policy
+enum RetryOn {
+  ALWAYS # Retries all failed steps
+  ON_FAILURE # Retries steps whose main container is marked as failed
+  ON_ERROR # Retries steps which encountered system level errors, such as Pod eviction
+  ON_TRANSIENT_ERROR # Retries steps which encountered transient errors, such as network timeouts or throttled cloud API calls
+}
+
+type RetryPolicy {
+  limit: Int!
+  retryOn: RetryOn
+  backoff: RetryBackoff
+}
+
+type RetryBackoff {
+  duration: String!
+  factor: Int
+  maxDuration: String
 }
 
 # TypeInstance Policy
@@ -1324,6 +1627,7 @@ type RulesForInterface {
 
 type PolicyRule {
   implementationConstraints: PolicyRuleImplementationConstraints
+  implementationSelection: PolicyRuleImplementationSelection
   inject: PolicyRuleInjectData
 }
 
@@ -1360,6 +1664,41 @@ type PolicyRuleImplementationConstraints {
   path: NodePath
 }
 
+enum ImplementationSelectionStrategy {
+  FIRST # Selects the first Implementation matching the rule
+  ATTRIBUTE_PREFERENCE # Selects the Implementation which has the most preferred Attribute
+  NEWEST_REVISION # Selects the Implementation with the highest revision
+  HIGHEST_APP_VERSION # Selects the Implementation with the highest application version satisfying the SemVer constraint
+  WEIGHTED_SCORE # Selects the Implementation with the highest sum of Attribute weights
+}
+
+type PolicyRuleImplementationSelection {
+  """
+  Strategy used to select a single Implementation from all Implementations matching the rule.
+  """
+  strategy: ImplementationSelectionStrategy!
+
+  """
+  Attributes ordered from the most to the least preferred one. Used by the ATTRIBUTE_PREFERENCE strategy.
+  """
+  attributes: [ManifestReferenceWithOptionalRevision!]
+
+  """
+  SemVer constraint the application version must satisfy. Used by the HIGHEST_APP_VERSION strategy.
+  """
+  appVersionConstraint: String
+
+  """
+  Attribute weights. Used by the WEIGHTED_SCORE strategy.
+  """
+  weights: [ImplementationAttributeWeight!]
+}
+
+type ImplementationAttributeWeight {
+  attribute: ManifestReferenceWithOptionalRevision!
+  weight: Int!
+}
+
 type Query {
   action(name: String!): Action
   actions(filter: ActionFilter): [Action!]!
@@ -1372,13 +1711,13 @@ type Mutation {
   runAction(name: String!): Action!
 
   """
-  CURRENTLY NOT IMPLEMENTED.
+  Terminates the runner of a given Action and unlocks its TypeInstances. Only Actions approved to run can be canceled.
   """
   cancelAction(name: String!): Action!
   updateAction(in: ActionDetailsInput!): Action!
 
   """
-  CURRENTLY NOT IMPLEMENTED.
+  Approves the current rendering iteration of a given Action and continues rendering with the provided optional TypeInstances.
   """
   continueAdvancedRendering(
     actionName: String!
@@ -1392,7 +1731,7 @@ type Mutation {
   updatePolicy(in: PolicyInput!): Policy!
 }
 
-# TODO: Directive for User authorization in https://github.com/capactio/capact/issues/508
+# User authorization is enforced by Gateway based on its RBAC policy.
 `, BuiltIn: false},
 }
 var parsedSchema = gqlparser.MustLoadSchema(sources...)
@@ -2306,6 +2645,38 @@ func (ec *executionContext) _ActionStatus_message(ctx context.Context, field gra
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _ActionStatus_reason(ctx context.Context, field graphql.CollectedField, obj *ActionStatus) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ActionStatus",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Reason, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*ActionFailureReason)
+	fc.Result = res
+	return ec.marshalOActionFailureReason2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐActionFailureReason(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _ActionStatus_runner(ctx context.Context, field graphql.CollectedField, obj *ActionStatus) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -2638,7 +3009,7 @@ func (ec *executionContext) _DefaultInjectForInterface_requiredTypeInstances(ctx
 	return ec.marshalORequiredTypeInstanceReference2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRequiredTypeInstanceReferenceᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _InputTypeInstanceDetails_id(ctx context.Context, field graphql.CollectedField, obj *InputTypeInstanceDetails) (ret graphql.Marshaler) {
+func (ec *executionContext) _ImplementationAttributeWeight_attribute(ctx context.Context, field graphql.CollectedField, obj *ImplementationAttributeWeight) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2646,7 +3017,7 @@ func (ec *executionContext) _InputTypeInstanceDetails_id(ctx context.Context, fi
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "InputTypeInstanceDetails",
+		Object:     "ImplementationAttributeWeight",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2656,7 +3027,7 @@ func (ec *executionContext) _InputTypeInstanceDetails_id(ctx context.Context, fi
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Attribute, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2668,12 +3039,12 @@ func (ec *executionContext) _InputTypeInstanceDetails_id(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*ManifestReferenceWithOptionalRevision)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNManifestReferenceWithOptionalRevision2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReferenceWithOptionalRevision(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _InputTypeInstanceDetails_name(ctx context.Context, field graphql.CollectedField, obj *InputTypeInstanceDetails) (ret graphql.Marshaler) {
+func (ec *executionContext) _ImplementationAttributeWeight_weight(ctx context.Context, field graphql.CollectedField, obj *ImplementationAttributeWeight) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2681,7 +3052,7 @@ func (ec *executionContext) _InputTypeInstanceDetails_name(ctx context.Context,
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "InputTypeInstanceDetails",
+		Object:     "ImplementationAttributeWeight",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2691,7 +3062,7 @@ func (ec *executionContext) _InputTypeInstanceDetails_name(ctx context.Context,
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.Weight, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2703,12 +3074,12 @@ func (ec *executionContext) _InputTypeInstanceDetails_name(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _InputTypeInstanceToProvide_name(ctx context.Context, field graphql.CollectedField, obj *InputTypeInstanceToProvide) (ret graphql.Marshaler) {
+func (ec *executionContext) _InputTypeInstanceDetails_id(ctx context.Context, field graphql.CollectedField, obj *InputTypeInstanceDetails) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2716,7 +3087,7 @@ func (ec *executionContext) _InputTypeInstanceToProvide_name(ctx context.Context
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "InputTypeInstanceToProvide",
+		Object:     "InputTypeInstanceDetails",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2726,7 +3097,7 @@ func (ec *executionContext) _InputTypeInstanceToProvide_name(ctx context.Context
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2740,10 +3111,10 @@ func (ec *executionContext) _InputTypeInstanceToProvide_name(ctx context.Context
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _InputTypeInstanceToProvide_typeRef(ctx context.Context, field graphql.CollectedField, obj *InputTypeInstanceToProvide) (ret graphql.Marshaler) {
+func (ec *executionContext) _InputTypeInstanceDetails_name(ctx context.Context, field graphql.CollectedField, obj *InputTypeInstanceDetails) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2751,7 +3122,7 @@ func (ec *executionContext) _InputTypeInstanceToProvide_typeRef(ctx context.Cont
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "InputTypeInstanceToProvide",
+		Object:     "InputTypeInstanceDetails",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2761,7 +3132,7 @@ func (ec *executionContext) _InputTypeInstanceToProvide_typeRef(ctx context.Cont
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TypeRef, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2773,12 +3144,12 @@ func (ec *executionContext) _InputTypeInstanceToProvide_typeRef(ctx context.Cont
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*ManifestReference)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNManifestReference2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReference(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _InterfacePolicy_default(ctx context.Context, field graphql.CollectedField, obj *InterfacePolicy) (ret graphql.Marshaler) {
+func (ec *executionContext) _InputTypeInstanceToProvide_name(ctx context.Context, field graphql.CollectedField, obj *InputTypeInstanceToProvide) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2786,7 +3157,7 @@ func (ec *executionContext) _InterfacePolicy_default(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "InterfacePolicy",
+		Object:     "InputTypeInstanceToProvide",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2796,21 +3167,24 @@ func (ec *executionContext) _InterfacePolicy_default(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Default, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*DefaultForInterface)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalODefaultForInterface2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐDefaultForInterface(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _InterfacePolicy_rules(ctx context.Context, field graphql.CollectedField, obj *InterfacePolicy) (ret graphql.Marshaler) {
+func (ec *executionContext) _InputTypeInstanceToProvide_typeRef(ctx context.Context, field graphql.CollectedField, obj *InputTypeInstanceToProvide) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2818,7 +3192,7 @@ func (ec *executionContext) _InterfacePolicy_rules(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "InterfacePolicy",
+		Object:     "InputTypeInstanceToProvide",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2828,7 +3202,7 @@ func (ec *executionContext) _InterfacePolicy_rules(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Rules, nil
+		return obj.TypeRef, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2840,12 +3214,12 @@ func (ec *executionContext) _InterfacePolicy_rules(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*RulesForInterface)
+	res := resTmp.(*ManifestReference)
 	fc.Result = res
-	return ec.marshalNRulesForInterface2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRulesForInterfaceᚄ(ctx, field.Selections, res)
+	return ec.marshalNManifestReference2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReference(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManifestReference_path(ctx context.Context, field graphql.CollectedField, obj *ManifestReference) (ret graphql.Marshaler) {
+func (ec *executionContext) _InterfacePolicy_default(ctx context.Context, field graphql.CollectedField, obj *InterfacePolicy) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2853,7 +3227,74 @@ func (ec *executionContext) _ManifestReference_path(ctx context.Context, field g
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ManifestReference",
+		Object:     "InterfacePolicy",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Default, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*DefaultForInterface)
+	fc.Result = res
+	return ec.marshalODefaultForInterface2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐDefaultForInterface(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _InterfacePolicy_rules(ctx context.Context, field graphql.CollectedField, obj *InterfacePolicy) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "InterfacePolicy",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Rules, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*RulesForInterface)
+	fc.Result = res
+	return ec.marshalNRulesForInterface2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRulesForInterfaceᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ManifestReference_path(ctx context.Context, field graphql.CollectedField, obj *ManifestReference) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ManifestReference",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3445,6 +3886,38 @@ func (ec *executionContext) _Policy_typeInstance(ctx context.Context, field grap
 	return ec.marshalOTypeInstancePolicy2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐTypeInstancePolicy(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _Policy_retry(ctx context.Context, field graphql.CollectedField, obj *Policy) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Policy",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Retry, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*RetryPolicy)
+	fc.Result = res
+	return ec.marshalORetryPolicy2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryPolicy(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _PolicyRule_implementationConstraints(ctx context.Context, field graphql.CollectedField, obj *PolicyRule) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -3477,6 +3950,38 @@ func (ec *executionContext) _PolicyRule_implementationConstraints(ctx context.Co
 	return ec.marshalOPolicyRuleImplementationConstraints2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐPolicyRuleImplementationConstraints(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _PolicyRule_implementationSelection(ctx context.Context, field graphql.CollectedField, obj *PolicyRule) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PolicyRule",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ImplementationSelection, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*PolicyRuleImplementationSelection)
+	fc.Result = res
+	return ec.marshalOPolicyRuleImplementationSelection2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐPolicyRuleImplementationSelection(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _PolicyRule_inject(ctx context.Context, field graphql.CollectedField, obj *PolicyRule) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -3605,6 +4110,137 @@ func (ec *executionContext) _PolicyRuleImplementationConstraints_path(ctx contex
 	return ec.marshalONodePath2ᚖstring(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _PolicyRuleImplementationSelection_strategy(ctx context.Context, field graphql.CollectedField, obj *PolicyRuleImplementationSelection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PolicyRuleImplementationSelection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Strategy, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(ImplementationSelectionStrategy)
+	fc.Result = res
+	return ec.marshalNImplementationSelectionStrategy2capactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationSelectionStrategy(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PolicyRuleImplementationSelection_attributes(ctx context.Context, field graphql.CollectedField, obj *PolicyRuleImplementationSelection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PolicyRuleImplementationSelection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Attributes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*ManifestReferenceWithOptionalRevision)
+	fc.Result = res
+	return ec.marshalOManifestReferenceWithOptionalRevision2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReferenceWithOptionalRevisionᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PolicyRuleImplementationSelection_appVersionConstraint(ctx context.Context, field graphql.CollectedField, obj *PolicyRuleImplementationSelection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PolicyRuleImplementationSelection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.AppVersionConstraint, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PolicyRuleImplementationSelection_weights(ctx context.Context, field graphql.CollectedField, obj *PolicyRuleImplementationSelection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PolicyRuleImplementationSelection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Weights, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*ImplementationAttributeWeight)
+	fc.Result = res
+	return ec.marshalOImplementationAttributeWeight2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationAttributeWeightᚄ(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _PolicyRuleInjectData_requiredTypeInstances(ctx context.Context, field graphql.CollectedField, obj *PolicyRuleInjectData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -3851,12 +4487,379 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(*introspection.Type)
+	fc.Result = res
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.introspectSchema()
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Schema)
+	fc.Result = res
+	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RequiredTypeInstanceReference_id(ctx context.Context, field graphql.CollectedField, obj *RequiredTypeInstanceReference) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RequiredTypeInstanceReference",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RequiredTypeInstanceReference_description(ctx context.Context, field graphql.CollectedField, obj *RequiredTypeInstanceReference) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RequiredTypeInstanceReference",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RetryBackoff_duration(ctx context.Context, field graphql.CollectedField, obj *RetryBackoff) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RetryBackoff",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Duration, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RetryBackoff_factor(ctx context.Context, field graphql.CollectedField, obj *RetryBackoff) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RetryBackoff",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Factor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RetryBackoff_maxDuration(ctx context.Context, field graphql.CollectedField, obj *RetryBackoff) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RetryBackoff",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MaxDuration, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RetryPolicy_limit(ctx context.Context, field graphql.CollectedField, obj *RetryPolicy) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RetryPolicy",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Limit, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RetryPolicy_retryOn(ctx context.Context, field graphql.CollectedField, obj *RetryPolicy) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RetryPolicy",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.RetryOn, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*RetryOn)
+	fc.Result = res
+	return ec.marshalORetryOn2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryOn(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RetryPolicy_backoff(ctx context.Context, field graphql.CollectedField, obj *RetryPolicy) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RetryPolicy",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Backoff, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*RetryBackoff)
+	fc.Result = res
+	return ec.marshalORetryBackoff2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryBackoff(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RulesForInterface_interface(ctx context.Context, field graphql.CollectedField, obj *RulesForInterface) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RulesForInterface",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Interface, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*ManifestReferenceWithOptionalRevision)
+	fc.Result = res
+	return ec.marshalNManifestReferenceWithOptionalRevision2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReferenceWithOptionalRevision(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RulesForInterface_oneOf(ctx context.Context, field graphql.CollectedField, obj *RulesForInterface) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RulesForInterface",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.OneOf, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*PolicyRule)
 	fc.Result = res
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNPolicyRule2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐPolicyRuleᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _RulesForTypeInstance_typeRef(ctx context.Context, field graphql.CollectedField, obj *RulesForTypeInstance) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3864,31 +4867,34 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "RulesForTypeInstance",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectSchema()
+		return obj.TypeRef, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.(*ManifestReferenceWithOptionalRevision)
 	fc.Result = res
-	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+	return ec.marshalNManifestReferenceWithOptionalRevision2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReferenceWithOptionalRevision(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RequiredTypeInstanceReference_id(ctx context.Context, field graphql.CollectedField, obj *RequiredTypeInstanceReference) (ret graphql.Marshaler) {
+func (ec *executionContext) _RulesForTypeInstance_backend(ctx context.Context, field graphql.CollectedField, obj *RulesForTypeInstance) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3896,7 +4902,7 @@ func (ec *executionContext) _RequiredTypeInstanceReference_id(ctx context.Contex
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "RequiredTypeInstanceReference",
+		Object:     "RulesForTypeInstance",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3906,7 +4912,7 @@ func (ec *executionContext) _RequiredTypeInstanceReference_id(ctx context.Contex
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Backend, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3918,12 +4924,12 @@ func (ec *executionContext) _RequiredTypeInstanceReference_id(ctx context.Contex
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*TypeInstanceBackendRule)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNTypeInstanceBackendRule2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐTypeInstanceBackendRule(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RequiredTypeInstanceReference_description(ctx context.Context, field graphql.CollectedField, obj *RequiredTypeInstanceReference) (ret graphql.Marshaler) {
+func (ec *executionContext) _RunnerProgress_phase(ctx context.Context, field graphql.CollectedField, obj *RunnerProgress) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3931,7 +4937,7 @@ func (ec *executionContext) _RequiredTypeInstanceReference_description(ctx conte
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "RequiredTypeInstanceReference",
+		Object:     "RunnerProgress",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3941,7 +4947,7 @@ func (ec *executionContext) _RequiredTypeInstanceReference_description(ctx conte
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Phase, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3955,7 +4961,7 @@ func (ec *executionContext) _RequiredTypeInstanceReference_description(ctx conte
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RulesForInterface_interface(ctx context.Context, field graphql.CollectedField, obj *RulesForInterface) (ret graphql.Marshaler) {
+func (ec *executionContext) _RunnerProgress_message(ctx context.Context, field graphql.CollectedField, obj *RunnerProgress) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3963,7 +4969,7 @@ func (ec *executionContext) _RulesForInterface_interface(ctx context.Context, fi
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "RulesForInterface",
+		Object:     "RunnerProgress",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3973,24 +4979,21 @@ func (ec *executionContext) _RulesForInterface_interface(ctx context.Context, fi
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Interface, nil
+		return obj.Message, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*ManifestReferenceWithOptionalRevision)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNManifestReferenceWithOptionalRevision2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReferenceWithOptionalRevision(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RulesForInterface_oneOf(ctx context.Context, field graphql.CollectedField, obj *RulesForInterface) (ret graphql.Marshaler) {
+func (ec *executionContext) _RunnerProgress_completed(ctx context.Context, field graphql.CollectedField, obj *RunnerProgress) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3998,7 +5001,7 @@ func (ec *executionContext) _RulesForInterface_oneOf(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "RulesForInterface",
+		Object:     "RunnerProgress",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -4008,7 +5011,7 @@ func (ec *executionContext) _RulesForInterface_oneOf(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.OneOf, nil
+		return obj.Completed, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4020,12 +5023,12 @@ func (ec *executionContext) _RulesForInterface_oneOf(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*PolicyRule)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNPolicyRule2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐPolicyRuleᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RulesForTypeInstance_typeRef(ctx context.Context, field graphql.CollectedField, obj *RulesForTypeInstance) (ret graphql.Marshaler) {
+func (ec *executionContext) _RunnerProgress_total(ctx context.Context, field graphql.CollectedField, obj *RunnerProgress) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4033,7 +5036,7 @@ func (ec *executionContext) _RulesForTypeInstance_typeRef(ctx context.Context, f
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "RulesForTypeInstance",
+		Object:     "RunnerProgress",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -4043,24 +5046,21 @@ func (ec *executionContext) _RulesForTypeInstance_typeRef(ctx context.Context, f
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TypeRef, nil
+		return obj.Total, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*ManifestReferenceWithOptionalRevision)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalNManifestReferenceWithOptionalRevision2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReferenceWithOptionalRevision(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RulesForTypeInstance_backend(ctx context.Context, field graphql.CollectedField, obj *RulesForTypeInstance) (ret graphql.Marshaler) {
+func (ec *executionContext) _RunnerStatus_status(ctx context.Context, field graphql.CollectedField, obj *RunnerStatus) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4068,7 +5068,7 @@ func (ec *executionContext) _RulesForTypeInstance_backend(ctx context.Context, f
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "RulesForTypeInstance",
+		Object:     "RunnerStatus",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -4078,24 +5078,21 @@ func (ec *executionContext) _RulesForTypeInstance_backend(ctx context.Context, f
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Backend, nil
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*TypeInstanceBackendRule)
+	res := resTmp.(interface{})
 	fc.Result = res
-	return ec.marshalNTypeInstanceBackendRule2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐTypeInstanceBackendRule(ctx, field.Selections, res)
+	return ec.marshalOAny2interface(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RunnerStatus_status(ctx context.Context, field graphql.CollectedField, obj *RunnerStatus) (ret graphql.Marshaler) {
+func (ec *executionContext) _RunnerStatus_progress(ctx context.Context, field graphql.CollectedField, obj *RunnerStatus) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -4113,7 +5110,7 @@ func (ec *executionContext) _RunnerStatus_status(ctx context.Context, field grap
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.Progress, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4122,9 +5119,9 @@ func (ec *executionContext) _RunnerStatus_status(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(interface{})
+	res := resTmp.(*RunnerProgress)
 	fc.Result = res
-	return ec.marshalOAny2interface(ctx, field.Selections, res)
+	return ec.marshalORunnerProgress2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRunnerProgress(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) _TypeInstanceBackendDetails_id(ctx context.Context, field graphql.CollectedField, obj *TypeInstanceBackendDetails) (ret graphql.Marshaler) {
@@ -5736,6 +6733,34 @@ func (ec *executionContext) unmarshalInputDefaultInjectForInterfaceInput(ctx con
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputImplementationAttributeWeightInput(ctx context.Context, obj interface{}) (ImplementationAttributeWeightInput, error) {
+	var it ImplementationAttributeWeightInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "attribute":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("attribute"))
+			it.Attribute, err = ec.unmarshalNManifestReferenceInput2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReferenceInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "weight":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("weight"))
+			it.Weight, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputInputTypeInstanceData(ctx context.Context, obj interface{}) (InputTypeInstanceData, error) {
 	var it InputTypeInstanceData
 	var asMap = obj.(map[string]interface{})
@@ -5842,6 +6867,14 @@ func (ec *executionContext) unmarshalInputPolicyInput(ctx context.Context, obj i
 			if err != nil {
 				return it, err
 			}
+		case "retry":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("retry"))
+			it.Retry, err = ec.unmarshalORetryPolicyInput2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryPolicyInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -5884,6 +6917,50 @@ func (ec *executionContext) unmarshalInputPolicyRuleImplementationConstraintsInp
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputPolicyRuleImplementationSelectionInput(ctx context.Context, obj interface{}) (PolicyRuleImplementationSelectionInput, error) {
+	var it PolicyRuleImplementationSelectionInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "strategy":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("strategy"))
+			it.Strategy, err = ec.unmarshalNImplementationSelectionStrategy2capactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationSelectionStrategy(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "attributes":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("attributes"))
+			it.Attributes, err = ec.unmarshalOManifestReferenceInput2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReferenceInputᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "appVersionConstraint":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("appVersionConstraint"))
+			it.AppVersionConstraint, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "weights":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("weights"))
+			it.Weights, err = ec.unmarshalOImplementationAttributeWeightInput2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationAttributeWeightInputᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputPolicyRuleInjectDataInput(ctx context.Context, obj interface{}) (PolicyRuleInjectDataInput, error) {
 	var it PolicyRuleInjectDataInput
 	var asMap = obj.(map[string]interface{})
@@ -5934,6 +7011,14 @@ func (ec *executionContext) unmarshalInputPolicyRuleInput(ctx context.Context, o
 			if err != nil {
 				return it, err
 			}
+		case "implementationSelection":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("implementationSelection"))
+			it.ImplementationSelection, err = ec.unmarshalOPolicyRuleImplementationSelectionInput2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐPolicyRuleImplementationSelectionInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		case "inject":
 			var err error
 
@@ -5976,6 +7061,78 @@ func (ec *executionContext) unmarshalInputRequiredTypeInstanceReferenceInput(ctx
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputRetryBackoffInput(ctx context.Context, obj interface{}) (RetryBackoffInput, error) {
+	var it RetryBackoffInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "duration":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("duration"))
+			it.Duration, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "factor":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("factor"))
+			it.Factor, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "maxDuration":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("maxDuration"))
+			it.MaxDuration, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputRetryPolicyInput(ctx context.Context, obj interface{}) (RetryPolicyInput, error) {
+	var it RetryPolicyInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "limit":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+			it.Limit, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "retryOn":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("retryOn"))
+			it.RetryOn, err = ec.unmarshalORetryOn2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryOn(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "backoff":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("backoff"))
+			it.Backoff, err = ec.unmarshalORetryBackoffInput2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryBackoffInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputRulesForInterfaceInput(ctx context.Context, obj interface{}) (RulesForInterfaceInput, error) {
 	var it RulesForInterfaceInput
 	var asMap = obj.(map[string]interface{})
@@ -6265,6 +7422,8 @@ func (ec *executionContext) _ActionStatus(ctx context.Context, sel ast.Selection
 			}
 		case "message":
 			out.Values[i] = ec._ActionStatus_message(ctx, field, obj)
+		case "reason":
+			out.Values[i] = ec._ActionStatus_reason(ctx, field, obj)
 		case "runner":
 			out.Values[i] = ec._ActionStatus_runner(ctx, field, obj)
 		case "createdBy":
@@ -6396,6 +7555,38 @@ func (ec *executionContext) _DefaultInjectForInterface(ctx context.Context, sel
 	return out
 }
 
+var implementationAttributeWeightImplementors = []string{"ImplementationAttributeWeight"}
+
+func (ec *executionContext) _ImplementationAttributeWeight(ctx context.Context, sel ast.SelectionSet, obj *ImplementationAttributeWeight) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, implementationAttributeWeightImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ImplementationAttributeWeight")
+		case "attribute":
+			out.Values[i] = ec._ImplementationAttributeWeight_attribute(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "weight":
+			out.Values[i] = ec._ImplementationAttributeWeight_weight(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var inputTypeInstanceDetailsImplementors = []string{"InputTypeInstanceDetails"}
 
 func (ec *executionContext) _InputTypeInstanceDetails(ctx context.Context, sel ast.SelectionSet, obj *InputTypeInstanceDetails) graphql.Marshaler {
@@ -6663,6 +7854,8 @@ func (ec *executionContext) _Policy(ctx context.Context, sel ast.SelectionSet, o
 			out.Values[i] = ec._Policy_interface(ctx, field, obj)
 		case "typeInstance":
 			out.Values[i] = ec._Policy_typeInstance(ctx, field, obj)
+		case "retry":
+			out.Values[i] = ec._Policy_retry(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -6687,6 +7880,8 @@ func (ec *executionContext) _PolicyRule(ctx context.Context, sel ast.SelectionSe
 			out.Values[i] = graphql.MarshalString("PolicyRule")
 		case "implementationConstraints":
 			out.Values[i] = ec._PolicyRule_implementationConstraints(ctx, field, obj)
+		case "implementationSelection":
+			out.Values[i] = ec._PolicyRule_implementationSelection(ctx, field, obj)
 		case "inject":
 			out.Values[i] = ec._PolicyRule_inject(ctx, field, obj)
 		default:
@@ -6728,6 +7923,39 @@ func (ec *executionContext) _PolicyRuleImplementationConstraints(ctx context.Con
 	return out
 }
 
+var policyRuleImplementationSelectionImplementors = []string{"PolicyRuleImplementationSelection"}
+
+func (ec *executionContext) _PolicyRuleImplementationSelection(ctx context.Context, sel ast.SelectionSet, obj *PolicyRuleImplementationSelection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, policyRuleImplementationSelectionImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PolicyRuleImplementationSelection")
+		case "strategy":
+			out.Values[i] = ec._PolicyRuleImplementationSelection_strategy(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "attributes":
+			out.Values[i] = ec._PolicyRuleImplementationSelection_attributes(ctx, field, obj)
+		case "appVersionConstraint":
+			out.Values[i] = ec._PolicyRuleImplementationSelection_appVersionConstraint(ctx, field, obj)
+		case "weights":
+			out.Values[i] = ec._PolicyRuleImplementationSelection_weights(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var policyRuleInjectDataImplementors = []string{"PolicyRuleInjectData"}
 
 func (ec *executionContext) _PolicyRuleInjectData(ctx context.Context, sel ast.SelectionSet, obj *PolicyRuleInjectData) graphql.Marshaler {
@@ -6825,24 +8053,86 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 	return out
 }
 
-var requiredTypeInstanceReferenceImplementors = []string{"RequiredTypeInstanceReference"}
+var requiredTypeInstanceReferenceImplementors = []string{"RequiredTypeInstanceReference"}
+
+func (ec *executionContext) _RequiredTypeInstanceReference(ctx context.Context, sel ast.SelectionSet, obj *RequiredTypeInstanceReference) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, requiredTypeInstanceReferenceImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RequiredTypeInstanceReference")
+		case "id":
+			out.Values[i] = ec._RequiredTypeInstanceReference_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "description":
+			out.Values[i] = ec._RequiredTypeInstanceReference_description(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var retryBackoffImplementors = []string{"RetryBackoff"}
 
-func (ec *executionContext) _RequiredTypeInstanceReference(ctx context.Context, sel ast.SelectionSet, obj *RequiredTypeInstanceReference) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, requiredTypeInstanceReferenceImplementors)
+func (ec *executionContext) _RetryBackoff(ctx context.Context, sel ast.SelectionSet, obj *RetryBackoff) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, retryBackoffImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("RequiredTypeInstanceReference")
-		case "id":
-			out.Values[i] = ec._RequiredTypeInstanceReference_id(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("RetryBackoff")
+		case "duration":
+			out.Values[i] = ec._RetryBackoff_duration(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "description":
-			out.Values[i] = ec._RequiredTypeInstanceReference_description(ctx, field, obj)
+		case "factor":
+			out.Values[i] = ec._RetryBackoff_factor(ctx, field, obj)
+		case "maxDuration":
+			out.Values[i] = ec._RetryBackoff_maxDuration(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var retryPolicyImplementors = []string{"RetryPolicy"}
+
+func (ec *executionContext) _RetryPolicy(ctx context.Context, sel ast.SelectionSet, obj *RetryPolicy) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, retryPolicyImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RetryPolicy")
+		case "limit":
+			out.Values[i] = ec._RetryPolicy_limit(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "retryOn":
+			out.Values[i] = ec._RetryPolicy_retryOn(ctx, field, obj)
+		case "backoff":
+			out.Values[i] = ec._RetryPolicy_backoff(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -6918,6 +8208,39 @@ func (ec *executionContext) _RulesForTypeInstance(ctx context.Context, sel ast.S
 	return out
 }
 
+var runnerProgressImplementors = []string{"RunnerProgress"}
+
+func (ec *executionContext) _RunnerProgress(ctx context.Context, sel ast.SelectionSet, obj *RunnerProgress) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, runnerProgressImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RunnerProgress")
+		case "phase":
+			out.Values[i] = ec._RunnerProgress_phase(ctx, field, obj)
+		case "message":
+			out.Values[i] = ec._RunnerProgress_message(ctx, field, obj)
+		case "completed":
+			out.Values[i] = ec._RunnerProgress_completed(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "total":
+			out.Values[i] = ec._RunnerProgress_total(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var runnerStatusImplementors = []string{"RunnerStatus"}
 
 func (ec *executionContext) _RunnerStatus(ctx context.Context, sel ast.SelectionSet, obj *RunnerStatus) graphql.Marshaler {
@@ -6931,6 +8254,8 @@ func (ec *executionContext) _RunnerStatus(ctx context.Context, sel ast.Selection
 			out.Values[i] = graphql.MarshalString("RunnerStatus")
 		case "status":
 			out.Values[i] = ec._RunnerStatus_status(ctx, field, obj)
+		case "progress":
+			out.Values[i] = ec._RunnerStatus_progress(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -7461,6 +8786,31 @@ func (ec *executionContext) marshalNID2string(ctx context.Context, sel ast.Selec
 	return res
 }
 
+func (ec *executionContext) marshalNImplementationAttributeWeight2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationAttributeWeight(ctx context.Context, sel ast.SelectionSet, v *ImplementationAttributeWeight) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._ImplementationAttributeWeight(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNImplementationAttributeWeightInput2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationAttributeWeightInput(ctx context.Context, v interface{}) (*ImplementationAttributeWeightInput, error) {
+	res, err := ec.unmarshalInputImplementationAttributeWeightInput(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalNImplementationSelectionStrategy2capactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationSelectionStrategy(ctx context.Context, v interface{}) (ImplementationSelectionStrategy, error) {
+	var res ImplementationSelectionStrategy
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNImplementationSelectionStrategy2capactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationSelectionStrategy(ctx context.Context, sel ast.SelectionSet, v ImplementationSelectionStrategy) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) unmarshalNInputTypeInstanceData2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐInputTypeInstanceData(ctx context.Context, v interface{}) (*InputTypeInstanceData, error) {
 	res, err := ec.unmarshalInputInputTypeInstanceData(ctx, v)
 	return &res, graphql.ErrorOnPath(ctx, err)
@@ -7513,6 +8863,21 @@ func (ec *executionContext) marshalNInputTypeInstanceDetails2ᚖcapactᚗioᚋca
 	return ec._InputTypeInstanceDetails(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNInt2int(ctx context.Context, v interface{}) (int, error) {
+	res, err := graphql.UnmarshalInt(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.SelectionSet, v int) graphql.Marshaler {
+	res := graphql.MarshalInt(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) marshalNInputTypeInstanceToProvide2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐInputTypeInstanceToProvideᚄ(ctx context.Context, sel ast.SelectionSet, v []*InputTypeInstanceToProvide) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -8239,6 +9604,22 @@ func (ec *executionContext) unmarshalOActionDetailsInput2ᚖcapactᚗioᚋcapact
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalOActionFailureReason2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐActionFailureReason(ctx context.Context, v interface{}) (*ActionFailureReason, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(ActionFailureReason)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOActionFailureReason2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐActionFailureReason(ctx context.Context, sel ast.SelectionSet, v *ActionFailureReason) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOActionFilter2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐActionFilter(ctx context.Context, v interface{}) (*ActionFilter, error) {
 	if v == nil {
 		return nil, nil
@@ -8496,6 +9877,70 @@ func (ec *executionContext) unmarshalODefaultInjectForInterfaceInput2ᚖcapact
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) marshalOImplementationAttributeWeight2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationAttributeWeightᚄ(ctx context.Context, sel ast.SelectionSet, v []*ImplementationAttributeWeight) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNImplementationAttributeWeight2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationAttributeWeight(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
+func (ec *executionContext) unmarshalOImplementationAttributeWeightInput2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationAttributeWeightInputᚄ(ctx context.Context, v interface{}) ([]*ImplementationAttributeWeightInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]*ImplementationAttributeWeightInput, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNImplementationAttributeWeightInput2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐImplementationAttributeWeightInput(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 func (ec *executionContext) unmarshalOInputTypeInstanceData2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐInputTypeInstanceDataᚄ(ctx context.Context, v interface{}) ([]*InputTypeInstanceData, error) {
 	if v == nil {
 		return nil, nil
@@ -8520,6 +9965,57 @@ func (ec *executionContext) unmarshalOInputTypeInstanceData2ᚕᚖcapactᚗioᚋ
 	return res, nil
 }
 
+func (ec *executionContext) unmarshalOInt2ᚕintᚄ(ctx context.Context, v interface{}) ([]int, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]int, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNInt2int(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOInt2ᚕintᚄ(ctx context.Context, sel ast.SelectionSet, v []int) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNInt2int(ctx, sel, v[i])
+	}
+
+	return ret
+}
+
+func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v interface{}) (*int, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalInt(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOInt2ᚖint(ctx context.Context, sel ast.SelectionSet, v *int) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalInt(*v)
+}
+
 func (ec *executionContext) marshalOInterfacePolicy2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐInterfacePolicy(ctx context.Context, sel ast.SelectionSet, v *InterfacePolicy) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -8683,6 +10179,21 @@ func (ec *executionContext) unmarshalOPolicyRuleImplementationConstraintsInput2
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) marshalOPolicyRuleImplementationSelection2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐPolicyRuleImplementationSelection(ctx context.Context, sel ast.SelectionSet, v *PolicyRuleImplementationSelection) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._PolicyRuleImplementationSelection(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalOPolicyRuleImplementationSelectionInput2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐPolicyRuleImplementationSelectionInput(ctx context.Context, v interface{}) (*PolicyRuleImplementationSelectionInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputPolicyRuleImplementationSelectionInput(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) marshalOPolicyRuleInjectData2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐPolicyRuleInjectData(ctx context.Context, sel ast.SelectionSet, v *PolicyRuleInjectData) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -8762,6 +10273,59 @@ func (ec *executionContext) unmarshalORequiredTypeInstanceReferenceInput2ᚕᚖc
 	return res, nil
 }
 
+func (ec *executionContext) marshalORetryBackoff2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryBackoff(ctx context.Context, sel ast.SelectionSet, v *RetryBackoff) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._RetryBackoff(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalORetryBackoffInput2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryBackoffInput(ctx context.Context, v interface{}) (*RetryBackoffInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputRetryBackoffInput(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalORetryOn2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryOn(ctx context.Context, v interface{}) (*RetryOn, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(RetryOn)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalORetryOn2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryOn(ctx context.Context, sel ast.SelectionSet, v *RetryOn) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
+func (ec *executionContext) marshalORetryPolicy2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryPolicy(ctx context.Context, sel ast.SelectionSet, v *RetryPolicy) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._RetryPolicy(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalORetryPolicyInput2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRetryPolicyInput(ctx context.Context, v interface{}) (*RetryPolicyInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputRetryPolicyInput(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalORunnerProgress2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRunnerProgress(ctx context.Context, sel ast.SelectionSet, v *RunnerProgress) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._RunnerProgress(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalORunnerStatus2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRunnerStatus(ctx context.Context, sel ast.SelectionSet, v *RunnerStatus) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
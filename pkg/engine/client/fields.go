@@ -51,8 +51,15 @@ var actionFields = fmt.Sprintf(`
 		phase
 		timestamp
 		message
+		reason
 		runner {
 			status
+			progress {
+				phase
+				message
+				completed
+				total
+			}
 		}
 		canceledBy {
 			username
@@ -99,6 +106,21 @@ const policyFields = `
 					}
 					path
 				}
+				implementationSelection {
+					strategy
+					attributes {
+						path
+						revision
+					}
+					appVersionConstraint
+					weights {
+						attribute {
+							path
+							revision
+						}
+						weight
+					}
+				}
 				inject {
 					requiredTypeInstances {
 						id
@@ -128,4 +150,13 @@ const policyFields = `
 			}
 		}
 	}
+	retry {
+		limit
+		retryOn
+		backoff {
+			duration
+			factor
+			maxDuration
+		}
+	}
 `
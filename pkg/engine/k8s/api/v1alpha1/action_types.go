@@ -54,11 +54,12 @@ type ActionSpec struct {
 	// +optional
 	Input *ActionInput `json:"input,omitempty"`
 
-	// AdvancedRendering holds properties related to Action advanced rendering mode. CURRENTLY NOT IMPLEMENTED.
+	// AdvancedRendering holds properties related to Action advanced rendering mode.
 	// +optional
 	AdvancedRendering *AdvancedRendering `json:"advancedRendering,omitempty"`
 
-	// RenderedActionOverride contains optional rendered Action that overrides the one rendered by Engine. CURRENTLY NOT IMPLEMENTED.
+	// RenderedActionOverride contains optional rendered Action that overrides the one rendered by Engine.
+	// It is validated against the Action rendered by Engine, so it must use the same runner Interface and TypeInstance steps.
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
 	RenderedActionOverride *runtime.RawExtension `json:"renderedActionOverride,omitempty"`
@@ -76,7 +77,8 @@ type ActionSpec struct {
 	// +kubebuilder:default=false
 	DryRun *bool `json:"dryRun,omitempty"`
 
-	// Cancel specifies whether the Action execution should be canceled. CURRENTLY NOT IMPLEMENTED.
+	// Cancel specifies whether the Action execution should be canceled.
+	// Engine terminates the runner, unlocks the TypeInstances and sets the Action phase to `Canceled`.
 	// +optional
 	// +kubebuilder:default=false
 	Cancel *bool `json:"cancel,omitempty"`
@@ -106,6 +108,15 @@ func (in *ActionSpec) IsAdvancedRenderingEnabled() bool {
 	return in.AdvancedRendering != nil && in.AdvancedRendering.Enabled
 }
 
+// ApprovedRenderingIterationName returns the name of rendering iteration approved by user.
+// It returns empty string if no iteration was approved.
+func (in *ActionSpec) ApprovedRenderingIterationName() string {
+	if in.AdvancedRendering == nil || in.AdvancedRendering.RenderingIteration == nil {
+		return ""
+	}
+	return in.AdvancedRendering.RenderingIteration.ApprovedIterationName
+}
+
 // IsExecuted returns true if Action is executed.
 func (in *Action) IsExecuted() bool {
 	return in.Status.Phase == RunningActionPhase || in.Status.Phase == BeingCanceledActionPhase
@@ -132,11 +143,31 @@ func (in *Action) IsReadyToExecute() bool {
 	return in.Status.Phase == ReadyToRunActionPhase && in.Spec.IsRun()
 }
 
+// IsAdvancedRenderingIterationApproved returns true if Action waits on a rendering iteration in advanced mode
+// and user approved that iteration.
+func (in *Action) IsAdvancedRenderingIterationApproved() bool {
+	if in.Status.Phase != AdvancedModeRenderingIterationActionPhase {
+		return false
+	}
+
+	iteration := in.Status.Rendering.GetRenderingIteration()
+	if iteration == nil {
+		return false
+	}
+
+	return iteration.CurrentIterationName == in.Spec.ApprovedRenderingIterationName()
+}
+
 // IsBeingDeleted returns true if a deletion timestamp is set
 func (in *Action) IsBeingDeleted() bool {
 	return !in.ObjectMeta.DeletionTimestamp.IsZero()
 }
 
+// IsCancelRequested returns true if user requested Action cancellation and the Action is not completed yet.
+func (in *Action) IsCancelRequested() bool {
+	return in.Spec.IsCanceled() && !in.IsCompleted()
+}
+
 // IsCompleted returns true if Action is in in the complete state.
 func (in *Action) IsCompleted() bool {
 	return in.Status.Phase == FailedActionPhase || in.Status.Phase == SucceededActionPhase || in.Status.Phase == CanceledActionPhase
@@ -239,6 +270,10 @@ type ActionStatus struct {
 	// +optional
 	Message *string `json:"message,omitempty"`
 
+	// Reason provides a machine-readable code, which describes why the Action failed.
+	// +optional
+	Reason ActionFailureReason `json:"reason,omitempty"`
+
 	// Runner holds data related to Runner that runs the Action.
 	// +optional
 	Runner *RunnerStatus `json:"runner,omitempty"`
@@ -251,15 +286,15 @@ type ActionStatus struct {
 	// +optional
 	Rendering *RenderingStatus `json:"rendering,omitempty"`
 
-	// CreatedBy holds user data which created a given Action. CURRENTLY NOT IMPLEMENTED.
+	// CreatedBy holds user data which created a given Action.
 	// +optional
 	CreatedBy *authv1.UserInfo `json:"createdBy,omitempty"`
 
-	// RunBy holds user data which run a given Action. CURRENTLY NOT IMPLEMENTED.
+	// RunBy holds user data which run a given Action.
 	// +optional
 	RunBy *authv1.UserInfo `json:"runBy,omitempty"`
 
-	// CanceledBy holds user data which canceled a given Action. CURRENTLY NOT IMPLEMENTED.
+	// CanceledBy holds user data which canceled a given Action.
 	// +optional
 	CanceledBy *authv1.UserInfo `json:"canceledBy,omitempty"`
 
@@ -270,6 +305,13 @@ type ActionStatus struct {
 	// Last time the condition transitioned from one status to another.
 	// +optional
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Conditions describe additional observations of the Action state, which are not reflected in the phase,
+	// e.g. the Action succeeded, but the runners reported warnings.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // ActionOutput describes Action output.
@@ -296,9 +338,26 @@ type RenderingStatus struct {
 	// +optional
 	TypeInstancesToLock []string `json:"typeInstancesToLock,omitempty"`
 
-	// AdvancedRendering describes status related to advanced rendering mode. CURRENTLY NOT IMPLEMENTED.
+	// AdvancedRendering describes status related to advanced rendering mode.
 	// +optional
 	AdvancedRendering *AdvancedRenderingStatus `json:"advancedRendering,omitempty"`
+
+	// ImplementationSelections describes how Implementations were selected during rendering.
+	// +optional
+	ImplementationSelections []ImplementationSelectionStatus `json:"implementationSelections,omitempty"`
+
+	// Runner describes the runner resolved for the rendered Action.
+	// +optional
+	Runner *RenderedRunner `json:"runner,omitempty"`
+}
+
+// RenderedRunner describes the runner resolved for the rendered Action.
+type RenderedRunner struct {
+	// Interface refers to the runner Interface imported by the Action Implementation.
+	Interface ManifestReference `json:"interface"`
+
+	// Image is the runner image compatible with the runner Interface revision.
+	Image string `json:"image"`
 }
 
 // SetAction sets the Action property to a given input.
@@ -349,6 +408,28 @@ func (r *RenderingStatus) SetTypeInstancesToLock(typeInstances []string) {
 	r.TypeInstancesToLock = typeInstances
 }
 
+// SetRenderingIteration sets the current rendering iteration in advanced rendering mode.
+func (r *RenderingStatus) SetRenderingIteration(name string, typeInstancesToProvide []InputTypeInstanceToProvide) {
+	r.AdvancedRendering = &AdvancedRenderingStatus{
+		RenderingIteration: &RenderingIterationStatus{
+			CurrentIterationName: name,
+		},
+	}
+
+	if len(typeInstancesToProvide) > 0 {
+		r.AdvancedRendering.RenderingIteration.InputTypeInstancesToProvide = &typeInstancesToProvide
+	}
+}
+
+// GetRenderingIteration returns the current rendering iteration in advanced rendering mode.
+// It returns nil if there is no such iteration.
+func (r *RenderingStatus) GetRenderingIteration() *RenderingIterationStatus {
+	if r == nil || r.AdvancedRendering == nil {
+		return nil
+	}
+	return r.AdvancedRendering.RenderingIteration
+}
+
 // ResolvedActionInput contains resolved details of Action input.
 type ResolvedActionInput struct {
 	// TypeInstances contains input TypeInstances passed for Action rendering.
@@ -420,6 +501,37 @@ type ManifestReference struct {
 	Revision *string `json:"revision,omitempty"`
 }
 
+// ImplementationSelectionStatus describes how the Implementation was selected for a given Interface.
+type ImplementationSelectionStatus struct {
+
+	// Name is the name of the workflow step, which imports the Interface.
+	// For the Action Interface, it is `capact-root`.
+	Name string `json:"name"`
+
+	// Interface refers to the Interface manifest.
+	Interface ManifestReference `json:"interface"`
+
+	// Strategy is the Implementation selection strategy defined in Policy.
+	Strategy string `json:"strategy"`
+
+	// Selected refers to the selected Implementation.
+	Selected ManifestReference `json:"selected"`
+
+	// Discarded contains Implementations, which matched the Policy rule, but were not selected.
+	// +optional
+	Discarded []DiscardedImplementation `json:"discarded,omitempty"`
+}
+
+// DiscardedImplementation describes the Implementation, which was not selected.
+type DiscardedImplementation struct {
+
+	// Implementation refers to the Implementation manifest.
+	Implementation ManifestReference `json:"implementation"`
+
+	// Reason describes why the Implementation was not selected.
+	Reason string `json:"reason"`
+}
+
 // AdvancedRenderingStatus describes status related to advanced rendering mode.
 type AdvancedRenderingStatus struct {
 
@@ -448,6 +560,33 @@ type RunnerStatus struct {
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Status *runtime.RawExtension `json:"status,omitempty"`
+
+	// Progress holds the last progress reported by the Runner.
+	// +optional
+	Progress *RunnerProgress `json:"progress,omitempty"`
+
+	// Warnings holds the warnings reported by the Runners executed as workflow steps.
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// RunnerProgress describes the progress of a long-running Runner operation.
+type RunnerProgress struct {
+	// Phase holds a short, Runner specific name of the current operation step.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides details about the current operation step.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Completed holds the number of already completed units of work, e.g. created resources.
+	// +optional
+	Completed int32 `json:"completed,omitempty"`
+
+	// Total holds the number of all units of work. It is not set if the total number is unknown.
+	// +optional
+	Total int32 `json:"total,omitempty"`
 }
 
 // NodePath defines full path for a given manifest, e.g. Implementation or Interface.
@@ -471,5 +610,38 @@ const (
 	FailedActionPhase                         ActionPhase = "Failed"
 )
 
+// ActionConditionType describes the type of the Action condition.
+type ActionConditionType string
+
+const (
+	// RunnerWarningsActionCondition indicates that the Runners reported warnings, which require attention,
+	// e.g. Terraform applied the resources, but its outputs cannot be produced.
+	RunnerWarningsActionCondition ActionConditionType = "RunnerWarnings"
+)
+
+// ActionFailureReason describes why the Action failed.
+// +kubebuilder:validation:Enum=InterfaceNotFound;ImplementationNotFound;InvalidInput;InvalidPolicy;InvalidManifest;UnsupportedRunner;RetriesExceeded;RunnerFailed
+type ActionFailureReason string
+
+// List of possible Action failure reasons.
+const (
+	// InterfaceNotFoundActionFailureReason indicates that the Interface referenced by the Action does not exist.
+	InterfaceNotFoundActionFailureReason ActionFailureReason = "InterfaceNotFound"
+	// ImplementationNotFoundActionFailureReason indicates that no Implementation satisfies the Action Policy.
+	ImplementationNotFoundActionFailureReason ActionFailureReason = "ImplementationNotFound"
+	// InvalidInputActionFailureReason indicates that the Action input parameters or TypeInstances are invalid.
+	InvalidInputActionFailureReason ActionFailureReason = "InvalidInput"
+	// InvalidPolicyActionFailureReason indicates that the Action Policy is invalid.
+	InvalidPolicyActionFailureReason ActionFailureReason = "InvalidPolicy"
+	// InvalidManifestActionFailureReason indicates that the Hub manifests used to render the Action are invalid.
+	InvalidManifestActionFailureReason ActionFailureReason = "InvalidManifest"
+	// UnsupportedRunnerActionFailureReason indicates that the runner selected for the Action is not supported.
+	UnsupportedRunnerActionFailureReason ActionFailureReason = "UnsupportedRunner"
+	// RetriesExceededActionFailureReason indicates that a transient error still occurred after all retries.
+	RetriesExceededActionFailureReason ActionFailureReason = "RetriesExceeded"
+	// RunnerFailedActionFailureReason indicates that the runner finished unsuccessfully.
+	RunnerFailedActionFailureReason ActionFailureReason = "RunnerFailed"
+)
+
 // ActionFinalizer is the name of the Action finalizer
 const ActionFinalizer = "actions.core.capact.io/finalizer"
@@ -7,6 +7,7 @@ package v1alpha1
 
 import (
 	"k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -230,6 +231,13 @@ func (in *ActionStatus) DeepCopyInto(out *ActionStatus) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionStatus.
@@ -282,6 +290,46 @@ func (in *AdvancedRenderingStatus) DeepCopy() *AdvancedRenderingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscardedImplementation) DeepCopyInto(out *DiscardedImplementation) {
+	*out = *in
+	in.Implementation.DeepCopyInto(&out.Implementation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscardedImplementation.
+func (in *DiscardedImplementation) DeepCopy() *DiscardedImplementation {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscardedImplementation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImplementationSelectionStatus) DeepCopyInto(out *ImplementationSelectionStatus) {
+	*out = *in
+	in.Interface.DeepCopyInto(&out.Interface)
+	in.Selected.DeepCopyInto(&out.Selected)
+	if in.Discarded != nil {
+		in, out := &in.Discarded, &out.Discarded
+		*out = make([]DiscardedImplementation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImplementationSelectionStatus.
+func (in *ImplementationSelectionStatus) DeepCopy() *ImplementationSelectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImplementationSelectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InputParameters) DeepCopyInto(out *InputParameters) {
 	*out = *in
@@ -415,6 +463,22 @@ func (in *RenderingIterationStatus) DeepCopy() *RenderingIterationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenderedRunner) DeepCopyInto(out *RenderedRunner) {
+	*out = *in
+	in.Interface.DeepCopyInto(&out.Interface)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderedRunner.
+func (in *RenderedRunner) DeepCopy() *RenderedRunner {
+	if in == nil {
+		return nil
+	}
+	out := new(RenderedRunner)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 	*out = *in
@@ -438,6 +502,18 @@ func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 		*out = new(AdvancedRenderingStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ImplementationSelections != nil {
+		in, out := &in.ImplementationSelections, &out.ImplementationSelections
+		*out = make([]ImplementationSelectionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Runner != nil {
+		in, out := &in.Runner, &out.Runner
+		*out = new(RenderedRunner)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderingStatus.
@@ -484,6 +560,21 @@ func (in *ResolvedActionInput) DeepCopy() *ResolvedActionInput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerProgress) DeepCopyInto(out *RunnerProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerProgress.
+func (in *RunnerProgress) DeepCopy() *RunnerProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerStatus) DeepCopyInto(out *RunnerStatus) {
 	*out = *in
@@ -492,6 +583,16 @@ func (in *RunnerStatus) DeepCopyInto(out *RunnerStatus) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(RunnerProgress)
+		**out = **in
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerStatus.
@@ -2,8 +2,11 @@ package policy
 
 import (
 	"encoding/json"
+	"time"
 
 	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+
+	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/yaml"
 )
@@ -32,6 +35,7 @@ const (
 type Policy struct {
 	Interface    InterfacePolicy    `json:"interface"`
 	TypeInstance TypeInstancePolicy `json:"typeInstance"`
+	Retry        *RetryPolicy       `json:"retry,omitempty"`
 }
 
 // InterfacePolicy holds the Policy for Interfaces.
@@ -79,9 +83,19 @@ type RulesForInterface struct {
 // +kubebuilder:object:generate=true
 type Rule struct {
 	ImplementationConstraints ImplementationConstraints `json:"implementationConstraints,omitempty"`
+	ImplementationSelection   *ImplementationSelection  `json:"implementationSelection,omitempty"`
 	Inject                    *InjectData               `json:"inject,omitempty"`
 }
 
+// SelectionStrategy returns the Implementation selection strategy for a given rule.
+// If not specified, the FirstImplementationSelection strategy is returned.
+func (in *Rule) SelectionStrategy() ImplementationSelectionStrategy {
+	if in == nil || in.ImplementationSelection == nil || in.ImplementationSelection.Strategy == "" {
+		return FirstImplementationSelection
+	}
+	return in.ImplementationSelection.Strategy
+}
+
 // RequiredTypeInstancesToInject returns required TypeInstances to inject for a given rule.
 func (in *Rule) RequiredTypeInstancesToInject() []RequiredTypeInstanceToInject {
 	if in == nil || in.Inject == nil {
@@ -127,6 +141,169 @@ type ImplementationConstraints struct {
 	Path *string `json:"path,omitempty"`
 }
 
+// ImplementationSelectionStrategy describes how a single Implementation is selected
+// from all Implementations which match a given rule.
+type ImplementationSelectionStrategy string
+
+const (
+	// FirstImplementationSelection selects the first Implementation returned by Hub.
+	FirstImplementationSelection ImplementationSelectionStrategy = "FIRST"
+	// AttributePreferenceImplementationSelection selects the Implementation which has the most preferred Attribute.
+	// Attributes are ordered from the most to the least preferred one.
+	AttributePreferenceImplementationSelection ImplementationSelectionStrategy = "ATTRIBUTE_PREFERENCE"
+	// NewestRevisionImplementationSelection selects the Implementation with the highest revision.
+	NewestRevisionImplementationSelection ImplementationSelectionStrategy = "NEWEST_REVISION"
+	// HighestAppVersionImplementationSelection selects the Implementation with the highest application version,
+	// which satisfies the optional SemVer constraint.
+	HighestAppVersionImplementationSelection ImplementationSelectionStrategy = "HIGHEST_APP_VERSION"
+	// WeightedScoreImplementationSelection selects the Implementation with the highest score.
+	// The score is a sum of weights for all Attributes the Implementation has.
+	WeightedScoreImplementationSelection ImplementationSelectionStrategy = "WEIGHTED_SCORE"
+)
+
+// ImplementationSelection holds the configuration of selecting a single Implementation
+// from all Implementations which match a given rule.
+// +kubebuilder:object:generate=true
+type ImplementationSelection struct {
+	// Strategy is the Implementation selection strategy.
+	Strategy ImplementationSelectionStrategy `json:"strategy"`
+
+	// Attributes holds the Attributes ordered by preference. Used by the ATTRIBUTE_PREFERENCE strategy.
+	Attributes []types.ManifestRefWithOptRevision `json:"attributes,omitempty"`
+
+	// AppVersionConstraint is a SemVer constraint the application version must satisfy.
+	// Used by the HIGHEST_APP_VERSION strategy.
+	AppVersionConstraint *string `json:"appVersionConstraint,omitempty"`
+
+	// Weights holds the Attribute weights. Used by the WEIGHTED_SCORE strategy.
+	Weights []ImplementationAttributeWeight `json:"weights,omitempty"`
+}
+
+// Validate validates the Implementation selection configuration for the chosen strategy.
+func (in *ImplementationSelection) Validate() error {
+	if in == nil {
+		return nil
+	}
+
+	switch in.Strategy {
+	case "", FirstImplementationSelection, NewestRevisionImplementationSelection:
+	case AttributePreferenceImplementationSelection:
+		if len(in.Attributes) == 0 {
+			return errors.Errorf("preferred Attributes must be provided for %s strategy", in.Strategy)
+		}
+	case HighestAppVersionImplementationSelection:
+		if in.AppVersionConstraint == nil || *in.AppVersionConstraint == "" {
+			return nil
+		}
+		if _, err := semver.NewConstraint(*in.AppVersionConstraint); err != nil {
+			return errors.Wrapf(err, "while parsing appVersion constraint %q", *in.AppVersionConstraint)
+		}
+	case WeightedScoreImplementationSelection:
+		if len(in.Weights) == 0 {
+			return errors.Errorf("Attribute weights must be provided for %s strategy", in.Strategy)
+		}
+	default:
+		return errors.Errorf("unknown Implementation selection strategy %q", in.Strategy)
+	}
+
+	return nil
+}
+
+// ImplementationAttributeWeight holds the weight of a given Attribute.
+// +kubebuilder:object:generate=true
+type ImplementationAttributeWeight struct {
+	// Attribute refers a specific Attribute by path and optional revision.
+	Attribute types.ManifestRefWithOptRevision `json:"attribute"`
+
+	// Weight is added to the Implementation score, if the Implementation has a given Attribute.
+	// It can be negative.
+	Weight int `json:"weight"`
+}
+
+// RetryOn describes which failures are retried.
+type RetryOn string
+
+const (
+	// AlwaysRetryOn retries all failed steps.
+	AlwaysRetryOn RetryOn = "ALWAYS"
+	// OnFailureRetryOn retries steps whose main container is marked as failed.
+	OnFailureRetryOn RetryOn = "ON_FAILURE"
+	// OnErrorRetryOn retries steps which encountered system level errors, such as Pod eviction or node failure.
+	OnErrorRetryOn RetryOn = "ON_ERROR"
+	// OnTransientErrorRetryOn retries steps which encountered errors classified as transient,
+	// such as network timeouts or throttled cloud API calls.
+	OnTransientErrorRetryOn RetryOn = "ON_TRANSIENT_ERROR"
+)
+
+// RetryPolicy holds the configuration of retrying failed runner Jobs and workflow steps.
+// +kubebuilder:object:generate=true
+type RetryPolicy struct {
+	// Limit is the maximum number of retries. It doesn't include the first attempt.
+	Limit int `json:"limit"`
+
+	// RetryOn describes which failures are retried. Defaults to ON_FAILURE.
+	RetryOn RetryOn `json:"retryOn,omitempty"`
+
+	// Backoff holds the delay configuration between retries.
+	Backoff *RetryBackoff `json:"backoff,omitempty"`
+}
+
+// RetryBackoff holds the delay configuration between retries.
+// +kubebuilder:object:generate=true
+type RetryBackoff struct {
+	// Duration is the delay before the first retry, e.g. `30s` or `2m`.
+	Duration string `json:"duration"`
+
+	// Factor multiplies the delay after each retry.
+	Factor *int `json:"factor,omitempty"`
+
+	// MaxDuration is the maximum time spent on retries, counting from the first attempt.
+	MaxDuration *string `json:"maxDuration,omitempty"`
+}
+
+// RetryOnOrDefault returns the retry classification. If not specified, the OnFailureRetryOn is returned.
+func (in *RetryPolicy) RetryOnOrDefault() RetryOn {
+	if in.RetryOn == "" {
+		return OnFailureRetryOn
+	}
+	return in.RetryOn
+}
+
+// Validate validates the retry policy.
+func (in *RetryPolicy) Validate() error {
+	if in == nil {
+		return nil
+	}
+
+	if in.Limit < 0 {
+		return errors.Errorf("retry limit must not be negative, got %d", in.Limit)
+	}
+
+	switch in.RetryOnOrDefault() {
+	case AlwaysRetryOn, OnFailureRetryOn, OnErrorRetryOn, OnTransientErrorRetryOn:
+	default:
+		return errors.Errorf("unknown retry classification %q", in.RetryOn)
+	}
+
+	if in.Backoff == nil {
+		return nil
+	}
+
+	if _, err := time.ParseDuration(in.Backoff.Duration); err != nil {
+		return errors.Wrapf(err, "while parsing retry backoff duration %q", in.Backoff.Duration)
+	}
+	if in.Backoff.Factor != nil && *in.Backoff.Factor < 1 {
+		return errors.Errorf("retry backoff factor must be greater than 0, got %d", *in.Backoff.Factor)
+	}
+	if in.Backoff.MaxDuration != nil {
+		if _, err := time.ParseDuration(*in.Backoff.MaxDuration); err != nil {
+			return errors.Wrapf(err, "while parsing retry backoff max duration %q", *in.Backoff.MaxDuration)
+		}
+	}
+
+	return nil
+}
+
 // RequiredTypeInstanceToInject holds a RequiredTypeInstances to be injected to the Action.
 // +kubebuilder:object:generate=true
 type RequiredTypeInstanceToInject struct {
@@ -191,3 +368,20 @@ func (in Policy) ToYAMLString() (string, error) {
 
 	return string(bytes), nil
 }
+
+// Validate validates the parts of the Policy, which cannot be checked by its schema.
+func (in Policy) Validate() error {
+	for _, rules := range in.Interface.Rules {
+		for idx, rule := range rules.OneOf {
+			if err := rule.ImplementationSelection.Validate(); err != nil {
+				return errors.Wrapf(err, "while validating Implementation selection for rule %d of %q", idx, rules.Interface.String())
+			}
+		}
+	}
+
+	if err := in.Retry.Validate(); err != nil {
+		return errors.Wrap(err, "while validating retry Policy")
+	}
+
+	return nil
+}
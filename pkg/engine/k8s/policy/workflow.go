@@ -46,6 +46,7 @@ type WorkflowRulesForInterface struct {
 // if this Implementation is selected.
 type WorkflowRule struct {
 	ImplementationConstraints ImplementationConstraints `json:"implementationConstraints,omitempty"`
+	ImplementationSelection   *ImplementationSelection  `json:"implementationSelection,omitempty"`
 	Inject                    *WorkflowInjectData       `json:"inject,omitempty"`
 }
 
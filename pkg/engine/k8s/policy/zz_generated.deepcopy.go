@@ -45,6 +45,22 @@ func (in *AdditionalTypeInstanceToInject) DeepCopy() *AdditionalTypeInstanceToIn
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImplementationAttributeWeight) DeepCopyInto(out *ImplementationAttributeWeight) {
+	*out = *in
+	in.Attribute.DeepCopyInto(&out.Attribute)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImplementationAttributeWeight.
+func (in *ImplementationAttributeWeight) DeepCopy() *ImplementationAttributeWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(ImplementationAttributeWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImplementationConstraints) DeepCopyInto(out *ImplementationConstraints) {
 	*out = *in
@@ -87,6 +103,40 @@ func (in *ImplementationConstraints) DeepCopy() *ImplementationConstraints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImplementationSelection) DeepCopyInto(out *ImplementationSelection) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make([]types.ManifestRefWithOptRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AppVersionConstraint != nil {
+		in, out := &in.AppVersionConstraint, &out.AppVersionConstraint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make([]ImplementationAttributeWeight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImplementationSelection.
+func (in *ImplementationSelection) DeepCopy() *ImplementationSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(ImplementationSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequiredTypeInstanceToInject) DeepCopyInto(out *RequiredTypeInstanceToInject) {
 	*out = *in
@@ -103,10 +153,60 @@ func (in *RequiredTypeInstanceToInject) DeepCopy() *RequiredTypeInstanceToInject
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBackoff) DeepCopyInto(out *RetryBackoff) {
+	*out = *in
+	if in.Factor != nil {
+		in, out := &in.Factor, &out.Factor
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBackoff.
+func (in *RetryBackoff) DeepCopy() *RetryBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(RetryBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rule) DeepCopyInto(out *Rule) {
 	*out = *in
 	in.ImplementationConstraints.DeepCopyInto(&out.ImplementationConstraints)
+	if in.ImplementationSelection != nil {
+		in, out := &in.ImplementationSelection, &out.ImplementationSelection
+		*out = new(ImplementationSelection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Inject != nil {
 		in, out := &in.Inject, &out.Inject
 		*out = (*in).DeepCopy()
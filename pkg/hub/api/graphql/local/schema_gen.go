@@ -1159,7 +1159,7 @@ type Mutation {
   unlockTypeInstances(in: UnlockTypeInstancesInput!): [ID!]!
 }
 
-# TODO: Prepare directive for user authorization in https://github.com/capactio/capact/issues/508
+# User authorization is enforced by Gateway based on its RBAC policy.
 `, BuiltIn: false},
 }
 var parsedSchema = gqlparser.MustLoadSchema(sources...)
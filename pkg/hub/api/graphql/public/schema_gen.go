@@ -2215,7 +2215,7 @@ type Query @additionalLabels(labels: ["published"]) {
   attribute(path: NodePath!): Attribute
 }
 
-# TODO: Prepare directive for user authorization in https://github.com/capactio/capact/issues/508
+# User authorization is enforced by Gateway based on its RBAC policy.
 `, BuiltIn: false},
 }
 var parsedSchema = gqlparser.MustLoadSchema(sources...)
@@ -0,0 +1,124 @@
+package storage_backend
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetValueFunc returns a value for a single TypeInstance.
+type GetValueFunc func(ctx context.Context, req *GetValueRequest) (*GetValueResponse, error)
+
+// OnLockFunc locks a single TypeInstance.
+type OnLockFunc func(ctx context.Context, req *OnLockRequest) (*OnLockResponse, error)
+
+// OnUnlockFunc unlocks a single TypeInstance.
+type OnUnlockFunc func(ctx context.Context, req *OnUnlockRequest) (*OnUnlockResponse, error)
+
+// GetValuesSequentially handles the GetValues request by calling getValue for each TypeInstance.
+// It stops on the first error.
+func GetValuesSequentially(ctx context.Context, req *GetValuesRequest, getValue GetValueFunc) (*GetValuesResponse, error) {
+	out := &GetValuesResponse{
+		Responses: make([]*GetValueResponse, 0, len(req.GetRequests())),
+	}
+	for _, item := range req.GetRequests() {
+		res, err := getValue(ctx, item)
+		if err != nil {
+			return nil, typeInstanceError(item.GetTypeInstanceId(), err)
+		}
+		out.Responses = append(out.Responses, res)
+	}
+
+	return out, nil
+}
+
+// OnLockBatchSequentially handles the OnLockBatch request by calling lock for each TypeInstance.
+// If locking one of them fails, the already locked TypeInstances are unlocked on a best-effort basis.
+func OnLockBatchSequentially(ctx context.Context, req *OnLockBatchRequest, lock OnLockFunc, unlock OnUnlockFunc) (*OnLockBatchResponse, error) {
+	for idx, item := range req.GetRequests() {
+		if _, err := lock(ctx, item); err != nil {
+			for _, locked := range req.GetRequests()[:idx] {
+				// best-effort rollback, the original error is more relevant for the caller
+				_, _ = unlock(ctx, &OnUnlockRequest{
+					TypeInstanceId: locked.GetTypeInstanceId(),
+					Context:        locked.GetContext(),
+				})
+			}
+			return nil, typeInstanceError(item.GetTypeInstanceId(), err)
+		}
+	}
+
+	return &OnLockBatchResponse{}, nil
+}
+
+// OnUnlockBatchSequentially handles the OnUnlockBatch request by calling unlock for each TypeInstance.
+// It stops on the first error.
+func OnUnlockBatchSequentially(ctx context.Context, req *OnUnlockBatchRequest, unlock OnUnlockFunc) (*OnUnlockBatchResponse, error) {
+	for _, item := range req.GetRequests() {
+		if _, err := unlock(ctx, item); err != nil {
+			return nil, typeInstanceError(item.GetTypeInstanceId(), err)
+		}
+	}
+
+	return &OnUnlockBatchResponse{}, nil
+}
+
+// GetValuesWithFallback calls the GetValues RPC. If the storage backend doesn't implement it,
+// it falls back to calling the GetValue RPC for each TypeInstance.
+func GetValuesWithFallback(ctx context.Context, client StorageBackendClient, req *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesResponse, error) {
+	res, err := client.GetValues(ctx, req, opts...)
+	if !isUnimplemented(err) {
+		return res, err
+	}
+
+	return GetValuesSequentially(ctx, req, func(ctx context.Context, req *GetValueRequest) (*GetValueResponse, error) {
+		return client.GetValue(ctx, req, opts...)
+	})
+}
+
+// OnLockBatchWithFallback calls the OnLockBatch RPC. If the storage backend doesn't implement it,
+// it falls back to calling the OnLock RPC for each TypeInstance.
+func OnLockBatchWithFallback(ctx context.Context, client StorageBackendClient, req *OnLockBatchRequest, opts ...grpc.CallOption) (*OnLockBatchResponse, error) {
+	res, err := client.OnLockBatch(ctx, req, opts...)
+	if !isUnimplemented(err) {
+		return res, err
+	}
+
+	lock := func(ctx context.Context, req *OnLockRequest) (*OnLockResponse, error) {
+		return client.OnLock(ctx, req, opts...)
+	}
+	unlock := func(ctx context.Context, req *OnUnlockRequest) (*OnUnlockResponse, error) {
+		return client.OnUnlock(ctx, req, opts...)
+	}
+	return OnLockBatchSequentially(ctx, req, lock, unlock)
+}
+
+// OnUnlockBatchWithFallback calls the OnUnlockBatch RPC. If the storage backend doesn't implement it,
+// it falls back to calling the OnUnlock RPC for each TypeInstance.
+func OnUnlockBatchWithFallback(ctx context.Context, client StorageBackendClient, req *OnUnlockBatchRequest, opts ...grpc.CallOption) (*OnUnlockBatchResponse, error) {
+	res, err := client.OnUnlockBatch(ctx, req, opts...)
+	if !isUnimplemented(err) {
+		return res, err
+	}
+
+	return OnUnlockBatchSequentially(ctx, req, func(ctx context.Context, req *OnUnlockRequest) (*OnUnlockResponse, error) {
+		return client.OnUnlock(ctx, req, opts...)
+	})
+}
+
+func isUnimplemented(err error) bool {
+	return status.Code(err) == codes.Unimplemented
+}
+
+// typeInstanceError prefixes the error message with the TypeInstance ID but keeps the original gRPC status code.
+func typeInstanceError(typeInstanceID string, err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return status.Error(codes.Internal, fmt.Sprintf("TypeInstance %q: %s", typeInstanceID, err.Error()))
+	}
+
+	return status.Error(st.Code(), fmt.Sprintf("TypeInstance %q: %s", typeInstanceID, st.Message()))
+}
@@ -0,0 +1,251 @@
+package storage_backend_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGetValuesWithFallback(t *testing.T) {
+	// given
+	backend := newUnaryOnlyBackend(map[string][]byte{
+		"id1": []byte(`{"key":1}`),
+		"id2": []byte(`{"key":2}`),
+	})
+	client := setupUnaryOnlyBackendClient(t, backend)
+
+	req := &pb.GetValuesRequest{
+		Requests: []*pb.GetValueRequest{
+			{TypeInstanceId: "id2", ResourceVersion: 1},
+			{TypeInstanceId: "id1", ResourceVersion: 1},
+		},
+	}
+
+	// when
+	res, err := pb.GetValuesWithFallback(context.Background(), client, req)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, res.Responses, 2)
+	assert.Equal(t, []byte(`{"key":2}`), res.Responses[0].Value)
+	assert.Equal(t, []byte(`{"key":1}`), res.Responses[1].Value)
+
+	// when
+	req.Requests = append(req.Requests, &pb.GetValueRequest{TypeInstanceId: "missing"})
+	_, err = pb.GetValuesWithFallback(context.Background(), client, req)
+
+	// then
+	assert.EqualError(t, err, `rpc error: code = NotFound desc = TypeInstance "missing": value not found`)
+}
+
+func TestOnLockBatchWithFallback(t *testing.T) {
+	t.Run("Locks all TypeInstances", func(t *testing.T) {
+		// given
+		backend := newUnaryOnlyBackend(map[string][]byte{"id1": nil, "id2": nil})
+		client := setupUnaryOnlyBackendClient(t, backend)
+
+		req := &pb.OnLockBatchRequest{
+			Requests: []*pb.OnLockRequest{
+				{TypeInstanceId: "id1", LockedBy: "owner"},
+				{TypeInstanceId: "id2", LockedBy: "owner"},
+			},
+		}
+
+		// when
+		_, err := pb.OnLockBatchWithFallback(context.Background(), client, req)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"id1": "owner", "id2": "owner"}, backend.lockedBy)
+
+		// when
+		_, err = pb.OnUnlockBatchWithFallback(context.Background(), client, &pb.OnUnlockBatchRequest{
+			Requests: []*pb.OnUnlockRequest{
+				{TypeInstanceId: "id1"},
+				{TypeInstanceId: "id2"},
+			},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, backend.lockedBy)
+	})
+
+	t.Run("Unlocks already locked TypeInstances on failure", func(t *testing.T) {
+		// given
+		backend := newUnaryOnlyBackend(map[string][]byte{"id1": nil, "id2": nil, "id3": nil})
+		backend.lockedBy["id3"] = "other"
+		client := setupUnaryOnlyBackendClient(t, backend)
+
+		req := &pb.OnLockBatchRequest{
+			Requests: []*pb.OnLockRequest{
+				{TypeInstanceId: "id1", LockedBy: "owner"},
+				{TypeInstanceId: "id2", LockedBy: "owner"},
+				{TypeInstanceId: "id3", LockedBy: "owner"},
+			},
+		}
+
+		// when
+		_, err := pb.OnLockBatchWithFallback(context.Background(), client, req)
+
+		// then
+		assert.EqualError(t, err, `rpc error: code = FailedPrecondition desc = TypeInstance "id3": already locked by "other"`)
+		assert.Equal(t, map[string]string{"id3": "other"}, backend.lockedBy)
+	})
+}
+
+func TestWatchValueWithFallback(t *testing.T) {
+	// given
+	backend := newUnaryOnlyBackend(map[string][]byte{"id": []byte("v1")})
+	client := setupUnaryOnlyBackendClient(t, backend)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errStopWatching := errors.New("stop watching")
+	var received []string
+	handler := func(res *pb.WatchValueResponse) error {
+		received = append(received, string(res.Value))
+		switch len(received) {
+		case 1:
+			backend.setValue("id", []byte("v2"))
+		case 2:
+			return errStopWatching
+		}
+		return nil
+	}
+
+	// when
+	err := pb.WatchValueWithFallback(ctx, client, &pb.WatchValueRequest{TypeInstanceId: "id"}, 10*time.Millisecond, handler)
+
+	// then
+	assert.ErrorIs(t, err, errStopWatching)
+	assert.Equal(t, []string{"v1", "v2"}, received)
+}
+
+func TestWatchValueByPolling_FollowsLatestRevision(t *testing.T) {
+	// given
+	var mu sync.Mutex
+	revisions := map[uint32][]byte{1: []byte("v1")}
+	getValue := func(_ context.Context, req *pb.GetValueRequest) (*pb.GetValueResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		value, ok := revisions[req.ResourceVersion]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "revision %d not found", req.ResourceVersion)
+		}
+		return &pb.GetValueResponse{Value: value}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errStopWatching := errors.New("stop watching")
+	var received []string
+	handler := func(res *pb.WatchValueResponse) error {
+		received = append(received, string(res.Value))
+		switch len(received) {
+		case 1:
+			mu.Lock()
+			revisions[2] = []byte("v2")
+			mu.Unlock()
+		case 2:
+			return errStopWatching
+		}
+		return nil
+	}
+
+	// when
+	err := pb.WatchValueByPolling(ctx, &pb.WatchValueRequest{TypeInstanceId: "id", ResourceVersion: 1}, 10*time.Millisecond, getValue, handler)
+
+	// then
+	assert.ErrorIs(t, err, errStopWatching)
+	assert.Equal(t, []string{"v1", "v2"}, received)
+}
+
+// unaryOnlyBackend implements only the unary RPCs to check the fallback logic.
+type unaryOnlyBackend struct {
+	pb.UnimplementedStorageBackendServer
+
+	mu       sync.Mutex
+	values   map[string][]byte
+	lockedBy map[string]string
+}
+
+func newUnaryOnlyBackend(values map[string][]byte) *unaryOnlyBackend {
+	return &unaryOnlyBackend{
+		values:   values,
+		lockedBy: map[string]string{},
+	}
+}
+
+func (b *unaryOnlyBackend) setValue(id string, value []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.values[id] = value
+}
+
+func (b *unaryOnlyBackend) GetValue(_ context.Context, req *pb.GetValueRequest) (*pb.GetValueResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	value, ok := b.values[req.TypeInstanceId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "value not found")
+	}
+	return &pb.GetValueResponse{Value: value}, nil
+}
+
+func (b *unaryOnlyBackend) OnLock(_ context.Context, req *pb.OnLockRequest) (*pb.OnLockResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if lockedBy, ok := b.lockedBy[req.TypeInstanceId]; ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "already locked by %q", lockedBy)
+	}
+	b.lockedBy[req.TypeInstanceId] = req.LockedBy
+	return &pb.OnLockResponse{}, nil
+}
+
+func (b *unaryOnlyBackend) OnUnlock(_ context.Context, req *pb.OnUnlockRequest) (*pb.OnUnlockResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.lockedBy, req.TypeInstanceId)
+	return &pb.OnUnlockResponse{}, nil
+}
+
+func setupUnaryOnlyBackendClient(t *testing.T, backend pb.StorageBackendServer) pb.StorageBackendClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	pb.RegisterStorageBackendServer(srv, backend)
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewStorageBackendClient(conn)
+}
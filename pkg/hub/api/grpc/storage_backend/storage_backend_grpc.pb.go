@@ -31,6 +31,14 @@ type StorageBackendClient interface {
 	GetLockedBy(ctx context.Context, in *GetLockedByRequest, opts ...grpc.CallOption) (*GetLockedByResponse, error)
 	OnLock(ctx context.Context, in *OnLockRequest, opts ...grpc.CallOption) (*OnLockResponse, error)
 	OnUnlock(ctx context.Context, in *OnUnlockRequest, opts ...grpc.CallOption) (*OnUnlockResponse, error)
+	// batch
+	GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesResponse, error)
+	OnLockBatch(ctx context.Context, in *OnLockBatchRequest, opts ...grpc.CallOption) (*OnLockBatchResponse, error)
+	OnUnlockBatch(ctx context.Context, in *OnUnlockBatchRequest, opts ...grpc.CallOption) (*OnUnlockBatchResponse, error)
+	// watch
+	WatchValue(ctx context.Context, in *WatchValueRequest, opts ...grpc.CallOption) (StorageBackend_WatchValueClient, error)
+	// capabilities
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
 }
 
 type storageBackendClient struct {
@@ -104,6 +112,74 @@ func (c *storageBackendClient) OnUnlock(ctx context.Context, in *OnUnlockRequest
 	return out, nil
 }
 
+func (c *storageBackendClient) GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesResponse, error) {
+	out := new(GetValuesResponse)
+	err := c.cc.Invoke(ctx, "/storage_backend.StorageBackend/GetValues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageBackendClient) OnLockBatch(ctx context.Context, in *OnLockBatchRequest, opts ...grpc.CallOption) (*OnLockBatchResponse, error) {
+	out := new(OnLockBatchResponse)
+	err := c.cc.Invoke(ctx, "/storage_backend.StorageBackend/OnLockBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageBackendClient) OnUnlockBatch(ctx context.Context, in *OnUnlockBatchRequest, opts ...grpc.CallOption) (*OnUnlockBatchResponse, error) {
+	out := new(OnUnlockBatchResponse)
+	err := c.cc.Invoke(ctx, "/storage_backend.StorageBackend/OnUnlockBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageBackendClient) WatchValue(ctx context.Context, in *WatchValueRequest, opts ...grpc.CallOption) (StorageBackend_WatchValueClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageBackend_ServiceDesc.Streams[0], "/storage_backend.StorageBackend/WatchValue", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageBackendWatchValueClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageBackend_WatchValueClient interface {
+	Recv() (*WatchValueResponse, error)
+	grpc.ClientStream
+}
+
+type storageBackendWatchValueClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageBackendWatchValueClient) Recv() (*WatchValueResponse, error) {
+	m := new(WatchValueResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageBackendClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/storage_backend.StorageBackend/GetCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StorageBackendServer is the server API for StorageBackend service.
 // All implementations must embed UnimplementedStorageBackendServer
 // for forward compatibility
@@ -117,6 +193,14 @@ type StorageBackendServer interface {
 	GetLockedBy(context.Context, *GetLockedByRequest) (*GetLockedByResponse, error)
 	OnLock(context.Context, *OnLockRequest) (*OnLockResponse, error)
 	OnUnlock(context.Context, *OnUnlockRequest) (*OnUnlockResponse, error)
+	// batch
+	GetValues(context.Context, *GetValuesRequest) (*GetValuesResponse, error)
+	OnLockBatch(context.Context, *OnLockBatchRequest) (*OnLockBatchResponse, error)
+	OnUnlockBatch(context.Context, *OnUnlockBatchRequest) (*OnUnlockBatchResponse, error)
+	// watch
+	WatchValue(*WatchValueRequest, StorageBackend_WatchValueServer) error
+	// capabilities
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
 	mustEmbedUnimplementedStorageBackendServer()
 }
 
@@ -145,6 +229,21 @@ func (UnimplementedStorageBackendServer) OnLock(context.Context, *OnLockRequest)
 func (UnimplementedStorageBackendServer) OnUnlock(context.Context, *OnUnlockRequest) (*OnUnlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OnUnlock not implemented")
 }
+func (UnimplementedStorageBackendServer) GetValues(context.Context, *GetValuesRequest) (*GetValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValues not implemented")
+}
+func (UnimplementedStorageBackendServer) OnLockBatch(context.Context, *OnLockBatchRequest) (*OnLockBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnLockBatch not implemented")
+}
+func (UnimplementedStorageBackendServer) OnUnlockBatch(context.Context, *OnUnlockBatchRequest) (*OnUnlockBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnUnlockBatch not implemented")
+}
+func (UnimplementedStorageBackendServer) WatchValue(*WatchValueRequest, StorageBackend_WatchValueServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchValue not implemented")
+}
+func (UnimplementedStorageBackendServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
 func (UnimplementedStorageBackendServer) mustEmbedUnimplementedStorageBackendServer() {}
 
 // UnsafeStorageBackendServer may be embedded to opt out of forward compatibility for this service.
@@ -284,6 +383,99 @@ func _StorageBackend_OnUnlock_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageBackend_GetValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageBackendServer).GetValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/storage_backend.StorageBackend/GetValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageBackendServer).GetValues(ctx, req.(*GetValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageBackend_OnLockBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnLockBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageBackendServer).OnLockBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/storage_backend.StorageBackend/OnLockBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageBackendServer).OnLockBatch(ctx, req.(*OnLockBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageBackend_OnUnlockBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnUnlockBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageBackendServer).OnUnlockBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/storage_backend.StorageBackend/OnUnlockBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageBackendServer).OnUnlockBatch(ctx, req.(*OnUnlockBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageBackend_WatchValue_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchValueRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageBackendServer).WatchValue(m, &storageBackendWatchValueServer{stream})
+}
+
+type StorageBackend_WatchValueServer interface {
+	Send(*WatchValueResponse) error
+	grpc.ServerStream
+}
+
+type storageBackendWatchValueServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageBackendWatchValueServer) Send(m *WatchValueResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageBackend_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageBackendServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/storage_backend.StorageBackend/GetCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageBackendServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StorageBackend_ServiceDesc is the grpc.ServiceDesc for StorageBackend service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -319,7 +511,29 @@ var StorageBackend_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OnUnlock",
 			Handler:    _StorageBackend_OnUnlock_Handler,
 		},
+		{
+			MethodName: "GetValues",
+			Handler:    _StorageBackend_GetValues_Handler,
+		},
+		{
+			MethodName: "OnLockBatch",
+			Handler:    _StorageBackend_OnLockBatch_Handler,
+		},
+		{
+			MethodName: "OnUnlockBatch",
+			Handler:    _StorageBackend_OnUnlockBatch_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _StorageBackend_GetCapabilities_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchValue",
+			Handler:       _StorageBackend_WatchValue_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "storage_backend.proto",
 }
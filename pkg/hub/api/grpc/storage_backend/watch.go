@@ -0,0 +1,104 @@
+package storage_backend
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchValueHandler handles a single value received from the WatchValue stream.
+type WatchValueHandler func(res *WatchValueResponse) error
+
+// WatchValueByPolling gets the value in the requested resource version and passes it to the handler.
+// Then, it calls getValue for the next resource version every interval, so it follows the latest revision
+// instead of the one pinned in the request. The handler is called each time the value changes.
+// Storage backends which don't store revisions return the current value for any resource version,
+// so their changes are detected as well. It returns when the context is done or when getValue
+// or handler returns an error.
+func WatchValueByPolling(ctx context.Context, req *WatchValueRequest, interval time.Duration, getValue GetValueFunc, handler WatchValueHandler) error {
+	resourceVersion := req.GetResourceVersion()
+	res, err := getValue(ctx, &GetValueRequest{
+		TypeInstanceId:  req.GetTypeInstanceId(),
+		ResourceVersion: resourceVersion,
+		Context:         req.GetContext(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := handler(&WatchValueResponse{Value: res.Value}); err != nil {
+		return err
+	}
+	lastValue := res.GetValue()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		res, err := getValue(ctx, &GetValueRequest{
+			TypeInstanceId:  req.GetTypeInstanceId(),
+			ResourceVersion: resourceVersion + 1,
+			Context:         req.GetContext(),
+		})
+		if status.Code(err) == codes.NotFound {
+			// the next revision is not created yet
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		resourceVersion++
+
+		if bytes.Equal(lastValue, res.GetValue()) {
+			continue
+		}
+		if err := handler(&WatchValueResponse{Value: res.Value}); err != nil {
+			return err
+		}
+		lastValue = res.GetValue()
+	}
+}
+
+// WatchValueWithFallback calls the WatchValue RPC and passes each received value to the handler.
+// If the storage backend doesn't implement it, it falls back to polling the GetValue RPC every pollInterval.
+func WatchValueWithFallback(ctx context.Context, client StorageBackendClient, req *WatchValueRequest, pollInterval time.Duration, handler WatchValueHandler, opts ...grpc.CallOption) error {
+	stream, err := client.WatchValue(ctx, req, opts...)
+	if err == nil {
+		err = receiveWatchValues(stream, handler)
+	}
+
+	if !isUnimplemented(err) {
+		return err
+	}
+
+	return WatchValueByPolling(ctx, req, pollInterval, func(ctx context.Context, req *GetValueRequest) (*GetValueResponse, error) {
+		return client.GetValue(ctx, req, opts...)
+	}, handler)
+}
+
+func receiveWatchValues(stream StorageBackend_WatchValueClient, handler WatchValueHandler) error {
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := handler(res); err != nil {
+			return err
+		}
+	}
+}
@@ -16,9 +16,11 @@ func (e *PolicyEnforcedClient) mergePolicies() policy.Policy {
 		case policy.Global:
 			applyInterfacePolicy(&currentPolicy.Interface, e.globalPolicy.Interface)
 			applyTypeInstancePolicy(&currentPolicy.TypeInstance, e.globalPolicy.TypeInstance)
+			applyRetryPolicy(&currentPolicy, e.globalPolicy.Retry)
 		case policy.Action:
 			applyInterfacePolicy(&currentPolicy.Interface, e.actionPolicy.Interface)
 			applyTypeInstancePolicy(&currentPolicy.TypeInstance, e.actionPolicy.TypeInstance)
+			applyRetryPolicy(&currentPolicy, e.actionPolicy.Retry)
 		case policy.Workflow:
 			for _, wp := range e.workflowStepPolicies {
 				// ignore TypeInstance Policy on Workflow as it's not supported,
@@ -73,7 +75,20 @@ func applyInterfacePolicy(currentPolicy *policy.InterfacePolicy, newPolicy polic
 	}
 }
 
+// Retry policy is not merged, the one from the higher priority policy is used
+func applyRetryPolicy(currentPolicy *policy.Policy, newPolicy *policy.RetryPolicy) {
+	if currentPolicy.Retry != nil || newPolicy == nil {
+		return
+	}
+	currentPolicy.Retry = newPolicy.DeepCopy()
+}
+
 func mergeRules(rule *policy.Rule, newRule policy.Rule) {
+	// Implementation selection is not merged, the one from the higher priority policy is used
+	if rule.ImplementationSelection == nil && newRule.ImplementationSelection != nil {
+		rule.ImplementationSelection = newRule.ImplementationSelection.DeepCopy()
+	}
+
 	if newRule.Inject == nil {
 		return
 	}
@@ -1429,6 +1429,67 @@ func TestPolicyEnforcedClient_mergeTypeInstancePoliciesForDefault(t *testing.T)
 	}
 }
 
+func TestPolicyEnforcedClient_mergeRetryPolicies(t *testing.T) {
+	globalRetry := &policy.RetryPolicy{
+		Limit:   2,
+		RetryOn: policy.OnErrorRetryOn,
+	}
+	actionRetry := &policy.RetryPolicy{
+		Limit:   5,
+		RetryOn: policy.OnTransientErrorRetryOn,
+		Backoff: &policy.RetryBackoff{
+			Duration: "30s",
+			Factor:   ptr.Int(2),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		global   policy.Policy
+		action   policy.ActionPolicy
+		expected *policy.RetryPolicy
+		order    policy.MergeOrder
+	}{
+		{
+			name:     "Action retry policy has higher priority",
+			global:   policy.Policy{Retry: globalRetry},
+			action:   policy.ActionPolicy{Retry: actionRetry},
+			expected: actionRetry,
+			order:    policy.MergeOrder{policy.Action, policy.Global},
+		},
+		{
+			name:     "Global retry policy has higher priority",
+			global:   policy.Policy{Retry: globalRetry},
+			action:   policy.ActionPolicy{Retry: actionRetry},
+			expected: globalRetry,
+			order:    policy.MergeOrder{policy.Global, policy.Action},
+		},
+		{
+			name:     "Global retry policy used if Action one is not set",
+			global:   policy.Policy{Retry: globalRetry},
+			expected: globalRetry,
+			order:    policy.MergeOrder{policy.Action, policy.Global},
+		},
+		{
+			name:  "No retry policy",
+			order: policy.MergeOrder{policy.Action, policy.Global},
+		},
+	}
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			cli := client.NewPolicyEnforcedClient(nil, nil)
+			cli.SetPolicyOrder(tt.order)
+			cli.SetGlobalPolicy(tt.global)
+			cli.SetActionPolicy(tt.action)
+
+			// expect
+			assert.Equal(t, tt.expected, cli.MergedPolicy().Retry)
+		})
+	}
+}
+
 func TestRequiredTypeInstancesForRule(t *testing.T) {
 	tests := []struct {
 		name     string
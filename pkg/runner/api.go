@@ -21,6 +21,14 @@ type (
 		Status interface{}
 	}
 
+	// RunInput defines the input arguments to run a synchronous runner.
+	RunInput struct {
+		// RunnerCtx contains Runner data provided by Engine.
+		RunnerCtx Context
+		// Args that was provided by Engine.
+		Args json.RawMessage
+	}
+
 	// WaitForCompletionInput defines the input for the wait step of the runner.
 	WaitForCompletionInput struct {
 		// RunnerCtx contains Runner data provided by Engine.
@@ -28,14 +36,42 @@ type (
 	}
 
 	// WaitForCompletionOutput defines the output from the wait step of the runner.
+	// It is also returned by synchronous runners, once the operation is finished.
 	WaitForCompletionOutput struct {
 		// Succeeded indicates if runner finished successfully or not.
 		Succeeded bool
 		// Message holds a human readable message indicating details about why the is in this condition.
 		Message string
+		// Status holds optional generic status object, which is reported after the runner finished.
+		// It is later marshalled to JSON format.
+		Status interface{}
+	}
+
+	// Progress describes the progress of a long-running runner operation.
+	Progress struct {
+		// Phase holds a short, runner specific name of the current operation step, e.g. `pre-install hooks`.
+		Phase string `json:"phase,omitempty"`
+		// Message holds a human readable message with details about the current operation step.
+		Message string `json:"message,omitempty"`
+		// Completed holds the number of already completed units of work, e.g. created resources.
+		Completed int `json:"completed,omitempty"`
+		// Total holds the number of all units of work. It is zero if the total number is unknown.
+		Total int `json:"total,omitempty"`
 	}
 )
 
+// ProgressReportFunc reports the progress of the runner operation.
+// Reporting the progress is best effort, so it doesn't return errors.
+type ProgressReportFunc func(progress Progress)
+
+// Report reports a given progress. It is safe to call it on nil ProgressReportFunc.
+func (f ProgressReportFunc) Report(progress Progress) {
+	if f == nil {
+		return
+	}
+	f(progress)
+}
+
 // ErrorOrNil returns error if action finished unsuccessfully.
 func (o WaitForCompletionOutput) ErrorOrNil() error {
 	if !o.Succeeded {
@@ -45,13 +81,33 @@ func (o WaitForCompletionOutput) ErrorOrNil() error {
 }
 
 // Runner provide functionality to execute runner in a generic way.
+// Each runner must implement either AsyncRunner or SyncRunner interface.
 type Runner interface {
+	Name() string
+}
+
+// AsyncRunner starts a long-running operation and waits for its completion in a separate step.
+// The status returned from Start is reported before waiting for the completion.
+type AsyncRunner interface {
+	Runner
 	Start(ctx context.Context, in StartInput) (*StartOutput, error)
 	WaitForCompletion(ctx context.Context, in WaitForCompletionInput) (*WaitForCompletionOutput, error)
-	Name() string
+}
+
+// SyncRunner executes an operation in a single blocking call.
+type SyncRunner interface {
+	Runner
+	Run(ctx context.Context, in RunInput) (*WaitForCompletionOutput, error)
 }
 
 // StatusReporter provide functionality to report status.
 type StatusReporter interface {
 	Report(ctx context.Context, runnerCtx Context, status interface{}) error
+	ReportProgress(ctx context.Context, runnerCtx Context, progress Progress) error
+}
+
+// WarningStatus is implemented by statuses, which indicate that the runner operation finished, but its result requires attention.
+// Unlike other statuses, the warnings are reported also by the runners executed as workflow steps.
+type WarningStatus interface {
+	Warning() string
 }
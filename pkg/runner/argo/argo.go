@@ -10,6 +10,7 @@ import (
 	wfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,7 +37,7 @@ type (
 	}
 )
 
-var _ runner.Runner = &Runner{}
+var _ runner.AsyncRunner = &Runner{}
 
 // Runner provides functionality to run and wait for Argo Workflow.
 type Runner struct {
@@ -72,8 +73,17 @@ func (r *Runner) Start(ctx context.Context, in runner.StartInput) (*runner.Start
 		Spec: renderedWorkflow.Spec,
 	}
 
-	// We have agreement that we should return error also if workflow already exits.
-	if err := r.submitWorkflow(ctx, &wf, in.RunnerCtx); err != nil {
+	err := r.submitWorkflow(ctx, &wf, in.RunnerCtx)
+	switch {
+	case err == nil:
+	case apierrors.IsAlreadyExists(err):
+		// The runner is started once again, e.g. after Pod restart. Argo Workflow started by the previous attempt
+		// is reused, so the already executed steps are not executed once again. The failed steps are retried
+		// by Argo according to the retry strategy injected during rendering.
+		if err := r.ensureWorkflowStartedForOwner(ctx, wf); err != nil {
+			return nil, err
+		}
+	default:
 		return nil, errors.Wrap(err, "while creating Argo Workflow")
 	}
 
@@ -89,6 +99,8 @@ func (r *Runner) Start(ctx context.Context, in runner.StartInput) (*runner.Start
 
 // WaitForCompletion waits until Argo Workflow is finished.
 func (r *Runner) WaitForCompletion(ctx context.Context, in runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	// In DryRun mode, the Argo Workflow is only validated by the server, so the dry-run mode of the runners
+	// executed in the workflow steps, e.g. the Helm runner manifest diff, is not available for Actions.
 	if in.RunnerCtx.DryRun {
 		return &runner.WaitForCompletionOutput{
 			Succeeded: true,
@@ -154,6 +166,28 @@ func statusFromEvent(event *watch.Event) (wfv1.WorkflowStatus, error) {
 	}
 }
 
+// ensureWorkflowStartedForOwner returns error if already existing Argo Workflow wasn't started by the runner for a given owner.
+func (r *Runner) ensureWorkflowStartedForOwner(ctx context.Context, wf wfv1.Workflow) error {
+	existing, err := r.wfClientset.ArgoprojV1alpha1().Workflows(wf.Namespace).Get(ctx, wf.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "while getting already existing Argo Workflow")
+	}
+
+	if existing.Labels[wfManagedByLabelKey] != runnerName {
+		return errors.Errorf("Argo Workflow %q already exists and it is not managed by %s", wf.Name, runnerName)
+	}
+
+	for _, ref := range existing.OwnerReferences {
+		for _, expRef := range wf.OwnerReferences {
+			if ref.UID == expRef.UID {
+				return nil
+			}
+		}
+	}
+
+	return errors.Errorf("Argo Workflow %q already exists and it is owned by a different resource", wf.Name)
+}
+
 func (r *Runner) submitWorkflow(ctx context.Context, wf *wfv1.Workflow, runnerCtx runner.Context) error {
 	wfNSCli := r.wfClientset.ArgoprojV1alpha1().Workflows(runnerCtx.Platform.Namespace)
 	_, err := util.SubmitWorkflow(ctx, wfNSCli, r.wfClientset, runnerCtx.Platform.Namespace, wf, &wfv1.SubmitOpts{
@@ -78,14 +78,84 @@ func TestRunnerStartHappyPath(t *testing.T) {
 		require.NotNil(t, gotOutStatus)
 		assert.Equal(t, expOutStatus, *gotOutStatus)
 	})
+
+	t.Run("Should reuse Argo Workflow started by the previous runner attempt", func(t *testing.T) {
+		// given
+		input, expOutStatus := fixStartInputAndOutput(t)
+		input.RunnerCtx.Platform.OwnerRef = metav1.OwnerReference{UID: "action-uid"}
+
+		wf := fixFinishedArgoWorkflow(t, input.RunnerCtx.Name, input.RunnerCtx.Platform.Namespace)
+		wf.Labels = map[string]string{wfManagedByLabelKey: runnerName}
+		wf.OwnerReferences = []metav1.OwnerReference{input.RunnerCtx.Platform.OwnerRef}
+		fakeCli := fake.NewSimpleClientset(&wf)
+
+		r := NewRunner(fakeCli)
+
+		// when
+		gotOutStatus, err := r.Start(context.Background(), input)
+
+		// then
+		require.NoError(t, err)
+
+		require.NotNil(t, gotOutStatus)
+		assert.Equal(t, expOutStatus, *gotOutStatus)
+	})
+}
+
+func TestRunnerStartReusesFailedWorkflow(t *testing.T) {
+	// given
+	ctx := context.Background()
+	input, expOutStatus := fixStartInputAndOutput(t)
+	input.RunnerCtx.Platform.OwnerRef = metav1.OwnerReference{UID: "action-uid"}
+
+	wf := fixFinishedArgoWorkflow(t, input.RunnerCtx.Name, input.RunnerCtx.Platform.Namespace)
+	wf.Labels = map[string]string{wfManagedByLabelKey: runnerName}
+	wf.OwnerReferences = []metav1.OwnerReference{input.RunnerCtx.Platform.OwnerRef}
+	wf.Status.Phase = wfv1.WorkflowFailed
+	fakeCli := fake.NewSimpleClientset(&wf)
+
+	r := NewRunner(fakeCli)
+
+	// when
+	gotOutStatus, err := r.Start(ctx, input)
+
+	// then
+	require.NoError(t, err)
+
+	require.NotNil(t, gotOutStatus)
+	assert.Equal(t, expOutStatus, *gotOutStatus)
+
+	gotWf, err := fakeCli.ArgoprojV1alpha1().Workflows(input.RunnerCtx.Platform.Namespace).Get(ctx, input.RunnerCtx.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.WorkflowFailed, gotWf.Status.Phase, "failed Argo Workflow should be kept with its history, not resubmitted")
 }
 
 func TestRunnerStartFailure(t *testing.T) {
-	t.Run("Should return error when Argo Workflow already exits", func(t *testing.T) {
+	t.Run("Should return error when Argo Workflow not managed by runner already exits", func(t *testing.T) {
+		// given
+		input, _ := fixStartInputAndOutput(t)
+
+		wf := fixFinishedArgoWorkflow(t, input.RunnerCtx.Name, input.RunnerCtx.Platform.Namespace)
+		fakeCli := fake.NewSimpleClientset(&wf)
+
+		r := NewRunner(fakeCli)
+
+		// when
+		out, err := r.Start(context.Background(), input)
+
+		// then
+		assert.EqualError(t, err, `Argo Workflow "Rocket" already exists and it is not managed by argo-runner`)
+		assert.Nil(t, out)
+	})
+
+	t.Run("Should return error when Argo Workflow owned by a different resource already exits", func(t *testing.T) {
 		// given
 		input, _ := fixStartInputAndOutput(t)
+		input.RunnerCtx.Platform.OwnerRef = metav1.OwnerReference{UID: "action-uid"}
 
 		wf := fixFinishedArgoWorkflow(t, input.RunnerCtx.Name, input.RunnerCtx.Platform.Namespace)
+		wf.Labels = map[string]string{wfManagedByLabelKey: runnerName}
+		wf.OwnerReferences = []metav1.OwnerReference{{UID: "other-uid"}}
 		fakeCli := fake.NewSimpleClientset(&wf)
 
 		r := NewRunner(fakeCli)
@@ -94,7 +164,7 @@ func TestRunnerStartFailure(t *testing.T) {
 		out, err := r.Start(context.Background(), input)
 
 		// then
-		assert.EqualError(t, err, `while creating Argo Workflow: workflows.argoproj.io "Rocket" already exists`)
+		assert.EqualError(t, err, `Argo Workflow "Rocket" already exists and it is owned by a different resource`)
 		assert.Nil(t, out)
 	})
 
@@ -3,10 +3,6 @@ package cloudsql
 import (
 	"context"
 	"fmt"
-	"html/template"
-	"io/ioutil"
-	"os"
-	"path/filepath"
 	"time"
 
 	"capact.io/capact/pkg/runner"
@@ -16,16 +12,18 @@ import (
 	"github.com/sethvargo/go-password/password"
 	"go.uber.org/zap"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
-	"sigs.k8s.io/yaml"
 )
 
 type createAction struct {
 	logger          *zap.Logger
+	progress        runner.ProgressReportFunc
 	sqladminService *sqladmin.Service
 	gcpProjectName  string
 	args            *Args
 	dbInstance      *sqladmin.DatabaseInstance
 	outputCfg       OutputConfig
+	// operationName holds the name of the GCP operation, which creates the database instance.
+	operationName string
 }
 
 func (a *createAction) Start(_ context.Context, in *runner.StartInput) (*runner.StartOutput, error) {
@@ -36,6 +34,10 @@ func (a *createAction) Start(_ context.Context, in *runner.StartInput) (*runner.
 		return nil, errors.Wrap(err, "while preparing create database instance parameters")
 	}
 
+	if _, err := engineFor(a.dbInstance.DatabaseVersion); err != nil {
+		return nil, err
+	}
+
 	a.logger = a.logger.With(zap.String("instanceName", a.dbInstance.Name))
 	a.logger.Info("creating database")
 
@@ -59,15 +61,21 @@ func (a *createAction) WaitForCompletion(ctx context.Context, _ runner.WaitForCo
 
 	a.logger.Info("database ready")
 
+	eng, err := engineFor(createdDb.DatabaseVersion)
+	if err != nil {
+		return nil, err
+	}
+
 	output := &createOutputValues{
 		DBInstance:    createdDb,
-		Port:          PostgresPort,
-		DefaultDBName: PostgresDefaultDBName,
-		Username:      PostgresRootUser,
+		Port:          eng.Port,
+		DefaultDBName: eng.DefaultDBName,
+		Username:      eng.RootUser,
 		Password:      a.dbInstance.RootPassword,
 	}
 
-	if err := a.createOutputFiles(a.outputCfg, &a.args.Output, output); err != nil {
+	writer := &outputWriter{logger: a.logger, cfg: a.outputCfg}
+	if err := writer.createOutputFiles(&a.args.Output, eng, output); err != nil {
 		return nil, errors.Wrap(err, "while writing output")
 	}
 
@@ -99,14 +107,19 @@ func (a *createAction) prepareCreateDatabaseInstanceParameters(runnerCtx *runner
 }
 
 func (a *createAction) createDatabaseInstance(instance *sqladmin.DatabaseInstance) error {
-	_, err := a.sqladminService.Instances.Insert(a.gcpProjectName, instance).Do()
-	return err
+	op, err := a.sqladminService.Instances.Insert(a.gcpProjectName, instance).Do()
+	if err != nil {
+		return err
+	}
+
+	a.operationName = op.Name
+	return nil
 }
 
 func (a *createAction) waitForDatabaseInstanceRunning(ctx context.Context, instanceName string) (*sqladmin.DatabaseInstance, error) {
 	for {
 		select {
-		case <-time.After(createWaitDelay):
+		case <-time.After(operationPollInterval):
 			a.logger.Debug("checking db instance status")
 			db, err := a.getDatabaseInstance(instanceName)
 			if err != nil {
@@ -116,79 +129,34 @@ func (a *createAction) waitForDatabaseInstanceRunning(ctx context.Context, insta
 			if db.State == "RUNNABLE" {
 				return db, nil
 			}
+
+			a.reportProgress(db.State)
 		case <-ctx.Done():
 			return nil, ErrInstanceCreateTimeout
 		}
 	}
 }
 
-func (a *createAction) getDatabaseInstance(name string) (*sqladmin.DatabaseInstance, error) {
-	return a.sqladminService.Instances.Get(a.gcpProjectName, name).Do()
-}
-
-func (a *createAction) createOutputFiles(cfg OutputConfig, args *OutputArgs, values *createOutputValues) error {
-	if err := a.createCloudSQLInstanceOutputFile(cfg.CloudSQLInstanceFilePath, values); err != nil {
-		return errors.Wrap(err, "while creating default artifact")
-	}
-
-	if args.GoTemplate == nil {
-		return nil
-	}
-
-	if err := a.createAdditionalOutputFile(cfg.AdditionalFilePath, args, values); err != nil {
-		return errors.Wrap(err, "while creating additional artifact")
-	}
-
-	return nil
-}
-
-func (a *createAction) createCloudSQLInstanceOutputFile(path string, output *createOutputValues) error {
-	artifact := &cloudSQLOutput{
-		Name:            output.DBInstance.Name,
-		Project:         output.DBInstance.Project,
-		Region:          output.DBInstance.Region,
-		DatabaseVersion: output.DBInstance.DatabaseVersion,
-	}
-
-	data, err := yaml.Marshal(artifact)
-	if err != nil {
-		return errors.Wrap(err, "while marshaling artifact to YAML")
-	}
+// reportProgress reports the database instance state together with the status of the create operation.
+// Errors are only logged, as the progress is informative only.
+func (a *createAction) reportProgress(instanceState string) {
+	msg := fmt.Sprintf("Database instance state: %s", instanceState)
 
-	if err := ioutil.WriteFile(path, data, artifactsFileMode); err != nil {
-		return errors.Wrapf(err, "while writing artifact file %s", path)
+	if a.operationName != "" {
+		op, err := a.sqladminService.Operations.Get(a.gcpProjectName, a.operationName).Do()
+		if err != nil {
+			a.logger.Debug("Cannot get create operation", zap.Error(err))
+		} else {
+			msg = fmt.Sprintf("%s, %s operation: %s", msg, op.OperationType, op.Status)
+		}
 	}
 
-	return nil
+	a.progress.Report(runner.Progress{
+		Phase:   string(CreateCommandType),
+		Message: msg,
+	})
 }
 
-func (a *createAction) createAdditionalOutputFile(path string, args *OutputArgs, values *createOutputValues) error {
-	// yaml.Unmarshal converts YAML to JSON then uses JSON to unmarshal into an object
-	// but the GoTemplate is defined via YAML, so we need to revert that change
-	artifactTemplate, err := yaml.JSONToYAML(args.GoTemplate)
-	if err != nil {
-		return errors.Wrap(err, "while converting GoTemplate property from JSON to YAML")
-	}
-
-	tmpl, err := template.New("output").Parse(string(artifactTemplate))
-	if err != nil {
-		return errors.Wrap(err, "failed to load template")
-	}
-
-	fd, err := os.Create(filepath.Clean(path))
-	if err != nil {
-		return errors.Wrap(err, "cannot open output file to write")
-	}
-	defer func() {
-		if err := fd.Close(); err != nil {
-			a.logger.Error("failed to close output file descriptor", zap.Error(err))
-		}
-	}()
-
-	err = tmpl.Execute(fd, values)
-	if err != nil {
-		return errors.Wrap(err, "failed to render output file")
-	}
-
-	return nil
+func (a *createAction) getDatabaseInstance(name string) (*sqladmin.DatabaseInstance, error) {
+	return a.sqladminService.Instances.Get(a.gcpProjectName, name).Do()
 }
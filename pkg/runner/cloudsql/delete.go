@@ -0,0 +1,84 @@
+package cloudsql
+
+import (
+	"context"
+	"fmt"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+type deleteAction struct {
+	logger          *zap.Logger
+	progress        runner.ProgressReportFunc
+	sqladminService *sqladmin.Service
+	gcpProjectName  string
+	instanceTIPath  string
+	outputCfg       OutputConfig
+
+	instance  *cloudSQLOutput
+	operation *sqladmin.Operation
+}
+
+// Start deletes the CloudSQL instance described by the instance TypeInstance.
+// In the dry-run mode, the instance is not deleted.
+func (a *deleteAction) Start(_ context.Context, in *runner.StartInput) (*runner.StartOutput, error) {
+	var err error
+
+	a.instance, err = loadInstanceTypeInstance(a.instanceTIPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading CloudSQL instance")
+	}
+	if a.instance.Project == "" {
+		a.instance.Project = a.gcpProjectName
+	}
+
+	a.logger = a.logger.With(zap.String("instanceName", a.instance.Name))
+
+	if in.RunnerCtx.DryRun {
+		a.logger.Info("dry run: skipping database deletion")
+		return &runner.StartOutput{
+			Status: "Dry run: skipping database instance deletion",
+		}, nil
+	}
+
+	a.logger.Info("deleting database")
+	a.operation, err = a.sqladminService.Instances.Delete(a.instance.Project, a.instance.Name).Do()
+	if err != nil {
+		return nil, errors.Wrap(err, "while deleting database instance")
+	}
+
+	return &runner.StartOutput{
+		Status: "Deleting database instance",
+	}, nil
+}
+
+// WaitForCompletion waits until the delete operation is done and emits the instance TypeInstance with the `Deleted` flag set,
+// so the Action workflow can decide what to do with it.
+func (a *deleteAction) WaitForCompletion(ctx context.Context, _ runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	msg := fmt.Sprintf("Dry run of database %s deletion", a.instance.Name)
+
+	if a.operation != nil {
+		a.logger.Info("waiting for database to be deleted")
+		waiter := &operationWaiter{logger: a.logger, progress: a.progress, sqladminService: a.sqladminService}
+		if err := waiter.waitForOperationDone(ctx, a.instance.Project, a.operation, DeleteCommandType); err != nil {
+			return nil, errors.Wrap(err, "while waiting for database to be deleted")
+		}
+
+		a.instance.Deleted = true
+		msg = fmt.Sprintf("Delete database %s", a.instance.Name)
+	}
+
+	writer := &outputWriter{logger: a.logger, cfg: a.outputCfg}
+	if err := writer.createCloudSQLInstanceOutputFile(a.instance); err != nil {
+		return nil, errors.Wrap(err, "while writing output")
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   msg,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package cloudsql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// engine holds database engine specific defaults.
+type engine struct {
+	Port          int
+	DefaultDBName string
+	RootUser      string
+	// DefaultOutputTemplate is used to render the additional output, if the Go template is not provided in the arguments.
+	// The host is empty, if the instance has no IP address assigned.
+	DefaultOutputTemplate string
+}
+
+var (
+	postgresEngine = engine{
+		Port:          PostgresPort,
+		DefaultDBName: PostgresDefaultDBName,
+		RootUser:      PostgresRootUser,
+		DefaultOutputTemplate: `host: "{{ with .DBInstance.IpAddresses }}{{ (index . 0).IpAddress }}{{ end }}"
+port: {{ .Port }}
+defaultDBName: "{{ .DefaultDBName }}"
+superuser:
+  username: "{{ .Username }}"
+  password: "{{ .Password }}"
+`,
+	}
+
+	mysqlEngine = engine{
+		Port:          MySQLPort,
+		DefaultDBName: MySQLDefaultDBName,
+		RootUser:      MySQLRootUser,
+		DefaultOutputTemplate: `host: "{{ with .DBInstance.IpAddresses }}{{ (index . 0).IpAddress }}{{ end }}"
+port: {{ .Port }}
+superuser:
+  username: "{{ .Username }}"
+  password: "{{ .Password }}"
+`,
+	}
+)
+
+// engineFor returns the engine defaults for a given CloudSQL database version, e.g. `POSTGRES_11` or `MYSQL_8_0`.
+// PostgreSQL is used if the database version is not specified, as it is the default engine of the runner.
+func engineFor(databaseVersion string) (engine, error) {
+	switch {
+	case databaseVersion == "", strings.HasPrefix(databaseVersion, "POSTGRES"):
+		return postgresEngine, nil
+	case strings.HasPrefix(databaseVersion, "MYSQL"):
+		return mysqlEngine, nil
+	default:
+		return engine{}, fmt.Errorf("unsupported database version %q", databaseVersion)
+	}
+}
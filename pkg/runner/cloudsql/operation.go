@@ -0,0 +1,87 @@
+package cloudsql
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	"sigs.k8s.io/yaml"
+)
+
+const operationDoneStatus = "DONE"
+
+// operationWaiter waits for the long-running CloudSQL operations.
+type operationWaiter struct {
+	logger          *zap.Logger
+	progress        runner.ProgressReportFunc
+	sqladminService *sqladmin.Service
+}
+
+// waitForOperationDone blocks until a given operation is done and returns error if the operation failed.
+func (w *operationWaiter) waitForOperationDone(ctx context.Context, project string, op *sqladmin.Operation, phase CommandType) error {
+	name := op.Name
+	for {
+		if op.Status == operationDoneStatus {
+			return operationError(op)
+		}
+
+		w.progress.Report(runner.Progress{
+			Phase:   string(phase),
+			Message: fmt.Sprintf("%s operation: %s", op.OperationType, op.Status),
+		})
+
+		select {
+		case <-time.After(operationPollInterval):
+			w.logger.Debug("checking operation status", zap.String("operation", name))
+			var err error
+			op, err = w.sqladminService.Operations.Get(project, name).Context(ctx).Do()
+			if err != nil {
+				return errors.Wrapf(err, "while getting operation %q", name)
+			}
+		case <-ctx.Done():
+			return ErrOperationTimeout
+		}
+	}
+}
+
+func operationError(op *sqladmin.Operation) error {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return nil
+	}
+
+	var msgs []string
+	for _, e := range op.Error.Errors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", e.Code, e.Message))
+	}
+	return fmt.Errorf("%s operation failed: %s", op.OperationType, strings.Join(msgs, ", "))
+}
+
+func loadInstanceTypeInstance(path string) (*cloudSQLOutput, error) {
+	if path == "" {
+		return nil, ErrMissingInstanceTypeInstance
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading CloudSQL instance TypeInstance file %s", path)
+	}
+
+	instance := &cloudSQLOutput{}
+	if err := yaml.Unmarshal(data, instance); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling CloudSQL instance TypeInstance")
+	}
+
+	if instance.Name == "" {
+		return nil, errors.New("CloudSQL instance TypeInstance must have name")
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,90 @@
+package cloudsql
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	"sigs.k8s.io/yaml"
+)
+
+// outputWriter writes the CloudSQL runner output artifacts.
+type outputWriter struct {
+	logger *zap.Logger
+	cfg    OutputConfig
+}
+
+func (w *outputWriter) createOutputFiles(args *OutputArgs, eng engine, values *createOutputValues) error {
+	if err := w.createCloudSQLInstanceOutputFile(instanceOutputFor(values.DBInstance)); err != nil {
+		return errors.Wrap(err, "while creating default artifact")
+	}
+
+	if err := w.createAdditionalOutputFile(args, eng, values); err != nil {
+		return errors.Wrap(err, "while creating additional artifact")
+	}
+
+	return nil
+}
+
+func instanceOutputFor(instance *sqladmin.DatabaseInstance) *cloudSQLOutput {
+	return &cloudSQLOutput{
+		Name:            instance.Name,
+		Project:         instance.Project,
+		Region:          instance.Region,
+		DatabaseVersion: instance.DatabaseVersion,
+	}
+}
+
+func (w *outputWriter) createCloudSQLInstanceOutputFile(artifact *cloudSQLOutput) error {
+	data, err := yaml.Marshal(artifact)
+	if err != nil {
+		return errors.Wrap(err, "while marshaling artifact to YAML")
+	}
+
+	path := w.cfg.CloudSQLInstanceFilePath
+	if err := ioutil.WriteFile(path, data, artifactsFileMode); err != nil {
+		return errors.Wrapf(err, "while writing artifact file %s", path)
+	}
+
+	return nil
+}
+
+func (w *outputWriter) createAdditionalOutputFile(args *OutputArgs, eng engine, values *createOutputValues) error {
+	artifactTemplate := []byte(eng.DefaultOutputTemplate)
+	if args.GoTemplate != nil {
+		// yaml.Unmarshal converts YAML to JSON then uses JSON to unmarshal into an object
+		// but the GoTemplate is defined via YAML, so we need to revert that change
+		var err error
+		artifactTemplate, err = yaml.JSONToYAML(args.GoTemplate)
+		if err != nil {
+			return errors.Wrap(err, "while converting GoTemplate property from JSON to YAML")
+		}
+	}
+
+	tmpl, err := template.New("output").Parse(string(artifactTemplate))
+	if err != nil {
+		return errors.Wrap(err, "failed to load template")
+	}
+
+	path := w.cfg.AdditionalFilePath
+	fd, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrap(err, "cannot open output file to write")
+	}
+	defer func() {
+		if err := fd.Close(); err != nil {
+			w.logger.Error("failed to close output file descriptor", zap.Error(err))
+		}
+	}()
+
+	err = tmpl.Execute(fd, values)
+	if err != nil {
+		return errors.Wrap(err, "failed to render output file")
+	}
+
+	return nil
+}
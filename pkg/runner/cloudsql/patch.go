@@ -0,0 +1,115 @@
+package cloudsql
+
+import (
+	"context"
+	"fmt"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+type patchAction struct {
+	logger          *zap.Logger
+	progress        runner.ProgressReportFunc
+	sqladminService *sqladmin.Service
+	gcpProjectName  string
+	instanceTIPath  string
+	args            *Args
+	outputCfg       OutputConfig
+
+	instance  *cloudSQLOutput
+	operation *sqladmin.Operation
+}
+
+// Start updates the CloudSQL instance described by the instance TypeInstance.
+// Only the fields set in the `instance` argument are changed.
+func (a *patchAction) Start(_ context.Context, in *runner.StartInput) (*runner.StartOutput, error) {
+	var err error
+
+	a.instance, err = loadInstanceTypeInstance(a.instanceTIPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading CloudSQL instance")
+	}
+	if a.instance.Project == "" {
+		a.instance.Project = a.gcpProjectName
+	}
+
+	// the root password is used only when the instance is created, so the CloudSQL API ignores it for updates
+	if a.args.Instance.RootPassword != "" {
+		return nil, errors.New("root password cannot be updated with the patch command")
+	}
+
+	a.logger = a.logger.With(zap.String("instanceName", a.instance.Name))
+
+	if in.RunnerCtx.DryRun {
+		a.logger.Info("dry run: skipping database update")
+		return &runner.StartOutput{
+			Status: "Dry run: skipping database instance update",
+		}, nil
+	}
+
+	patch := a.args.Instance
+	patch.Name = a.instance.Name
+	patch.Project = a.instance.Project
+
+	a.logger.Info("updating database")
+	a.operation, err = a.sqladminService.Instances.Patch(a.instance.Project, a.instance.Name, &patch).Do()
+	if err != nil {
+		return nil, errors.Wrap(err, "while updating database instance")
+	}
+
+	return &runner.StartOutput{
+		Status: "Updating database instance",
+	}, nil
+}
+
+// WaitForCompletion waits until the patch operation is done and produces the output artifacts from the updated instance.
+func (a *patchAction) WaitForCompletion(ctx context.Context, _ runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	msg := fmt.Sprintf("Dry run of database %s update", a.instance.Name)
+
+	if a.operation != nil {
+		a.logger.Info("waiting for database to be updated")
+		waiter := &operationWaiter{logger: a.logger, progress: a.progress, sqladminService: a.sqladminService}
+		if err := waiter.waitForOperationDone(ctx, a.instance.Project, a.operation, PatchCommandType); err != nil {
+			return nil, errors.Wrap(err, "while waiting for database to be updated")
+		}
+		msg = fmt.Sprintf("Update database %s", a.instance.Name)
+	}
+
+	updatedDb, err := a.sqladminService.Instances.Get(a.instance.Project, a.instance.Name).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting DB instance")
+	}
+
+	eng, err := engineFor(updatedDb.DatabaseVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &outputWriter{logger: a.logger, cfg: a.outputCfg}
+	if err := writer.createCloudSQLInstanceOutputFile(instanceOutputFor(updatedDb)); err != nil {
+		return nil, errors.Wrap(err, "while writing output")
+	}
+
+	// the root password is not returned by the CloudSQL API, so the default
+	// additional output is not rendered, as it would contain an empty password
+	if a.args.Output.GoTemplate != nil {
+		output := &createOutputValues{
+			DBInstance:    updatedDb,
+			Port:          eng.Port,
+			DefaultDBName: eng.DefaultDBName,
+			Username:      eng.RootUser,
+		}
+		if err := writer.createAdditionalOutputFile(&a.args.Output, eng, output); err != nil {
+			return nil, errors.Wrap(err, "while writing additional output")
+		}
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   msg,
+	}, nil
+}
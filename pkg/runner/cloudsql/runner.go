@@ -16,19 +16,22 @@ type runnerAction interface {
 	WaitForCompletion(ctx context.Context, in runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error)
 }
 
+var _ runner.AsyncRunner = &Runner{}
+
 // Runner provides functionality to run and wait for GCP CloudSQL operations.
 type Runner struct {
 	logger          *zap.Logger
+	progress        runner.ProgressReportFunc
 	sqladminService *sqladmin.Service
 	gcpProjectName  string
 	action          runnerAction
-	outputCfg       OutputConfig
+	cfg             Config
 }
 
 // NewRunner returns new instance of CloudSQL runner.
-func NewRunner(cfg OutputConfig, sqladminService *sqladmin.Service, gcpProjectName string) *Runner {
+func NewRunner(cfg Config, sqladminService *sqladmin.Service, gcpProjectName string) *Runner {
 	return &Runner{
-		outputCfg:       cfg,
+		cfg:             cfg,
 		logger:          &zap.Logger{},
 		sqladminService: sqladminService,
 		gcpProjectName:  gcpProjectName,
@@ -40,6 +43,11 @@ func (r *Runner) InjectLogger(logger *zap.Logger) {
 	r.logger = logger
 }
 
+// InjectProgressReporter sets the progress reporting function on the runner.
+func (r *Runner) InjectProgressReporter(fn runner.ProgressReportFunc) {
+	r.progress = fn
+}
+
 // Name returns the name of the runner.
 func (r *Runner) Name() string {
 	return "cloudsql"
@@ -58,10 +66,30 @@ func (r *Runner) Start(ctx context.Context, in runner.StartInput) (*runner.Start
 	case CreateCommandType:
 		r.action = &createAction{
 			logger:          r.logger,
+			progress:        r.progress,
+			gcpProjectName:  r.gcpProjectName,
+			sqladminService: r.sqladminService,
+			args:            args,
+			outputCfg:       r.cfg.Output,
+		}
+	case PatchCommandType:
+		r.action = &patchAction{
+			logger:          r.logger,
+			progress:        r.progress,
 			gcpProjectName:  r.gcpProjectName,
 			sqladminService: r.sqladminService,
+			instanceTIPath:  r.cfg.InstanceTypeInstanceFilepath,
 			args:            args,
-			outputCfg:       r.outputCfg,
+			outputCfg:       r.cfg.Output,
+		}
+	case DeleteCommandType:
+		r.action = &deleteAction{
+			logger:          r.logger,
+			progress:        r.progress,
+			gcpProjectName:  r.gcpProjectName,
+			sqladminService: r.sqladminService,
+			instanceTIPath:  r.cfg.InstanceTypeInstanceFilepath,
+			outputCfg:       r.cfg.Output,
 		}
 	default:
 		return nil, ErrUnknownCommand
@@ -0,0 +1,382 @@
+package cloudsql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/api/option"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	"sigs.k8s.io/yaml"
+)
+
+const fakeProject = "capact-test"
+
+func TestRunnerCreate(t *testing.T) {
+	tests := map[string]struct {
+		args          string
+		expAdditional string
+	}{
+		"Should create MySQL instance with default output": {
+			args: `
+command: create
+instance:
+  name: mysql-db
+  databaseVersion: MYSQL_8_0
+  rootPassword: s3cr3t
+`,
+			expAdditional: `host: "10.0.0.1"
+port: 3306
+superuser:
+  username: "root"
+  password: "s3cr3t"
+`,
+		},
+		"Should create PostgreSQL instance with output from template": {
+			args: `
+command: create
+instance:
+  name: postgres-db
+  databaseVersion: POSTGRES_11
+  rootPassword: s3cr3t
+output:
+  goTemplate:
+    port: "{{ .Port }}"
+    defaultDBName: "{{ .DefaultDBName }}"
+    username: "{{ .Username }}"
+`,
+			expAdditional: `defaultDBName: postgres
+port: "5432"
+username: postgres
+`,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			fake := newFakeSQLAdmin()
+			r, cfg := fixRunner(t, fake, "")
+
+			// when
+			succeeded := runFixRunner(t, r, tc.args, false)
+
+			// then
+			assert.True(t, succeeded)
+			assert.Len(t, fake.instances, 1)
+
+			additional, err := os.ReadFile(cfg.Output.AdditionalFilePath)
+			require.NoError(t, err)
+			assert.YAMLEq(t, tc.expAdditional, string(additional))
+		})
+	}
+}
+
+func TestRunnerPatch(t *testing.T) {
+	// given
+	fake := newFakeSQLAdmin()
+	fake.instances["mysql-db"] = fixDatabaseInstance("mysql-db", "MYSQL_8_0", "db-g1-small")
+	r, cfg := fixRunner(t, fake, fixInstanceTypeInstance(t, "mysql-db"))
+
+	// when
+	succeeded := runFixRunner(t, r, `
+command: patch
+instance:
+  settings:
+    tier: db-n1-standard-1
+`, false)
+
+	// then
+	assert.True(t, succeeded)
+	assert.Equal(t, "db-n1-standard-1", fake.instances["mysql-db"].Settings.Tier)
+
+	gotInstance := readInstanceOutput(t, cfg)
+	assert.Equal(t, "mysql-db", gotInstance.Name)
+	assert.Equal(t, "MYSQL_8_0", gotInstance.DatabaseVersion)
+	assert.False(t, gotInstance.Deleted)
+
+	_, err := os.Stat(cfg.Output.AdditionalFilePath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRunnerPatchRootPassword(t *testing.T) {
+	// given
+	fake := newFakeSQLAdmin()
+	fake.instances["mysql-db"] = fixDatabaseInstance("mysql-db", "MYSQL_8_0", "db-g1-small")
+	r, _ := fixRunner(t, fake, fixInstanceTypeInstance(t, "mysql-db"))
+
+	// when
+	_, err := r.Start(context.Background(), runner.StartInput{
+		RunnerCtx: runner.Context{Name: "cloudsql-test"},
+		Args: json.RawMessage(`
+command: patch
+instance:
+  rootPassword: s3cr3t
+`),
+	})
+
+	// then
+	assert.EqualError(t, err, "root password cannot be updated with the patch command")
+	assert.Equal(t, "db-g1-small", fake.instances["mysql-db"].Settings.Tier)
+}
+
+func TestRunnerDelete(t *testing.T) {
+	tests := map[string]struct {
+		dryRun     bool
+		expDeleted bool
+	}{
+		"Should delete instance": {
+			expDeleted: true,
+		},
+		"Should not delete instance in dry run mode": {
+			dryRun:     true,
+			expDeleted: false,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			fake := newFakeSQLAdmin()
+			fake.instances["postgres-db"] = fixDatabaseInstance("postgres-db", "POSTGRES_11", "db-g1-small")
+			r, cfg := fixRunner(t, fake, fixInstanceTypeInstance(t, "postgres-db"))
+
+			// when
+			succeeded := runFixRunner(t, r, `command: delete`, tc.dryRun)
+
+			// then
+			assert.True(t, succeeded)
+			_, exists := fake.instances["postgres-db"]
+			assert.Equal(t, tc.expDeleted, !exists)
+
+			gotInstance := readInstanceOutput(t, cfg)
+			assert.Equal(t, "postgres-db", gotInstance.Name)
+			assert.Equal(t, tc.expDeleted, gotInstance.Deleted)
+		})
+	}
+}
+
+func TestRunnerDeleteWithoutInstanceTypeInstance(t *testing.T) {
+	// given
+	r, _ := fixRunner(t, newFakeSQLAdmin(), "")
+
+	// when
+	_, err := r.Start(context.Background(), runner.StartInput{Args: json.RawMessage(`{"command": "delete"}`)})
+
+	// then
+	assert.ErrorIs(t, err, ErrMissingInstanceTypeInstance)
+}
+
+func TestOutputWriter(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	w := &outputWriter{
+		logger: zap.NewNop(),
+		cfg: OutputConfig{
+			CloudSQLInstanceFilePath: filepath.Join(dir, "cloudSQLInstance.yaml"),
+			AdditionalFilePath:       filepath.Join(dir, "additional.yaml"),
+		},
+	}
+	instance := fixDatabaseInstance("postgres-db", "POSTGRES_11", "db-g1-small")
+	instance.IpAddresses = nil
+	values := &createOutputValues{
+		DBInstance:    instance,
+		Port:          PostgresPort,
+		DefaultDBName: PostgresDefaultDBName,
+		Username:      PostgresRootUser,
+		Password:      "p<a>s&s",
+	}
+
+	// when
+	err := w.createOutputFiles(&OutputArgs{}, postgresEngine, values)
+
+	// then
+	require.NoError(t, err)
+
+	gotInstance, err := os.ReadFile(w.cfg.CloudSQLInstanceFilePath)
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+Name: postgres-db
+Project: capact-test
+Region: us-central
+DatabaseVersion: POSTGRES_11
+`, string(gotInstance))
+
+	gotAdditional, err := os.ReadFile(w.cfg.AdditionalFilePath)
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+host: ""
+port: 5432
+defaultDBName: "postgres"
+superuser:
+  username: "postgres"
+  password: "p<a>s&s"
+`, string(gotAdditional))
+}
+
+func fixRunner(t *testing.T, fake *fakeSQLAdmin, instanceTIPath string) (*Runner, Config) {
+	t.Helper()
+
+	oldInterval := operationPollInterval
+	operationPollInterval = time.Millisecond
+	t.Cleanup(func() { operationPollInterval = oldInterval })
+
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	svc, err := sqladmin.NewService(context.Background(), option.WithEndpoint(srv.URL+"/"), option.WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	cfg := Config{
+		InstanceTypeInstanceFilepath: instanceTIPath,
+		Output: OutputConfig{
+			CloudSQLInstanceFilePath: filepath.Join(dir, "cloudSQLInstance.yaml"),
+			AdditionalFilePath:       filepath.Join(dir, "additional.yaml"),
+		},
+	}
+
+	r := NewRunner(cfg, svc, fakeProject)
+	r.InjectLogger(zap.NewNop())
+
+	return r, cfg
+}
+
+func runFixRunner(t *testing.T, r *Runner, args string, dryRun bool) bool {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	runnerCtx := runner.Context{Name: "cloudsql-test", DryRun: dryRun}
+	_, err := r.Start(ctx, runner.StartInput{RunnerCtx: runnerCtx, Args: json.RawMessage(args)})
+	require.NoError(t, err)
+
+	out, err := r.WaitForCompletion(ctx, runner.WaitForCompletionInput{RunnerCtx: runnerCtx})
+	require.NoError(t, err)
+
+	return out.Succeeded
+}
+
+func fixDatabaseInstance(name, version, tier string) *sqladmin.DatabaseInstance {
+	return &sqladmin.DatabaseInstance{
+		Name:            name,
+		Project:         fakeProject,
+		Region:          "us-central",
+		DatabaseVersion: version,
+		State:           "RUNNABLE",
+		IpAddresses:     []*sqladmin.IpMapping{{IpAddress: "10.0.0.1"}},
+		Settings:        &sqladmin.Settings{Tier: tier},
+	}
+}
+
+func fixInstanceTypeInstance(t *testing.T, name string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "instance.yaml")
+	data := fmt.Sprintf("Name: %s\nProject: %s\nRegion: us-central\n", name, fakeProject)
+	require.NoError(t, os.WriteFile(path, []byte(data), artifactsFileMode))
+
+	return path
+}
+
+func readInstanceOutput(t *testing.T, cfg Config) cloudSQLOutput {
+	t.Helper()
+
+	data, err := os.ReadFile(cfg.Output.CloudSQLInstanceFilePath)
+	require.NoError(t, err)
+
+	var out cloudSQLOutput
+	require.NoError(t, yaml.Unmarshal(data, &out))
+	return out
+}
+
+// fakeSQLAdmin is a minimal in-memory implementation of the CloudSQL Admin API.
+// All operations are reported as running, until they are polled.
+type fakeSQLAdmin struct {
+	mu        sync.Mutex
+	instances map[string]*sqladmin.DatabaseInstance
+}
+
+func newFakeSQLAdmin() *fakeSQLAdmin {
+	return &fakeSQLAdmin{
+		instances: map[string]*sqladmin.DatabaseInstance{},
+	}
+}
+
+func (f *fakeSQLAdmin) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(req.URL.Path, "/sql/v1beta4")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "projects" {
+		http.NotFound(w, req)
+		return
+	}
+
+	switch {
+	case parts[2] == "operations" && len(parts) == 4 && req.Method == http.MethodGet:
+		f.writeJSON(w, &sqladmin.Operation{Name: parts[3], Status: operationDoneStatus})
+	case parts[2] == "instances" && len(parts) == 3 && req.Method == http.MethodPost:
+		instance := &sqladmin.DatabaseInstance{}
+		if err := json.NewDecoder(req.Body).Decode(instance); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		created := fixDatabaseInstance(instance.Name, instance.DatabaseVersion, "")
+		created.Project = parts[1]
+		f.instances[instance.Name] = created
+		f.writeJSON(w, &sqladmin.Operation{Name: "create", OperationType: "CREATE", Status: "PENDING"})
+	case parts[2] == "instances" && len(parts) == 4:
+		f.serveInstance(w, req, parts[3])
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func (f *fakeSQLAdmin) serveInstance(w http.ResponseWriter, req *http.Request, name string) {
+	instance, found := f.instances[name]
+	if !found {
+		http.NotFound(w, req)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		f.writeJSON(w, instance)
+	case http.MethodPatch:
+		patch := &sqladmin.DatabaseInstance{}
+		if err := json.NewDecoder(req.Body).Decode(patch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if patch.Settings != nil {
+			instance.Settings = patch.Settings
+		}
+		f.writeJSON(w, &sqladmin.Operation{Name: "patch", OperationType: "UPDATE", Status: "RUNNING"})
+	case http.MethodDelete:
+		delete(f.instances, name)
+		f.writeJSON(w, &sqladmin.Operation{Name: "delete", OperationType: "DELETE", Status: "RUNNING"})
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeSQLAdmin) writeJSON(w http.ResponseWriter, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
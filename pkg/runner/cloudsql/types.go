@@ -10,33 +10,57 @@ import (
 )
 
 const (
-	// PostgresPort defines the CloudSQL DB port.
+	// PostgresPort defines the CloudSQL PostgreSQL DB port.
 	PostgresPort = 5432
-	// PostgresDefaultDBName defines the CloudSQL default database name.
+	// PostgresDefaultDBName defines the CloudSQL PostgreSQL default database name.
 	PostgresDefaultDBName = "postgres"
-	// PostgresRootUser defines the CloudSQL database instance root user name.
+	// PostgresRootUser defines the CloudSQL PostgreSQL database instance root user name.
 	PostgresRootUser = "postgres"
 
-	createWaitDelay = 10 * time.Second
+	// MySQLPort defines the CloudSQL MySQL DB port.
+	MySQLPort = 3306
+	// MySQLDefaultDBName defines the CloudSQL MySQL default database name.
+	MySQLDefaultDBName = "mysql"
+	// MySQLRootUser defines the CloudSQL MySQL database instance root user name.
+	MySQLRootUser = "root"
 
 	artifactsFileMode os.FileMode = 0644
 )
 
+// operationPollInterval defines how often the state of the CloudSQL instances and operations is checked.
+var operationPollInterval = 10 * time.Second
+
 // CommandType represents the operation type to be performed by the runner.
 type CommandType string
 
 const (
 	// CreateCommandType is an operation to create a new CloudSQL instance.
 	CreateCommandType = "create"
+	// PatchCommandType is an operation to update an existing CloudSQL instance.
+	PatchCommandType = "patch"
+	// DeleteCommandType is an operation to delete an existing CloudSQL instance.
+	DeleteCommandType = "delete"
 )
 
 var (
 	// ErrInstanceCreateTimeout indicates the operation timed out.
 	ErrInstanceCreateTimeout = errors.New("timed out waiting for DB instance to be ready")
+	// ErrOperationTimeout indicates the timeout while waiting for the CloudSQL operation to finish.
+	ErrOperationTimeout = errors.New("timed out waiting for CloudSQL operation to finish")
 	// ErrUnknownCommand indicates an unknown operation command.
 	ErrUnknownCommand = errors.New("unknown command")
+	// ErrMissingInstanceTypeInstance indicates that the CloudSQL instance TypeInstance is required, but not provided.
+	ErrMissingInstanceTypeInstance = errors.New("CloudSQL instance TypeInstance is required for this command")
 )
 
+// Config holds Runner related configuration.
+type Config struct {
+	// InstanceTypeInstanceFilepath is a path to the CloudSQL instance TypeInstance.
+	// It is required by the patch and delete commands.
+	InstanceTypeInstanceFilepath string `envconfig:"optional"`
+	Output                       OutputConfig
+}
+
 // OutputConfig stores the configuration for the CloudSQL runner output files.
 type OutputConfig struct {
 	CloudSQLInstanceFilePath string `envconfig:"default=/tmp/cloudSQLInstance.yaml"`
@@ -65,9 +89,14 @@ type createOutputValues struct {
 	Password      string `yaml:"password"`
 }
 
+// cloudSQLOutput describes the CloudSQL instance TypeInstance.
+// It is produced by the create command and consumed by the patch and delete commands.
+// The keys are kept the same as in the TypeInstances created by the previous runner versions.
 type cloudSQLOutput struct {
-	Name            string `yaml:"name"`
-	Project         string `yaml:"project"`
-	Region          string `yaml:"region"`
-	DatabaseVersion string `yaml:"databaseVersion"`
+	Name            string `json:"Name"`
+	Project         string `json:"Project"`
+	Region          string `json:"Region"`
+	DatabaseVersion string `json:"DatabaseVersion"`
+	// Deleted indicates that the CloudSQL instance was deleted.
+	Deleted bool `json:"Deleted,omitempty"`
 }
@@ -14,6 +14,9 @@ type Config struct {
 	ContextPath string
 	ArgsPath    string
 	Logger      logger.Config
+	// ShutdownGracePeriod is the time the runner has to return once the context is done, e.g. after SIGTERM.
+	// It should be shorter than the Pod termination grace period.
+	ShutdownGracePeriod time.Duration `envconfig:"default=20s"`
 }
 
 // InputData holds the input data for the runners.
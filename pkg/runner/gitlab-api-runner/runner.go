@@ -1,70 +1,51 @@
 package gitlabapi
 
 import (
-	"bytes"
 	"context"
-	"text/template"
+	"net/url"
+	"strings"
 
 	"capact.io/capact/pkg/runner"
+	httprunner "capact.io/capact/pkg/runner/http-runner"
 
 	"github.com/pkg/errors"
-	"github.com/xanzy/go-gitlab"
 	"go.uber.org/zap"
+	"golang.org/x/oauth2"
 	"sigs.k8s.io/yaml"
 )
 
-// RESTRunner provides functionality to execute REST API calls.
+var _ runner.SyncRunner = &RESTRunner{}
+
+// RESTRunner provides functionality to execute GitLab REST API calls.
+// It translates the GitLab specific arguments and executes them with the HTTP runner.
 type RESTRunner struct {
-	cfg Config
-	log *zap.Logger
+	http *httprunner.HTTPRunner
+	log  *zap.Logger
 }
 
-// Do sends an API request and renders the API response.
-func (r *RESTRunner) Do(ctx context.Context, in runner.StartInput) (*runner.WaitForCompletionOutput, error) {
-	input, err := r.readInputData(in)
-	if err != nil {
-		return nil, errors.Wrap(err, "while reading input data")
-	}
-
-	var opts []gitlab.ClientOptionFunc
-	if input.Args.BaseURL != "" {
-		opts = append(opts, gitlab.WithBaseURL(input.Args.BaseURL))
-	}
-
-	git, err := r.gitlabClientForAuth(input.Args.Auth, opts)
-	if err != nil {
-		return nil, errors.Wrap(err, "while creating GitLab API client")
-	}
-
-	req, err := git.NewRequest(input.Args.Method, input.Args.Path, input.Args.RequestBody, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "while creating request")
-	}
-
-	req.WithContext(ctx)
-
-	if input.Args.QueryParameters != nil {
-		req.URL.RawQuery = input.Args.QueryParameters.Encode()
+// NewRESTRunner returns new instance of GitLab REST API runner.
+func NewRESTRunner(cfg Config) *RESTRunner {
+	return &RESTRunner{
+		http: httprunner.NewHTTPRunner(cfg),
 	}
+}
 
-	var rawBody interface{}
-	_, err = git.Do(req, &rawBody)
-	if err != nil {
-		return nil, errors.Wrap(err, "while executing request")
+// Run sends an API request and renders the API response.
+func (r *RESTRunner) Run(ctx context.Context, in runner.RunInput) (*runner.WaitForCompletionOutput, error) {
+	var args Arguments
+	if err := yaml.Unmarshal(in.Args, &args); err != nil {
+		return nil, errors.Wrap(err, "while unmarshalling runner arguments")
 	}
 
-	artifact, err := r.renderOutput(input.Args.Output.GoTemplate, rawBody)
+	httpArgs, err := r.httpArguments(ctx, args)
 	if err != nil {
-		return nil, errors.Wrap(err, "while rendering additional data")
-	}
-
-	if err := r.saveOutput(artifact); err != nil {
 		return nil, err
 	}
 
-	return &runner.WaitForCompletionOutput{
-		Succeeded: true,
-	}, nil
+	return r.http.Execute(ctx, httprunner.Input{
+		Args: httpArgs,
+		Ctx:  in.RunnerCtx,
+	})
 }
 
 // Name returns the runner name.
@@ -75,76 +56,83 @@ func (r *RESTRunner) Name() string {
 // InjectLogger sets the logger on the runner.
 func (r *RESTRunner) InjectLogger(logger *zap.Logger) {
 	r.log = logger
+	r.http.InjectLogger(logger)
 }
 
-func (r *RESTRunner) saveOutput(data []byte) error {
-	if data == nil {
-		return nil
-	}
-
-	r.log.Debug("Saving additional output", zap.String("path", r.cfg.Output.AdditionalFilePath))
-	err := runner.SaveToFile(r.cfg.Output.AdditionalFilePath, data)
-	if err != nil {
-		return errors.Wrap(err, "while saving default output")
-	}
-
-	return nil
+// InjectProgressReporter sets the progress reporting function on the runner.
+func (r *RESTRunner) InjectProgressReporter(fn runner.ProgressReportFunc) {
+	r.http.InjectProgressReporter(fn)
 }
 
-func (r *RESTRunner) readInputData(in runner.StartInput) (Input, error) {
-	var args Arguments
-	err := yaml.Unmarshal(in.Args, &args)
+func (r *RESTRunner) httpArguments(ctx context.Context, args Arguments) (httprunner.Arguments, error) {
+	baseURL, err := apiBaseURL(args.BaseURL)
 	if err != nil {
-		return Input{}, errors.Wrap(err, "while unmarshalling runner arguments")
-	}
-
-	return Input{
-		Args: args,
-		Ctx:  in.RunnerCtx,
-	}, nil
+		return httprunner.Arguments{}, err
+	}
+
+	out := httprunner.DefaultArguments()
+	out.BaseURL = baseURL.String()
+	out.Path = strings.TrimPrefix(args.Path, "/")
+	out.QueryParameters = args.QueryParameters
+	out.Output.GoTemplate = args.Output.GoTemplate
+	if args.Method != "" {
+		out.Method = args.Method
+	}
+	if args.RequestBody != nil {
+		out.RequestBody = *args.RequestBody
+	}
+
+	auth := args.Auth
+	switch {
+	case auth.Token != nil && auth.Basic != nil:
+		return httprunner.Arguments{}, errors.New("both token and basic credentials must not be provided")
+	case auth.Token != nil:
+		out.Headers = map[string]string{privateTokenHeader: *auth.Token}
+	case auth.Basic != nil:
+		token, err := r.passwordCredentialsToken(ctx, baseURL, *auth.Basic)
+		if err != nil {
+			return httprunner.Arguments{}, err
+		}
+		out.Auth.Token = &token
+	default:
+		return httprunner.Arguments{}, errors.New("no token or basic credentials provided")
+	}
+
+	return out, nil
 }
 
-func (r *RESTRunner) gitlabClientForAuth(auth Auth, opts []gitlab.ClientOptionFunc) (*gitlab.Client, error) {
-	token := auth.Token
-	basic := auth.Basic
-
-	if token != nil && basic != nil {
-		return nil, errors.New("both token and basic credentials must not be provided")
-	}
-
-	// access token
-	if token != nil {
-		return gitlab.NewClient(
-			*token,
-			opts...,
-		)
+// passwordCredentialsToken exchanges the basic credentials for the OAuth2 access token,
+// as the GitLab REST API doesn't support the basic authentication.
+func (r *RESTRunner) passwordCredentialsToken(ctx context.Context, apiURL *url.URL, creds BasicAuth) (string, error) {
+	cfg := oauth2.Config{
+		Endpoint: oauth2.Endpoint{
+			TokenURL: strings.TrimSuffix(apiURL.String(), apiVersionPath) + "oauth/token",
+		},
 	}
 
-	// basic credentials
-	if basic != nil {
-		return gitlab.NewBasicAuthClient(
-			basic.Username,
-			basic.Password,
-			opts...,
-		)
+	token, err := cfg.PasswordCredentialsToken(ctx, creds.Username, creds.Password)
+	if err != nil {
+		return "", errors.Wrap(err, "while getting OAuth2 token for basic credentials")
 	}
-
-	return nil, errors.New("no token or basic credentials provided")
+	return token.AccessToken, nil
 }
 
-func (r *RESTRunner) renderOutput(artifactTemplate string, data interface{}) ([]byte, error) {
-	if artifactTemplate == "" {
-		return []byte{}, nil
+// apiBaseURL returns the GitLab REST API v4 URL for a given GitLab instance URL.
+func apiBaseURL(baseURL string) (*url.URL, error) {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
 	}
 
-	tmpl, err := template.New("output").Parse(artifactTemplate)
+	out, err := url.Parse(baseURL)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to load template")
+		return nil, errors.Wrap(err, "while parsing base URL")
 	}
 
-	var buff bytes.Buffer
-	if err := tmpl.Execute(&buff, data); err != nil {
-		return nil, errors.Wrap(err, "while rendering output")
+	if !strings.HasSuffix(out.Path, "/") {
+		out.Path += "/"
+	}
+	if !strings.HasSuffix(out.Path, apiVersionPath) {
+		out.Path += apiVersionPath
 	}
-	return buff.Bytes(), nil
+	return out, nil
 }
@@ -0,0 +1,122 @@
+package gitlabapi
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRESTRunnerRun(t *testing.T) {
+	tests := map[string]struct {
+		auth          string
+		expAuthHeader string
+		expAuthValue  string
+	}{
+		"Should send private token": {
+			auth: `
+  token: private-token`,
+			expAuthHeader: "PRIVATE-TOKEN",
+			expAuthValue:  "private-token",
+		},
+		"Should exchange basic credentials for OAuth2 token": {
+			auth: `
+  basic:
+    username: user
+    password: pass`,
+			expAuthHeader: "Authorization",
+			expAuthValue:  "Bearer oauth-token",
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/oauth/token" {
+					assert.NoError(t, r.ParseForm())
+					assert.Equal(t, "password", r.PostForm.Get("grant_type"))
+					assert.Equal(t, "user", r.PostForm.Get("username"))
+					assert.Equal(t, "pass", r.PostForm.Get("password"))
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"access_token": "oauth-token", "token_type": "bearer"}`))
+					return
+				}
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/v4/projects", r.URL.Path)
+				assert.Equal(t, tc.expAuthValue, r.Header.Get(tc.expAuthHeader))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id": 42}`))
+			}))
+			defer srv.Close()
+
+			args := fmt.Sprintf(`
+method: POST
+baseURL: %s
+path: /projects
+body:
+  name: my-project
+auth:%s
+output:
+  goTemplate: "id: {{ .id }}"
+`, srv.URL, tc.auth)
+
+			outputPath := path.Join(t.TempDir(), "additional.yaml")
+			cfg := Config{}
+			cfg.Output.AdditionalFilePath = outputPath
+
+			r := NewRESTRunner(cfg)
+			r.InjectLogger(zap.NewNop())
+
+			// when
+			out, err := r.Run(context.Background(), runner.RunInput{Args: []byte(args)})
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, out.Succeeded)
+
+			additional, err := ioutil.ReadFile(outputPath)
+			require.NoError(t, err)
+			assert.Equal(t, "id: 42", string(additional))
+		})
+	}
+}
+
+func TestAPIBaseURL(t *testing.T) {
+	tests := map[string]struct {
+		in  string
+		exp string
+	}{
+		"Should use gitlab.com by default": {
+			in:  "",
+			exp: "https://gitlab.com/api/v4/",
+		},
+		"Should append API path": {
+			in:  "https://gitlab.example.com",
+			exp: "https://gitlab.example.com/api/v4/",
+		},
+		"Should keep API path": {
+			in:  "https://gitlab.example.com/api/v4",
+			exp: "https://gitlab.example.com/api/v4/",
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			out, err := apiBaseURL(tc.in)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, out.String())
+		})
+	}
+}
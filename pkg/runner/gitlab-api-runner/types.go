@@ -3,22 +3,20 @@ package gitlabapi
 import (
 	"net/url"
 
-	"capact.io/capact/pkg/runner"
+	httprunner "capact.io/capact/pkg/runner/http-runner"
 )
 
-// Config holds RESTRunner related configuration.
-type Config struct {
-	Output struct {
-		// Extracting resource data from response body
-		AdditionalFilePath string `envconfig:"default=/tmp/additional.yaml"`
-	}
-}
+const (
+	// defaultBaseURL is the GitLab instance used, if the base URL is not provided.
+	defaultBaseURL = "https://gitlab.com/"
+	// apiVersionPath is appended to the base URL, as all GitLab REST API v4 paths are relative to it.
+	apiVersionPath = "api/v4/"
+	// privateTokenHeader is the header used by GitLab for personal, project and group access tokens.
+	privateTokenHeader = "PRIVATE-TOKEN"
+)
 
-// Input stores the input configuration for the runner.
-type Input struct {
-	Args Arguments
-	Ctx  runner.Context
-}
+// Config holds RESTRunner related configuration.
+type Config = httprunner.Config
 
 // Arguments stores the input arguments for the GitLab API runner operation.
 type Arguments struct {
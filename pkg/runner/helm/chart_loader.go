@@ -0,0 +1,138 @@
+package helm
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+// chartLoader locates and loads Helm charts from chart repositories and OCI registries.
+type chartLoader struct {
+	log                 *zap.Logger
+	repositoryCachePath string
+	creds               *RepositoryCredentials
+}
+
+func newChartLoader(log *zap.Logger, repositoryCachePath string, creds *RepositoryCredentials) *chartLoader {
+	return &chartLoader{
+		log:                 log,
+		repositoryCachePath: repositoryCachePath,
+		creds:               creds,
+	}
+}
+
+// Load locates and loads a given chart.
+// For chart repositories, the repository credentials are set on the provided chart path options.
+func (l *chartLoader) Load(opts *action.ChartPathOptions, chrt Chart) (*chart.Chart, error) {
+	if IsOCIChart(chrt) {
+		return l.loadFromOCIRegistry(chrt)
+	}
+
+	cleanup, err := l.setRepositoryCredentials(opts)
+	defer cleanup()
+	if err != nil {
+		return nil, errors.Wrap(err, "while setting repository credentials")
+	}
+
+	chartPath, err := opts.LocateChart(chrt.Name, &cli.EnvSettings{
+		RepositoryCache: l.repositoryCachePath,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while locating Helm chart")
+	}
+
+	chartData, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading Helm chart")
+	}
+
+	return chartData, nil
+}
+
+func (l *chartLoader) loadFromOCIRegistry(chrt Chart) (*chart.Chart, error) {
+	ref, err := parseOCIReference(chrt)
+	if err != nil {
+		return nil, err
+	}
+
+	registryCli, err := newOCIRegistryClient(l.creds)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating OCI registry client")
+	}
+
+	l.log.Debug("Pulling Helm chart from OCI registry",
+		zap.String("registry", ref.Registry),
+		zap.String("repository", ref.Repository),
+		zap.String("tag", ref.Tag),
+	)
+	archive, err := registryCli.PullChart(ref)
+	if err != nil {
+		return nil, errors.Wrap(err, "while pulling Helm chart from OCI registry")
+	}
+
+	chartData, err := loader.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading Helm chart")
+	}
+
+	return chartData, nil
+}
+
+// setRepositoryCredentials sets the credentials and TLS options on a given chart path options.
+// Helm expects TLS certificates as files, so they are saved as temporary files, which are removed by the returned cleanup function.
+func (l *chartLoader) setRepositoryCredentials(opts *action.ChartPathOptions) (func(), error) {
+	var files []string
+	cleanup := func() {
+		for _, file := range files {
+			if err := os.Remove(file); err != nil {
+				l.log.Warn("Cannot remove temporary file", zap.String("path", file), zap.Error(err))
+			}
+		}
+	}
+
+	if l.creds == nil {
+		return cleanup, nil
+	}
+
+	opts.Username = l.creds.Username
+	opts.Password = l.creds.Password
+	opts.InsecureSkipTLSverify = l.creds.InsecureSkipTLSVerify
+
+	for _, item := range []struct {
+		data string
+		dst  *string
+	}{
+		{data: l.creds.CABundle, dst: &opts.CaFile},
+		{data: l.creds.ClientCertificate, dst: &opts.CertFile},
+		{data: l.creds.ClientKey, dst: &opts.KeyFile},
+	} {
+		if item.data == "" {
+			continue
+		}
+
+		file, err := ioutil.TempFile("", "helm-repo-tls")
+		if err != nil {
+			return cleanup, errors.Wrap(err, "while creating temporary file")
+		}
+		files = append(files, file.Name())
+
+		_, err = file.WriteString(item.data)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return cleanup, errors.Wrap(err, "while writing temporary file")
+		}
+
+		*item.dst = file.Name()
+	}
+
+	return cleanup, nil
+}
@@ -73,6 +73,30 @@ func setKubeconfigEnvIfTypeInstanceExists(path string, log *zap.Logger) error {
 	return nil
 }
 
+func loadRepositoryCredentials(path string, log *zap.Logger) (*RepositoryCredentials, error) {
+	if path == "" {
+		log.Debug("optional repository credentials TI not specified")
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		log.Debug("optional repository credentials TI specified but file does not exist")
+		return nil, nil
+	default:
+		return nil, errors.Wrap(err, "while reading repository credentials TI")
+	}
+
+	var creds RepositoryCredentials
+	if err := yaml.Unmarshal(data, &creds); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling repository credentials TI")
+	}
+
+	return &creds, nil
+}
+
 func extractKubeconfigFromTI(path string) ([]byte, error) {
 	data, err := os.ReadFile(filepath.Clean(path))
 	if err != nil {
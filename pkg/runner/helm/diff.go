@@ -0,0 +1,226 @@
+package helm
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"sigs.k8s.io/yaml"
+)
+
+// sensitiveValue replaces the Secret data in the diff, so the dry-run output doesn't leak credentials.
+const sensitiveValue = "(sensitive value)"
+
+// ManifestDiff describes changes between the Kubernetes resources of the live Helm release and the rendered one.
+type ManifestDiff struct {
+	Added   []ResourceRef    `json:"added"`
+	Changed []ResourceChange `json:"changed"`
+	Removed []ResourceRef    `json:"removed"`
+}
+
+// ResourceRef identifies a Kubernetes resource.
+type ResourceRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// ResourceChange describes changes of a single Kubernetes resource.
+type ResourceChange struct {
+	ResourceRef
+	Fields []FieldChange `json:"fields"`
+}
+
+// FieldChange describes a change of a single resource field.
+// The Path uses dot notation, e.g. `spec.template.spec.containers`. Lists are compared as a whole.
+type FieldChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// Summary returns a short, human readable summary of the diff.
+func (d ManifestDiff) Summary() string {
+	return fmt.Sprintf("%d to add, %d to change, %d to remove", len(d.Added), len(d.Changed), len(d.Removed))
+}
+
+type resourceKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+type resource struct {
+	ref    ResourceRef
+	object map[string]interface{}
+}
+
+// dryRunDiff returns the marshaled diff between two Helm release manifests together with its summary.
+func dryRunDiff(namespace, oldManifest, newManifest string) ([]byte, string, error) {
+	diff, err := diffManifests(namespace, oldManifest, newManifest)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "while calculating manifest diff")
+	}
+
+	out, err := yaml.Marshal(&diff)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "while marshaling manifest diff")
+	}
+
+	return out, diff.Summary(), nil
+}
+
+// diffManifests returns the diff between two Helm release manifests.
+// Resources without namespace are assumed to be in a given release namespace.
+func diffManifests(namespace, oldManifest, newManifest string) (ManifestDiff, error) {
+	oldResources, err := parseManifest(namespace, oldManifest)
+	if err != nil {
+		return ManifestDiff{}, errors.Wrap(err, "while parsing live release manifest")
+	}
+	newResources, err := parseManifest(namespace, newManifest)
+	if err != nil {
+		return ManifestDiff{}, errors.Wrap(err, "while parsing rendered release manifest")
+	}
+
+	diff := ManifestDiff{
+		Added:   []ResourceRef{},
+		Changed: []ResourceChange{},
+		Removed: []ResourceRef{},
+	}
+
+	for _, key := range sortedKeys(newResources) {
+		newRes := newResources[key]
+		oldRes, found := oldResources[key]
+		if !found {
+			diff.Added = append(diff.Added, newRes.ref)
+			continue
+		}
+
+		var fields []FieldChange
+		diffValues("", oldRes.object, newRes.object, &fields)
+		if len(fields) == 0 {
+			continue
+		}
+		if key.kind == "Secret" {
+			maskSecretData(fields)
+		}
+		diff.Changed = append(diff.Changed, ResourceChange{
+			ResourceRef: newRes.ref,
+			Fields:      fields,
+		})
+	}
+
+	for _, key := range sortedKeys(oldResources) {
+		if _, found := newResources[key]; !found {
+			diff.Removed = append(diff.Removed, oldResources[key].ref)
+		}
+	}
+
+	return diff, nil
+}
+
+func parseManifest(namespace, manifest string) (map[resourceKey]resource, error) {
+	out := map[resourceKey]resource{}
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		object := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &object); err != nil {
+			return nil, errors.Wrap(err, "while unmarshaling resource")
+		}
+		if len(object) == 0 {
+			continue
+		}
+
+		ref := ResourceRef{
+			APIVersion: stringField(object, "apiVersion"),
+			Kind:       stringField(object, "kind"),
+			Namespace:  namespace,
+		}
+		if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+			ref.Name = stringField(metadata, "name")
+			if ns := stringField(metadata, "namespace"); ns != "" {
+				ref.Namespace = ns
+			}
+		}
+
+		out[resourceKey{kind: ref.Kind, namespace: ref.Namespace, name: ref.Name}] = resource{
+			ref:    ref,
+			object: object,
+		}
+	}
+
+	return out, nil
+}
+
+func diffValues(path string, oldVal, newVal interface{}, changes *[]FieldChange) {
+	oldMap, oldIsMap := oldVal.(map[string]interface{})
+	newMap, newIsMap := newVal.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := map[string]struct{}{}
+		for k := range oldMap {
+			keys[k] = struct{}{}
+		}
+		for k := range newMap {
+			keys[k] = struct{}{}
+		}
+
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			diffValues(childPath, oldMap[k], newMap[k], changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(oldVal, newVal) {
+		*changes = append(*changes, FieldChange{Path: path, Old: oldVal, New: newVal})
+	}
+}
+
+func maskSecretData(fields []FieldChange) {
+	for i := range fields {
+		root := strings.SplitN(fields[i].Path, ".", 2)[0]
+		if root != "data" && root != "stringData" {
+			continue
+		}
+		if fields[i].Old != nil {
+			fields[i].Old = sensitiveValue
+		}
+		if fields[i].New != nil {
+			fields[i].New = sensitiveValue
+		}
+	}
+}
+
+func sortedKeys(resources map[resourceKey]resource) []resourceKey {
+	keys := make([]resourceKey, 0, len(resources))
+	for k := range resources {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		if keys[i].namespace != keys[j].namespace {
+			return keys[i].namespace < keys[j].namespace
+		}
+		return keys[i].name < keys[j].name
+	})
+	return keys
+}
+
+func stringField(object map[string]interface{}, key string) string {
+	val, _ := object[key].(string)
+	return val
+}
@@ -0,0 +1,115 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const liveManifest = `---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+spec:
+  ports:
+  - port: 80
+---
+# Source: app/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+  labels:
+    version: "1"
+data:
+  password: b2xk
+---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  namespace: other
+data:
+  key: value
+`
+
+const renderedManifest = `---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+spec:
+  ports:
+  - port: 8080
+---
+# Source: app/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+  labels:
+    version: "2"
+data:
+  password: bmV3
+---
+# Source: app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+`
+
+func TestDiffManifests(t *testing.T) {
+	// given
+	expDiff := ManifestDiff{
+		Added: []ResourceRef{
+			{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "app"},
+		},
+		Changed: []ResourceChange{
+			{
+				ResourceRef: ResourceRef{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "app"},
+				Fields: []FieldChange{
+					{Path: "data.password", Old: sensitiveValue, New: sensitiveValue},
+					{Path: "metadata.labels.version", Old: "1", New: "2"},
+				},
+			},
+			{
+				ResourceRef: ResourceRef{APIVersion: "v1", Kind: "Service", Namespace: "default", Name: "app"},
+				Fields: []FieldChange{
+					{
+						Path: "spec.ports",
+						Old:  []interface{}{map[string]interface{}{"port": float64(80)}},
+						New:  []interface{}{map[string]interface{}{"port": float64(8080)}},
+					},
+				},
+			},
+		},
+		Removed: []ResourceRef{
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "other", Name: "app"},
+		},
+	}
+
+	// when
+	diff, err := diffManifests("default", liveManifest, renderedManifest)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, expDiff, diff)
+	assert.Equal(t, "1 to add, 2 to change, 1 to remove", diff.Summary())
+}
+
+func TestDiffManifestsWithoutChanges(t *testing.T) {
+	// when
+	diff, err := diffManifests("default", liveManifest, liveManifest)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "0 to add, 0 to change, 0 to remove", diff.Summary())
+}
@@ -0,0 +1,80 @@
+package helm
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"sigs.k8s.io/yaml"
+)
+
+func fixActionConfig(t *testing.T, releases ...*release.Release) *action.Configuration {
+	t.Helper()
+
+	store := storage.Init(driver.NewMemory())
+	for _, rel := range releases {
+		require.NoError(t, store.Create(rel))
+	}
+
+	return &action.Configuration{
+		Releases:     store,
+		KubeClient:   &kubefake.PrintingKubeClient{Out: ioutil.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(format string, v ...interface{}) {},
+	}
+}
+
+func fixActionConfigProducer(cfg *action.Configuration) actionConfigProducer {
+	return func(string) (*action.Configuration, error) {
+		return cfg, nil
+	}
+}
+
+func fixRelease(version int, chartVersion string, status release.Status) *release.Release {
+	return &release.Release{
+		Name:      "nginx",
+		Namespace: "default",
+		Version:   version,
+		Info: &release.Info{
+			Status: status,
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				Name:    "nginx",
+				Version: chartVersion,
+			},
+		},
+	}
+}
+
+func fixHelmReleaseFile(t *testing.T, rel ChartRelease) string {
+	t.Helper()
+
+	data, err := yaml.Marshal(rel)
+	require.NoError(t, err)
+
+	filePath := path.Join(t.TempDir(), "helm-release.yaml")
+	require.NoError(t, ioutil.WriteFile(filePath, data, 0600))
+
+	return filePath
+}
+
+func fixChartRelease() ChartRelease {
+	return ChartRelease{
+		Name:      "nginx",
+		Namespace: "default",
+		Chart: Chart{
+			Name:    "nginx",
+			Version: "1.1.0",
+			Repo:    "https://charts.bitnami.com/bitnami",
+		},
+	}
+}
@@ -3,6 +3,9 @@ package helm
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 
 	"capact.io/capact/internal/ptr"
 	"go.uber.org/zap"
@@ -18,19 +21,24 @@ type helmCommand interface {
 	Do(ctx context.Context, in Input) (Output, Status, error)
 }
 
-// Runner provides functionality to run and wait for Helm operations.
+var _ runner.SyncRunner = &helmRunner{}
+
+// helmRunner provides functionality to run Helm operations.
 type helmRunner struct {
-	cfg Config
-	log *zap.Logger
+	cfg      Config
+	log      *zap.Logger
+	progress runner.ProgressReportFunc
 }
 
-func newHelmRunner(cfg Config) *helmRunner {
+// NewRunner returns new instance of Helm runner.
+func NewRunner(cfg Config) runner.SyncRunner {
 	return &helmRunner{
 		cfg: cfg,
 	}
 }
 
-func (r *helmRunner) Do(ctx context.Context, in runner.StartInput) (*runner.WaitForCompletionOutput, error) {
+// Run executes the Helm operation and waits until it is finished.
+func (r *helmRunner) Run(ctx context.Context, in runner.RunInput) (*runner.WaitForCompletionOutput, error) {
 	actionCfgProducer := r.getActionConfigProducer()
 
 	cmdInput, err := r.readCommandData(in)
@@ -38,19 +46,30 @@ func (r *helmRunner) Do(ctx context.Context, in runner.StartInput) (*runner.Wait
 		return nil, err
 	}
 
+	creds, err := loadRepositoryCredentials(r.cfg.OptionalRepositoryCredentialsTI, r.log)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading repository credentials")
+	}
+	chartLoader := newChartLoader(r.log, r.cfg.RepositoryCachePath, creds)
+
 	renderer := NewRenderer()
 	outputter := NewOutputter(r.log, renderer)
 
 	var helmCmd helmCommand
 	switch r.cfg.Command {
 	case InstallCommandType:
-		helmCmd = newInstaller(r.log, r.cfg.RepositoryCachePath, actionCfgProducer, outputter)
+		helmCmd = newInstaller(r.log, chartLoader, actionCfgProducer, outputter)
 	case UpgradeCommandType:
-		helmCmd = newUpgrader(r.log, r.cfg.RepositoryCachePath, r.cfg.HelmReleasePath, actionCfgProducer, outputter)
+		helmCmd = newUpgrader(r.log, chartLoader, r.cfg.HelmReleasePath, actionCfgProducer, outputter)
+	case RollbackCommandType:
+		helmCmd = newRollbacker(r.log, r.cfg.HelmReleasePath, actionCfgProducer, outputter)
+	case UninstallCommandType:
+		helmCmd = newUninstaller(r.log, r.cfg.HelmReleasePath, actionCfgProducer)
 	default:
 		return nil, errors.New("Unsupported command")
 	}
 
+	r.progress.Report(runner.Progress{Phase: string(r.cfg.Command)})
 	out, status, err := helmCmd.Do(ctx, cmdInput)
 	if err != nil {
 		return nil, errors.Wrapf(err, "while running Helm command %q", r.cfg.Command)
@@ -67,14 +86,42 @@ func (r *helmRunner) Do(ctx context.Context, in runner.StartInput) (*runner.Wait
 	}, nil
 }
 
+// Name returns the name of the Helm runner.
 func (r *helmRunner) Name() string {
 	return "helm.v3"
 }
 
+// InjectLogger sets the logger on the runner.
 func (r *helmRunner) InjectLogger(logger *zap.Logger) {
 	r.log = logger
 }
 
+// InjectProgressReporter sets the progress reporting function on the runner.
+func (r *helmRunner) InjectProgressReporter(fn runner.ProgressReportFunc) {
+	r.progress = fn
+}
+
+// progressLogPrefixes holds the Helm debug log prefixes, which indicate the progress of the Helm operation.
+var progressLogPrefixes = []string{
+	"creating ",
+	"Created a new",
+	"Watching for changes to",
+	"beginning wait for",
+	"Starting delete",
+}
+
+func (r *helmRunner) reportProgressFromLog(msg string) {
+	for _, prefix := range progressLogPrefixes {
+		if strings.HasPrefix(msg, prefix) {
+			r.progress.Report(runner.Progress{
+				Phase:   string(r.cfg.Command),
+				Message: msg,
+			})
+			return
+		}
+	}
+}
+
 type actionConfigProducer func(forNamespace string) (*action.Configuration, error)
 
 func (r *helmRunner) getActionConfigProducer() actionConfigProducer {
@@ -94,7 +141,9 @@ func (r *helmRunner) getActionConfigProducer() actionConfigProducer {
 		}
 
 		debugLog := func(format string, v ...interface{}) {
-			r.log.Debug(fmt.Sprintf(format, v...), zap.String("source", "Helm"))
+			msg := fmt.Sprintf(format, v...)
+			r.log.Debug(msg, zap.String("source", "Helm"))
+			r.reportProgressFromLog(msg)
 		}
 
 		err = actionConfig.Init(helmCfg, forNamespace, r.cfg.HelmDriver, debugLog)
@@ -107,7 +156,7 @@ func (r *helmRunner) getActionConfigProducer() actionConfigProducer {
 	}
 }
 
-func (r *helmRunner) readCommandData(in runner.StartInput) (Input, error) {
+func (r *helmRunner) readCommandData(in runner.RunInput) (Input, error) {
 	args := DefaultArguments()
 	err := yaml.Unmarshal(in.Args, &args)
 	if err != nil {
@@ -120,6 +169,20 @@ func (r *helmRunner) readCommandData(in runner.StartInput) (Input, error) {
 	}, nil
 }
 
+func loadHelmReleaseData(log *zap.Logger, path string) (ChartRelease, error) {
+	log.Debug("Reading Helm Release data from file", zap.String("path", path))
+	bytes, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return ChartRelease{}, errors.Wrapf(err, "while reading values from file %q", path)
+	}
+
+	var chartRelease ChartRelease
+	if err := yaml.Unmarshal(bytes, &chartRelease); err != nil {
+		return ChartRelease{}, errors.Wrapf(err, "while parsing %q", path)
+	}
+	return chartRelease, nil
+}
+
 func (r *helmRunner) saveOutput(out Output) error {
 	r.log.Debug("Saving Helm release output", zap.String("path", r.cfg.Output.HelmReleaseFilePath))
 	err := runner.SaveToFile(r.cfg.Output.HelmReleaseFilePath, out.Release)
@@ -127,6 +190,13 @@ func (r *helmRunner) saveOutput(out Output) error {
 		return errors.Wrap(err, "while saving Helm release output")
 	}
 
+	if out.Diff != nil {
+		r.log.Debug("Saving manifest diff", zap.String("path", r.cfg.Output.DiffFilePath))
+		if err := runner.SaveToFile(r.cfg.Output.DiffFilePath, out.Diff); err != nil {
+			return errors.Wrap(err, "while saving manifest diff")
+		}
+	}
+
 	if out.Additional == nil {
 		return nil
 	}
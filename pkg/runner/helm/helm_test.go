@@ -0,0 +1,63 @@
+package helm
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHelmRunnerSaveOutput(t *testing.T) {
+	tests := map[string]struct {
+		out           Output
+		expAdditional bool
+		expDiff       bool
+	}{
+		"Should save release, additional output and diff": {
+			out:           Output{Release: []byte("release"), Additional: []byte("additional"), Diff: []byte("diff")},
+			expAdditional: true,
+			expDiff:       true,
+		},
+		"Should save diff without additional output": {
+			out:     Output{Release: []byte("release"), Diff: []byte("diff")},
+			expDiff: true,
+		},
+		"Should save only release": {
+			out: Output{Release: []byte("release")},
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			dir := t.TempDir()
+			r := &helmRunner{log: zap.NewNop()}
+			r.cfg.Output.HelmReleaseFilePath = filepath.Join(dir, "helm-release.yaml")
+			r.cfg.Output.AdditionalFilePath = filepath.Join(dir, "additional.yaml")
+			r.cfg.Output.DiffFilePath = filepath.Join(dir, "diff.yaml")
+
+			// when
+			err := r.saveOutput(tc.out)
+
+			// then
+			require.NoError(t, err)
+			assertFileContent(t, r.cfg.Output.HelmReleaseFilePath, true, tc.out.Release)
+			assertFileContent(t, r.cfg.Output.AdditionalFilePath, tc.expAdditional, tc.out.Additional)
+			assertFileContent(t, r.cfg.Output.DiffFilePath, tc.expDiff, tc.out.Diff)
+		})
+	}
+}
+
+func assertFileContent(t *testing.T, path string, exists bool, expected []byte) {
+	t.Helper()
+
+	got, err := ioutil.ReadFile(filepath.Clean(path))
+	if !exists {
+		assert.Error(t, err, "file %q should not exist", path)
+		return
+	}
+	require.NoError(t, err)
+	assert.Equal(t, expected, got)
+}
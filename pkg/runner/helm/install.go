@@ -8,23 +8,21 @@ import (
 
 	"github.com/pkg/errors"
 	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/chart/loader"
-	"helm.sh/helm/v3/pkg/cli"
 )
 
 type installer struct {
-	actionCfgProducer   actionConfigProducer
-	log                 *zap.Logger
-	out                 outputter
-	repositoryCachePath string
+	actionCfgProducer actionConfigProducer
+	log               *zap.Logger
+	out               outputter
+	chartLoader       *chartLoader
 }
 
-func newInstaller(log *zap.Logger, repositoryCachePath string, actionCfgProducer actionConfigProducer, outputter outputter) *installer {
+func newInstaller(log *zap.Logger, chartLoader *chartLoader, actionCfgProducer actionConfigProducer, outputter outputter) *installer {
 	return &installer{
-		log:                 log,
-		actionCfgProducer:   actionCfgProducer,
-		repositoryCachePath: repositoryCachePath,
-		out:                 outputter,
+		log:               log,
+		actionCfgProducer: actionCfgProducer,
+		chartLoader:       chartLoader,
+		out:               outputter,
 	}
 }
 
@@ -42,16 +40,11 @@ func (i *installer) Do(_ context.Context, in Input) (Output, Status, error) {
 	}
 	installCli.ReleaseName = name
 
-	chartPath, err := installCli.ChartPathOptions.LocateChart(chartName, &cli.EnvSettings{
-		RepositoryCache: i.repositoryCachePath,
-	})
+	chrt := in.Args.Chart
+	chrt.Name = chartName
+	chartData, err := i.chartLoader.Load(&installCli.ChartPathOptions, chrt)
 	if err != nil {
-		return Output{}, Status{}, errors.Wrap(err, "while locating Helm chart")
-	}
-
-	chartData, err := loader.Load(chartPath)
-	if err != nil {
-		return Output{}, Status{}, errors.Wrap(err, "while loading Helm chart")
+		return Output{}, Status{}, err
 	}
 
 	values, err := readValueOverrides(in.Args.Values, in.Args.ValuesFromFile)
@@ -68,7 +61,7 @@ func (i *installer) Do(_ context.Context, in Input) (Output, Status, error) {
 		return Output{}, Status{}, errors.Wrap(err, "Helm release is nil")
 	}
 
-	releaseOut, err := i.out.ProduceHelmRelease(in.Args.Chart.Repo, helmRelease)
+	releaseOut, err := i.out.ProduceHelmRelease(chartRepository(in.Args.Chart), helmRelease)
 	if err != nil {
 		return Output{}, Status{}, errors.Wrap(err, "while saving default output")
 	}
@@ -83,9 +76,20 @@ func (i *installer) Do(_ context.Context, in Input) (Output, Status, error) {
 		Message:   fmt.Sprintf("release %q installed successfully in namespace %q", helmRelease.Name, helmRelease.Namespace),
 	}
 
+	var diffOut []byte
+	if in.Ctx.DryRun {
+		var summary string
+		diffOut, summary, err = dryRunDiff(helmRelease.Namespace, "", helmRelease.Manifest)
+		if err != nil {
+			return Output{}, Status{}, err
+		}
+		status.Message = fmt.Sprintf("dry run of release %q installation in namespace %q: %s", helmRelease.Name, helmRelease.Namespace, summary)
+	}
+
 	return Output{
 		Release:    releaseOut,
 		Additional: additionalOut,
+		Diff:       diffOut,
 	}, status, nil
 }
 
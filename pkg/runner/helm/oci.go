@@ -0,0 +1,332 @@
+package helm
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// OCIScheme is the prefix of the chart repositories and chart names stored in OCI registries.
+	OCIScheme = "oci://"
+
+	ociManifestMediaType                 = "application/vnd.oci.image.manifest.v1+json"
+	helmChartContentLayerMediaType       = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	legacyHelmChartContentLayerMediaType = "application/tar+gzip"
+)
+
+var authChallengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// IsOCIChart returns true if a given chart is stored in an OCI registry.
+func IsOCIChart(chrt Chart) bool {
+	return strings.HasPrefix(chrt.Repo, OCIScheme) || strings.HasPrefix(chrt.Name, OCIScheme)
+}
+
+// chartRepository returns the repository of a given chart.
+// For charts specified with a full OCI reference, the repository is extracted from the chart name.
+func chartRepository(chrt Chart) string {
+	if !strings.HasPrefix(chrt.Name, OCIScheme) {
+		return chrt.Repo
+	}
+	return chrt.Name[:strings.LastIndex(chrt.Name, "/")]
+}
+
+type ociReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+// parseOCIReference returns the OCI reference for a given chart.
+// The chart can be specified either as a full reference in the chart name, e.g. `oci://registry.example.com/charts/nginx:1.0.0`,
+// or as an OCI repository and a chart name, e.g. `oci://registry.example.com/charts` and `nginx`.
+// The chart version takes precedence over the tag from the reference.
+func parseOCIReference(chrt Chart) (ociReference, error) {
+	ref := chrt.Name
+	if !strings.HasPrefix(ref, OCIScheme) {
+		ref = strings.TrimSuffix(chrt.Repo, "/") + "/" + ref
+	}
+	ref = strings.TrimPrefix(ref, OCIScheme)
+
+	slash := strings.Index(ref, "/")
+	if slash <= 0 || slash == len(ref)-1 {
+		return ociReference{}, errors.Errorf("invalid OCI chart reference %q", OCIScheme+ref)
+	}
+	out := ociReference{
+		Registry:   ref[:slash],
+		Repository: ref[slash+1:],
+		Tag:        chrt.Version,
+	}
+
+	// the tag can be specified only after the last path element, as the registry host may contain a port
+	if colon := strings.LastIndex(out.Repository, ":"); colon > strings.LastIndex(out.Repository, "/") {
+		if out.Tag == "" {
+			out.Tag = out.Repository[colon+1:]
+		}
+		out.Repository = out.Repository[:colon]
+	}
+
+	if out.Tag == "" {
+		return ociReference{}, errors.Errorf("chart version is required for OCI chart %q", OCIScheme+ref)
+	}
+
+	return out, nil
+}
+
+// ociRegistryClient pulls Helm charts from OCI registries using the OCI Distribution API.
+type ociRegistryClient struct {
+	httpClient *http.Client
+	creds      *RepositoryCredentials
+	scheme     string
+}
+
+func newOCIRegistryClient(creds *RepositoryCredentials) (*ociRegistryClient, error) {
+	tlsCfg, err := tlsConfigForCredentials(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "https"
+	if creds != nil && creds.PlainHTTP {
+		scheme = "http"
+	}
+
+	return &ociRegistryClient{
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsCfg,
+			},
+		},
+		creds:  creds,
+		scheme: scheme,
+	}, nil
+}
+
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// PullChart returns the chart archive for a given reference.
+func (c *ociRegistryClient) PullChart(ref ociReference) ([]byte, error) {
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", c.scheme, ref.Registry, ref.Repository, ref.Tag)
+	data, err := c.get(manifestURL, ociManifestMediaType)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting chart manifest")
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling chart manifest")
+	}
+
+	var chartLayer *ociDescriptor
+	for i := range manifest.Layers {
+		switch manifest.Layers[i].MediaType {
+		case helmChartContentLayerMediaType, legacyHelmChartContentLayerMediaType:
+			chartLayer = &manifest.Layers[i]
+		}
+	}
+	if chartLayer == nil {
+		return nil, errors.Errorf("manifest %s:%s does not contain Helm chart content layer", ref.Repository, ref.Tag)
+	}
+
+	blobURL := fmt.Sprintf("%s://%s/v2/%s/blobs/%s", c.scheme, ref.Registry, ref.Repository, chartLayer.Digest)
+	blob, err := c.get(blobURL, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting chart content")
+	}
+
+	if err := verifyDigest(chartLayer.Digest, blob); err != nil {
+		return nil, err
+	}
+
+	return blob, nil
+}
+
+func (c *ociRegistryClient) get(rawURL, accept string) ([]byte, error) {
+	resp, err := c.do(rawURL, accept, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// registries challenge anonymous requests, so the request is repeated with the requested authorization
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		authorization, err := c.authorization(challenge)
+		if err != nil {
+			return nil, errors.Wrap(err, "while authorizing to registry")
+		}
+
+		resp, err = c.do(rawURL, accept, authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d while getting %q", resp.StatusCode, rawURL)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (c *ociRegistryClient) do(rawURL, accept, authorization string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating request")
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while getting %q", rawURL)
+	}
+
+	return resp, nil
+}
+
+// authorization returns the value of the Authorization header for a given WWW-Authenticate challenge.
+// Both Basic and Bearer token authentication schemes are supported.
+func (c *ociRegistryClient) authorization(challenge string) (string, error) {
+	switch {
+	case strings.HasPrefix(challenge, "Basic"):
+		if c.creds == nil || c.creds.Username == "" {
+			return "", errors.New("registry requires credentials, but they were not provided")
+		}
+		req := http.Request{Header: http.Header{}}
+		req.SetBasicAuth(c.creds.Username, c.creds.Password)
+		return req.Header.Get("Authorization"), nil
+	case strings.HasPrefix(challenge, "Bearer"):
+		token, err := c.fetchToken(challenge)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	default:
+		return "", errors.Errorf("unsupported authentication challenge %q", challenge)
+	}
+}
+
+func (c *ociRegistryClient) fetchToken(challenge string) (string, error) {
+	params := map[string]string{}
+	for _, m := range authChallengeParamRegex.FindAllStringSubmatch(challenge, -1) {
+		params[m[1]] = m[2]
+	}
+
+	realm, found := params["realm"]
+	if !found {
+		return "", errors.Errorf("missing realm in authentication challenge %q", challenge)
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", errors.Wrap(err, "while parsing token realm")
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if val, found := params[key]; found {
+			query.Set(key, val)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", errors.Wrap(err, "while creating token request")
+	}
+	if c.creds != nil && c.creds.Username != "" {
+		req.SetBasicAuth(c.creds.Username, c.creds.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "while getting token")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("unexpected status code %d while getting token", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", errors.Wrap(err, "while decoding token response")
+	}
+
+	if tokenResp.Token != "" {
+		return tokenResp.Token, nil
+	}
+	return tokenResp.AccessToken, nil
+}
+
+func verifyDigest(digest string, data []byte) error {
+	expected := strings.TrimPrefix(digest, "sha256:")
+	if expected == digest {
+		return errors.Errorf("unsupported digest algorithm in %q", digest)
+	}
+
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != expected {
+		return errors.Errorf("chart content digest mismatch: expected %q, got %q", digest, "sha256:"+got)
+	}
+
+	return nil
+}
+
+func tlsConfigForCredentials(creds *RepositoryCredentials) (*tls.Config, error) {
+	// #nosec G402
+	cfg := &tls.Config{}
+	if creds == nil {
+		return cfg, nil
+	}
+
+	cfg.InsecureSkipVerify = creds.InsecureSkipTLSVerify
+
+	if creds.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(creds.CABundle)) {
+			return nil, errors.New("while appending CA bundle: no valid certificates found")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if creds.ClientCertificate != "" || creds.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(creds.ClientCertificate), []byte(creds.ClientKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "while loading client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,186 @@
+package helm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	tests := map[string]struct {
+		givenChart Chart
+		expRef     ociReference
+		expErr     string
+	}{
+		"Should use OCI repository and chart name": {
+			givenChart: Chart{Name: "nginx", Repo: "oci://registry.example.com/charts/", Version: "1.0.0"},
+			expRef:     ociReference{Registry: "registry.example.com", Repository: "charts/nginx", Tag: "1.0.0"},
+		},
+		"Should use full reference from chart name": {
+			givenChart: Chart{Name: "oci://localhost:5000/nginx:1.0.0"},
+			expRef:     ociReference{Registry: "localhost:5000", Repository: "nginx", Tag: "1.0.0"},
+		},
+		"Should prefer chart version over tag": {
+			givenChart: Chart{Name: "oci://localhost:5000/nginx:1.0.0", Version: "1.1.0"},
+			expRef:     ociReference{Registry: "localhost:5000", Repository: "nginx", Tag: "1.1.0"},
+		},
+		"Should return error if version is missing": {
+			givenChart: Chart{Name: "oci://localhost:5000/nginx"},
+			expErr:     `chart version is required for OCI chart "oci://localhost:5000/nginx"`,
+		},
+		"Should return error if repository is missing": {
+			givenChart: Chart{Name: "oci://localhost:5000/", Version: "1.0.0"},
+			expErr:     `invalid OCI chart reference "oci://localhost:5000/"`,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			ref, err := parseOCIReference(tc.givenChart)
+
+			// then
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expRef, ref)
+		})
+	}
+}
+
+func TestChartLoaderLoadFromOCIRegistry(t *testing.T) {
+	// given
+	archive := fixChartArchive(t)
+	srv := fixOCIRegistry(t, "charts/nginx", "1.0.0", archive)
+
+	creds := &RepositoryCredentials{
+		Username: "capact",
+		Password: "secret",
+		CABundle: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})),
+	}
+	loader := newChartLoader(zap.NewNop(), t.TempDir(), creds)
+
+	// when
+	chrt, err := loader.Load(&action.ChartPathOptions{}, Chart{
+		Name:    "nginx",
+		Repo:    fmt.Sprintf("oci://%s/charts", strings.TrimPrefix(srv.URL, "https://")),
+		Version: "1.0.0",
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", chrt.Metadata.Name)
+	assert.Equal(t, "1.0.0", chrt.Metadata.Version)
+}
+
+func TestChartLoaderLoadFromOCIRegistryErrors(t *testing.T) {
+	// given
+	srv := fixOCIRegistry(t, "charts/nginx", "1.0.0", fixChartArchive(t))
+	repo := fmt.Sprintf("oci://%s/charts", strings.TrimPrefix(srv.URL, "https://"))
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	tests := map[string]struct {
+		creds  *RepositoryCredentials
+		expErr string
+	}{
+		"Should reject untrusted server certificate": {
+			creds:  &RepositoryCredentials{Username: "capact", Password: "secret"},
+			expErr: "certificate signed by unknown authority",
+		},
+		"Should reject invalid credentials": {
+			creds:  &RepositoryCredentials{Username: "capact", Password: "wrong", CABundle: caBundle},
+			expErr: "while authorizing to registry: unexpected status code 401 while getting token",
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			loader := newChartLoader(zap.NewNop(), t.TempDir(), tc.creds)
+
+			// when
+			_, err := loader.Load(&action.ChartPathOptions{}, Chart{Name: "nginx", Repo: repo, Version: "1.0.0"})
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expErr)
+		})
+	}
+}
+
+func fixChartArchive(t *testing.T) []byte {
+	t.Helper()
+
+	path, err := chartutil.Save(&chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       "nginx",
+			Version:    "1.0.0",
+		},
+	}, t.TempDir())
+	require.NoError(t, err)
+
+	archive, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	return archive
+}
+
+// fixOCIRegistry returns a TLS server, which serves a given chart using the OCI Distribution API with the token authentication.
+func fixOCIRegistry(t *testing.T, repository, tag string, archive []byte) *httptest.Server {
+	t.Helper()
+
+	sum := sha256.Sum256(archive)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	manifest, err := json.Marshal(ociManifest{
+		Layers: []ociDescriptor{
+			{MediaType: "application/vnd.cncf.helm.config.v1+json", Digest: "sha256:config"},
+			{MediaType: helmChartContentLayerMediaType, Digest: digest},
+		},
+	})
+	require.NoError(t, err)
+
+	const token = "registry-token"
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "capact" || pass != "secret" || r.URL.Query().Get("scope") != "repository:"+repository+":pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"token": %q}`, token)))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="registry",scope="repository:%s:pull"`, r.Host, repository))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case fmt.Sprintf("/v2/%s/manifests/%s", repository, tag):
+			_, _ = w.Write(manifest)
+		case fmt.Sprintf("/v2/%s/blobs/%s", repository, digest):
+			_, _ = w.Write(archive)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
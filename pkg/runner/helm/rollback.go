@@ -0,0 +1,122 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type rollbacker struct {
+	actionCfgProducer actionConfigProducer
+	log               *zap.Logger
+	out               outputter
+	helmReleasePath   string
+}
+
+func newRollbacker(log *zap.Logger, helmReleasePath string, actionCfgProducer actionConfigProducer, outputter outputter) helmCommand {
+	return &rollbacker{
+		log:               log,
+		actionCfgProducer: actionCfgProducer,
+		out:               outputter,
+		helmReleasePath:   helmReleasePath,
+	}
+}
+
+// Do executes rollback process.
+//
+// Same as for upgrade, it uses the Helm Release namespace instead of the namespace from the Runner Context.
+func (r *rollbacker) Do(_ context.Context, in Input) (Output, Status, error) {
+	if r.helmReleasePath == "" {
+		return Output{}, Status{}, errors.New("path to Helm Release is required for rollback")
+	}
+
+	helmReleaseData, err := loadHelmReleaseData(r.log, r.helmReleasePath)
+	if err != nil {
+		return Output{}, Status{}, err
+	}
+
+	actCfg, err := r.actionCfgProducer(helmReleaseData.Namespace)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while creating Helm action config")
+	}
+
+	rollbackCli := r.initActionRollbackFromInput(actCfg, in)
+	if err := rollbackCli.Run(helmReleaseData.Name); err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while rolling back Helm release")
+	}
+
+	// rollback creates a new revision, which is a copy of the target one
+	helmRelease, err := actCfg.Releases.Last(helmReleaseData.Name)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while getting Helm release after rollback")
+	}
+
+	releaseOut, err := r.out.ProduceHelmRelease(helmReleaseData.Chart.Repo, helmRelease)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while saving default output")
+	}
+
+	additionalOut, err := r.out.ProduceAdditional(in.Args.Output, helmRelease.Chart, helmRelease)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while rendering and saving additional output")
+	}
+
+	status := Status{
+		Succeeded: true,
+		Message:   fmt.Sprintf("release %q rolled back successfully in namespace %q (revision %d)", helmRelease.Name, helmRelease.Namespace, helmRelease.Version),
+	}
+
+	var diffOut []byte
+	if in.Ctx.DryRun {
+		var summary string
+		diffOut, summary, err = r.dryRunDiff(actCfg, helmRelease, in.Args.RollbackArgs.Revision)
+		if err != nil {
+			return Output{}, Status{}, err
+		}
+		status.Message = fmt.Sprintf("dry run of release %q rollback in namespace %q: %s", helmRelease.Name, helmRelease.Namespace, summary)
+	}
+
+	return Output{
+		Release:    releaseOut,
+		Additional: additionalOut,
+		Diff:       diffOut,
+	}, status, nil
+}
+
+// dryRunDiff returns the diff between the live release and the revision to roll back to.
+// Helm doesn't render anything for rollback in the dry-run mode, so the target revision is taken from the release history.
+func (r *rollbacker) dryRunDiff(cfg *action.Configuration, liveRelease *release.Release, revision int) ([]byte, string, error) {
+	if revision == 0 {
+		revision = liveRelease.Version - 1
+	}
+
+	targetRelease, err := cfg.Releases.Get(liveRelease.Name, revision)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "while getting Helm release revision %d", revision)
+	}
+
+	return dryRunDiff(liveRelease.Namespace, liveRelease.Manifest, targetRelease.Manifest)
+}
+
+func (r *rollbacker) initActionRollbackFromInput(cfg *action.Configuration, in Input) *action.Rollback {
+	rollbackCli := action.NewRollback(cfg)
+	rollbackCli.Wait = true
+
+	// context
+	rollbackCli.DryRun = in.Ctx.DryRun
+	rollbackCli.Timeout = in.Ctx.Timeout.Duration()
+
+	// common args
+	rollbackCli.DisableHooks = in.Args.CommonArgs.NoHooks
+
+	// rollback args
+	rollbackCli.Version = in.Args.RollbackArgs.Revision
+	rollbackCli.Force = in.Args.RollbackArgs.Force
+	rollbackCli.MaxHistory = in.Args.UpgradeArgs.MaxHistory
+
+	return rollbackCli
+}
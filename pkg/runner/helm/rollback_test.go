@@ -0,0 +1,93 @@
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/release"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRollbackerDo(t *testing.T) {
+	tests := map[string]struct {
+		revision        int
+		expChartVersion string
+	}{
+		"Should roll back to the previous revision": {
+			revision:        0,
+			expChartVersion: "1.0.1",
+		},
+		"Should roll back to a given revision": {
+			revision:        1,
+			expChartVersion: "1.0.0",
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			actCfg := fixActionConfig(t,
+				fixRelease(1, "1.0.0", release.StatusSuperseded),
+				fixRelease(2, "1.0.1", release.StatusSuperseded),
+				fixRelease(3, "1.1.0", release.StatusDeployed),
+			)
+			rollbacker := newRollbacker(zap.NewNop(), fixHelmReleaseFile(t, fixChartRelease()), fixActionConfigProducer(actCfg), NewOutputter(zap.NewNop(), NewRenderer()))
+
+			args := DefaultArguments()
+			args.Revision = tc.revision
+
+			// when
+			out, status, err := rollbacker.Do(context.Background(), Input{Args: args})
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, status.Succeeded)
+			assert.Equal(t, `release "nginx" rolled back successfully in namespace "default" (revision 4)`, status.Message)
+
+			var gotRelease ChartRelease
+			require.NoError(t, yaml.Unmarshal(out.Release, &gotRelease))
+			assert.Equal(t, tc.expChartVersion, gotRelease.Chart.Version)
+			assert.Equal(t, "https://charts.bitnami.com/bitnami", gotRelease.Chart.Repo)
+			assert.False(t, gotRelease.Deleted)
+
+			last, err := actCfg.Releases.Last("nginx")
+			require.NoError(t, err)
+			assert.Equal(t, release.StatusDeployed, last.Info.Status)
+		})
+	}
+}
+
+func TestRollbackerDoRespectsMaxHistory(t *testing.T) {
+	// given
+	actCfg := fixActionConfig(t,
+		fixRelease(1, "1.0.0", release.StatusSuperseded),
+		fixRelease(2, "1.1.0", release.StatusDeployed),
+	)
+	rollbacker := newRollbacker(zap.NewNop(), fixHelmReleaseFile(t, fixChartRelease()), fixActionConfigProducer(actCfg), NewOutputter(zap.NewNop(), NewRenderer()))
+
+	args := DefaultArguments()
+	args.MaxHistory = 2
+
+	// when
+	_, _, err := rollbacker.Do(context.Background(), Input{Args: args})
+
+	// then
+	require.NoError(t, err)
+
+	history, err := actCfg.Releases.History("nginx")
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+}
+
+func TestRollbackerDoWithoutHelmRelease(t *testing.T) {
+	// given
+	rollbacker := newRollbacker(zap.NewNop(), "", nil, nil)
+
+	// when
+	_, _, err := rollbacker.Do(context.Background(), Input{})
+
+	// then
+	assert.EqualError(t, err, "path to Helm Release is required for rollback")
+}
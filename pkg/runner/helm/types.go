@@ -14,14 +14,18 @@ type outputter interface {
 // Config holds Runner related configuration.
 type Config struct {
 	OptionalKubeconfigTI string `envconfig:"optional"`
-	Command              CommandType
-	HelmReleasePath      string `envconfig:"optional"`
-	HelmDriver           string `envconfig:"default=secrets"`
-	RepositoryCachePath  string `envconfig:"default=/tmp/helm"`
-	Output               struct {
+	// OptionalRepositoryCredentialsTI is a path to the TypeInstance with RepositoryCredentials.
+	OptionalRepositoryCredentialsTI string `envconfig:"optional"`
+	Command                         CommandType
+	HelmReleasePath                 string `envconfig:"optional"`
+	HelmDriver                      string `envconfig:"default=secrets"`
+	RepositoryCachePath             string `envconfig:"default=/tmp/helm"`
+	Output                          struct {
 		HelmReleaseFilePath string `envconfig:"default=/tmp/helm-release.yaml"`
 		// Extracting resource metadata from Kubernetes as outputs
 		AdditionalFilePath string `envconfig:"default=/tmp/additional.yaml"`
+		// Manifest diff produced in the dry-run mode
+		DiffFilePath string `envconfig:"default=/tmp/diff.yaml"`
 	}
 }
 
@@ -33,6 +37,10 @@ const (
 	InstallCommandType = "install"
 	// UpgradeCommandType is an operation to upgrade an Helm release.
 	UpgradeCommandType = "upgrade"
+	// RollbackCommandType is an operation to roll back an Helm release to a given or previous revision.
+	RollbackCommandType = "rollback"
+	// UninstallCommandType is an operation to uninstall an Helm release.
+	UninstallCommandType = "uninstall"
 	// MaxHistoryDefault limits the maximum number of revisions saved per release.
 	// Same value as defined by `helm upgrade` cmd: https://github.com/helm/helm/blob/a499b4b179307c267bdf3ec49b880e3dbd2a5591/pkg/cli/environment.go#L37-L38
 	MaxHistoryDefault = 10
@@ -52,6 +60,8 @@ type Arguments struct {
 	CommonArgs
 	InstallArgs
 	UpgradeArgs
+	RollbackArgs
+	UninstallArgs
 }
 
 // CommonArgs stores common arguments used in every operation.
@@ -71,12 +81,25 @@ type InstallArgs struct {
 }
 
 // UpgradeArgs stores input arguments for the upgrade operation.
+// MaxHistory is respected also by the rollback operation.
 type UpgradeArgs struct {
 	ReuseValues bool `json:"reuseValues"`
 	ResetValues bool `json:"resetValues"`
 	MaxHistory  int  `json:"maxHistory"`
 }
 
+// RollbackArgs stores input arguments for the rollback operation.
+type RollbackArgs struct {
+	// Revision to roll back to. If omitted or set to 0, the release is rolled back to the previous revision.
+	Revision int  `json:"revision"`
+	Force    bool `json:"force"`
+}
+
+// UninstallArgs stores input arguments for the uninstall operation.
+type UninstallArgs struct {
+	KeepHistory bool `json:"keepHistory"`
+}
+
 // OutputArgs stores input arguments for generating the output artifacts.
 type OutputArgs struct {
 	GoTemplate string `json:"goTemplate"`
@@ -89,11 +112,28 @@ type Chart struct {
 	Repo    string `json:"repo"`
 }
 
+// RepositoryCredentials holds credentials and TLS options for private chart repositories and OCI registries.
+type RepositoryCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// CABundle holds PEM encoded CA certificates used to verify the repository server certificate.
+	CABundle string `json:"caBundle"`
+	// ClientCertificate holds PEM encoded client certificate used for the TLS client authentication.
+	ClientCertificate string `json:"clientCertificate"`
+	// ClientKey holds PEM encoded client key used for the TLS client authentication.
+	ClientKey             string `json:"clientKey"`
+	InsecureSkipTLSVerify bool   `json:"insecureSkipTLSVerify"`
+	// PlainHTTP enables pulling charts from OCI registries over HTTP.
+	PlainHTTP bool `json:"plainHTTP"`
+}
+
 // ChartRelease represents a Helm chart release.
 type ChartRelease struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
 	Chart     Chart  `json:"chart"`
+	// Deleted indicates that the release was uninstalled.
+	Deleted bool `json:"deleted,omitempty"`
 }
 
 // Input stores the input configuration for the runner.
@@ -112,4 +152,6 @@ type Status struct {
 type Output struct {
 	Release    []byte
 	Additional []byte
+	// Diff holds the ManifestDiff. It is produced only in the dry-run mode.
+	Diff []byte
 }
@@ -0,0 +1,93 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/action"
+	"sigs.k8s.io/yaml"
+)
+
+type uninstaller struct {
+	actionCfgProducer actionConfigProducer
+	log               *zap.Logger
+	helmReleasePath   string
+}
+
+func newUninstaller(log *zap.Logger, helmReleasePath string, actionCfgProducer actionConfigProducer) helmCommand {
+	return &uninstaller{
+		log:               log,
+		actionCfgProducer: actionCfgProducer,
+		helmReleasePath:   helmReleasePath,
+	}
+}
+
+// Do executes uninstall process.
+//
+// The Helm Release TypeInstance is emitted with the `deleted` flag set,
+// so the Action workflow can decide what to do with it.
+func (u *uninstaller) Do(_ context.Context, in Input) (Output, Status, error) {
+	if u.helmReleasePath == "" {
+		return Output{}, Status{}, errors.New("path to Helm Release is required for uninstall")
+	}
+
+	helmReleaseData, err := loadHelmReleaseData(u.log, u.helmReleasePath)
+	if err != nil {
+		return Output{}, Status{}, err
+	}
+
+	actCfg, err := u.actionCfgProducer(helmReleaseData.Namespace)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while creating Helm action config")
+	}
+
+	uninstallCli := u.initActionUninstallFromInput(actCfg, in)
+	resp, err := uninstallCli.Run(helmReleaseData.Name)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while uninstalling Helm release")
+	}
+
+	helmReleaseData.Deleted = !in.Ctx.DryRun
+	releaseOut, err := yaml.Marshal(&helmReleaseData)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while marshaling yaml")
+	}
+
+	status := Status{
+		Succeeded: true,
+		Message:   fmt.Sprintf("release %q uninstalled successfully from namespace %q", helmReleaseData.Name, helmReleaseData.Namespace),
+	}
+
+	var diffOut []byte
+	if in.Ctx.DryRun && resp != nil && resp.Release != nil {
+		var summary string
+		diffOut, summary, err = dryRunDiff(helmReleaseData.Namespace, resp.Release.Manifest, "")
+		if err != nil {
+			return Output{}, Status{}, err
+		}
+		status.Message = fmt.Sprintf("dry run of release %q uninstallation from namespace %q: %s", helmReleaseData.Name, helmReleaseData.Namespace, summary)
+	}
+
+	return Output{
+		Release: releaseOut,
+		Diff:    diffOut,
+	}, status, nil
+}
+
+func (u *uninstaller) initActionUninstallFromInput(cfg *action.Configuration, in Input) *action.Uninstall {
+	uninstallCli := action.NewUninstall(cfg)
+
+	// context
+	uninstallCli.DryRun = in.Ctx.DryRun
+	uninstallCli.Timeout = in.Ctx.Timeout.Duration()
+
+	// common args
+	uninstallCli.DisableHooks = in.Args.CommonArgs.NoHooks
+
+	// uninstall args
+	uninstallCli.KeepHistory = in.Args.UninstallArgs.KeepHistory
+
+	return uninstallCli
+}
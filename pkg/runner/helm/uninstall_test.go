@@ -0,0 +1,70 @@
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/release"
+	"sigs.k8s.io/yaml"
+)
+
+func TestUninstallerDo(t *testing.T) {
+	tests := map[string]struct {
+		dryRun      bool
+		keepHistory bool
+		expDeleted  bool
+		expHistory  int
+	}{
+		"Should uninstall release and purge its history": {
+			expDeleted: true,
+			expHistory: 0,
+		},
+		"Should uninstall release and keep its history": {
+			keepHistory: true,
+			expDeleted:  true,
+			expHistory:  2,
+		},
+		"Should not uninstall release in dry run mode": {
+			dryRun:     true,
+			expDeleted: false,
+			expHistory: 2,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			actCfg := fixActionConfig(t,
+				fixRelease(1, "1.0.0", release.StatusSuperseded),
+				fixRelease(2, "1.1.0", release.StatusDeployed),
+			)
+			uninstaller := newUninstaller(zap.NewNop(), fixHelmReleaseFile(t, fixChartRelease()), fixActionConfigProducer(actCfg))
+
+			args := DefaultArguments()
+			args.KeepHistory = tc.keepHistory
+
+			// when
+			out, status, err := uninstaller.Do(context.Background(), Input{
+				Args: args,
+				Ctx:  runner.Context{DryRun: tc.dryRun},
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, status.Succeeded)
+			assert.Nil(t, out.Additional)
+
+			var gotRelease ChartRelease
+			require.NoError(t, yaml.Unmarshal(out.Release, &gotRelease))
+			assert.Equal(t, tc.expDeleted, gotRelease.Deleted)
+			assert.Equal(t, "1.1.0", gotRelease.Chart.Version)
+
+			history, _ := actCfg.Releases.History("nginx")
+			assert.Len(t, history, tc.expHistory)
+		})
+	}
+}
@@ -3,32 +3,27 @@ package helm
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
-	"path/filepath"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/chart/loader"
-	"helm.sh/helm/v3/pkg/cli"
-	"sigs.k8s.io/yaml"
 )
 
 type upgrader struct {
-	actionCfgProducer   actionConfigProducer
-	log                 *zap.Logger
-	out                 outputter
-	repositoryCachePath string
-	helmReleasePath     string
+	actionCfgProducer actionConfigProducer
+	log               *zap.Logger
+	out               outputter
+	chartLoader       *chartLoader
+	helmReleasePath   string
 }
 
-func newUpgrader(log *zap.Logger, repositoryCachePath string, helmReleasePath string, actionCfgProducer actionConfigProducer, outputter outputter) helmCommand {
+func newUpgrader(log *zap.Logger, chartLoader *chartLoader, helmReleasePath string, actionCfgProducer actionConfigProducer, outputter outputter) helmCommand {
 	return &upgrader{
-		log:                 log,
-		actionCfgProducer:   actionCfgProducer,
-		out:                 outputter,
-		repositoryCachePath: repositoryCachePath,
-		helmReleasePath:     helmReleasePath,
+		log:               log,
+		actionCfgProducer: actionCfgProducer,
+		out:               outputter,
+		chartLoader:       chartLoader,
+		helmReleasePath:   helmReleasePath,
 	}
 }
 
@@ -42,7 +37,7 @@ func (i *upgrader) Do(_ context.Context, in Input) (Output, Status, error) {
 		return Output{}, Status{}, errors.New("path to Helm Release is required for upgrade")
 	}
 
-	helmReleaseData, err := i.loadHelmReleaseData(i.helmReleasePath)
+	helmReleaseData, err := loadHelmReleaseData(i.log, i.helmReleasePath)
 	if err != nil {
 		return Output{}, Status{}, err
 	}
@@ -56,16 +51,9 @@ func (i *upgrader) Do(_ context.Context, in Input) (Output, Status, error) {
 
 	upgradeCli := i.initActionUpgradeFromInput(actCfg, in, helmChartRel)
 
-	chartPath, err := upgradeCli.ChartPathOptions.LocateChart(helmChartRel.Chart.Name, &cli.EnvSettings{
-		RepositoryCache: i.repositoryCachePath,
-	})
+	chartData, err := i.chartLoader.Load(&upgradeCli.ChartPathOptions, helmChartRel.Chart)
 	if err != nil {
-		return Output{}, Status{}, errors.Wrap(err, "while locating Helm chart")
-	}
-
-	chartData, err := loader.Load(chartPath)
-	if err != nil {
-		return Output{}, Status{}, errors.Wrap(err, "while loading Helm chart")
+		return Output{}, Status{}, err
 	}
 
 	values, err := readValueOverrides(in.Args.Values, in.Args.ValuesFromFile)
@@ -82,7 +70,7 @@ func (i *upgrader) Do(_ context.Context, in Input) (Output, Status, error) {
 		return Output{}, Status{}, errors.Wrap(err, "Helm release is nil")
 	}
 
-	releaseOut, err := i.out.ProduceHelmRelease(helmChartRel.Chart.Repo, helmRelease)
+	releaseOut, err := i.out.ProduceHelmRelease(chartRepository(helmChartRel.Chart), helmRelease)
 	if err != nil {
 		return Output{}, Status{}, errors.Wrap(err, "while saving default output")
 	}
@@ -97,26 +85,29 @@ func (i *upgrader) Do(_ context.Context, in Input) (Output, Status, error) {
 		Message:   fmt.Sprintf("release %q upgraded successfully in namespace %q", helmRelease.Name, helmRelease.Namespace),
 	}
 
+	var diffOut []byte
+	if in.Ctx.DryRun {
+		// in the dry-run mode the rendered release is not stored, so the last one is the live release
+		liveRelease, err := actCfg.Releases.Last(helmChartRel.Name)
+		if err != nil {
+			return Output{}, Status{}, errors.Wrap(err, "while getting live Helm release")
+		}
+
+		var summary string
+		diffOut, summary, err = dryRunDiff(helmRelease.Namespace, liveRelease.Manifest, helmRelease.Manifest)
+		if err != nil {
+			return Output{}, Status{}, err
+		}
+		status.Message = fmt.Sprintf("dry run of release %q upgrade in namespace %q: %s", helmRelease.Name, helmRelease.Namespace, summary)
+	}
+
 	return Output{
 		Release:    releaseOut,
 		Additional: additionalOut,
+		Diff:       diffOut,
 	}, status, nil
 }
 
-func (i *upgrader) loadHelmReleaseData(path string) (ChartRelease, error) {
-	i.log.Debug("Reading Helm Release data from file", zap.String("path", path))
-	bytes, err := ioutil.ReadFile(filepath.Clean(path))
-	if err != nil {
-		return ChartRelease{}, errors.Wrapf(err, "while reading values from file %q", path)
-	}
-
-	var chartRelease ChartRelease
-	if err := yaml.Unmarshal(bytes, &chartRelease); err != nil {
-		return ChartRelease{}, errors.Wrapf(err, "while parsing %q", path)
-	}
-	return chartRelease, nil
-}
-
 func (i *upgrader) mergeHelmChartData(helmRelease ChartRelease, in Input) ChartRelease {
 	if in.Args.Chart.Name != "" {
 		helmRelease.Chart.Name = in.Args.Chart.Name
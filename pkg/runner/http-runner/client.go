@@ -0,0 +1,254 @@
+package httprunner
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// retryableError indicates that the request can be retried.
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+// apiClient executes HTTP requests with authentication, custom headers and retries.
+type apiClient struct {
+	log     *zap.Logger
+	client  *http.Client
+	baseURL *url.URL
+	headers map[string]string
+	auth    Auth
+	retry   Retry
+}
+
+func newAPIClient(ctx context.Context, log *zap.Logger, args Arguments) (*apiClient, error) {
+	if err := validateAuth(args.Auth); err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(args.BaseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing base URL")
+	}
+	// relative paths are appended to the base URL path, e.g. `projects` to `https://gitlab.com/api/v4`
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		baseURL.Path += "/"
+	}
+
+	transport, err := newTransport(args.TLS)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating HTTP transport")
+	}
+	client := &http.Client{Transport: transport}
+
+	if creds := args.Auth.OAuth2; creds != nil {
+		cfg := clientcredentials.Config{
+			ClientID:       creds.ClientID,
+			ClientSecret:   creds.ClientSecret,
+			TokenURL:       creds.TokenURL,
+			Scopes:         creds.Scopes,
+			EndpointParams: url.Values{},
+		}
+		for k, v := range creds.EndpointParams {
+			cfg.EndpointParams.Set(k, v)
+		}
+		// the token is fetched with the same transport, so the TLS configuration applies also to the token endpoint
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, client)
+		client = cfg.Client(ctx)
+	}
+
+	return &apiClient{
+		log:     log,
+		client:  client,
+		baseURL: baseURL,
+		headers: args.Headers,
+		auth:    args.Auth,
+		retry:   args.Retry,
+	}, nil
+}
+
+func validateAuth(auth Auth) error {
+	configured := 0
+	for _, set := range []bool{auth.Basic != nil, auth.Token != nil, auth.OAuth2 != nil} {
+		if set {
+			configured++
+		}
+	}
+	if configured > 1 {
+		return errors.New("only one of basic, token and oauth2 credentials can be provided")
+	}
+	return nil
+}
+
+func newTransport(cfg *TLS) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg == nil {
+		return transport, nil
+	}
+
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipTLSVerify, // #nosec G402
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if cfg.CABundle != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.CABundle)) {
+			return nil, errors.New("while parsing CA bundle: no valid PEM certificates found")
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.ClientCertificate != "" || cfg.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCertificate), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "while loading client certificate")
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsCfg
+	return transport, nil
+}
+
+// Do sends the request and decodes the JSON response body. Requests are retried on connection errors
+// and 5xx responses according to the retry configuration.
+func (c *apiClient) Do(ctx context.Context, method, path string, query *url.Values, body interface{}) (interface{}, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "while marshaling request body")
+		}
+	}
+
+	attempts := c.retry.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := c.retry.Backoff.Duration()
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		out, err := c.do(ctx, method, path, query, payload)
+		if err == nil {
+			return out, nil
+		}
+		if _, ok := err.(retryableError); !ok {
+			return nil, err
+		}
+
+		lastErr = err
+		if attempt == attempts {
+			break
+		}
+
+		c.log.Debug("Retrying request", zap.Int("attempt", attempt), zap.Duration("backoff", backoff), zap.Error(err))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+
+	return nil, errors.Wrapf(lastErr, "while executing request after %d attempts", attempts)
+}
+
+func (c *apiClient) do(ctx context.Context, method, path string, query *url.Values, payload []byte) (interface{}, error) {
+	reqURL, err := c.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	if query != nil {
+		reqURL.RawQuery = query.Encode()
+	}
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), reqBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating request")
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	switch {
+	case c.auth.Basic != nil:
+		req.SetBasicAuth(c.auth.Basic.Username, c.auth.Basic.Password)
+	case c.auth.Token != nil:
+		req.Header.Set("Authorization", "Bearer "+*c.auth.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, retryableError{err: errors.Wrapf(err, "while sending %s request to %q", method, reqURL.Redacted())}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, retryableError{err: errors.Wrap(err, "while reading response body")}
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, retryableError{err: newStatusError(method, reqURL, resp.StatusCode, respBody)}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, newStatusError(method, reqURL, resp.StatusCode, respBody)
+	}
+
+	if len(bytes.TrimSpace(respBody)) == 0 {
+		return nil, nil
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return nil, errors.Wrap(err, "while decoding response body")
+	}
+	return out, nil
+}
+
+func (c *apiClient) resolve(path string) (*url.URL, error) {
+	ref, err := url.Parse(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while parsing path %q", path)
+	}
+	return c.baseURL.ResolveReference(ref), nil
+}
+
+func newStatusError(method string, reqURL *url.URL, statusCode int, body []byte) error {
+	msg := strings.TrimSpace(string(body))
+	return fmt.Errorf("%s %q returned %d %s: %s", method, reqURL.Redacted(), statusCode, http.StatusText(statusCode), msg)
+}
@@ -0,0 +1,119 @@
+package httprunner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const pollPhase = "poll"
+
+// poller polls the status URL until the success or failure condition is met.
+type poller struct {
+	log      *zap.Logger
+	client   *apiClient
+	progress runner.ProgressReportFunc
+}
+
+// Poll returns the last status response body once the success condition is met.
+func (p *poller) Poll(ctx context.Context, poll Poll, initialResponse interface{}) (interface{}, error) {
+	statusURL, err := renderTemplate(poll.URL, initialResponse)
+	if err != nil {
+		return nil, errors.Wrap(err, "while rendering status URL")
+	}
+	statusURL = strings.TrimSpace(statusURL)
+
+	interval := poll.Interval.Duration()
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		body, err := p.client.Do(ctx, http.MethodGet, statusURL, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "while getting status")
+		}
+
+		done, err := conditionMet(poll.SuccessCondition, body)
+		if err != nil {
+			return nil, errors.Wrap(err, "while checking success condition")
+		}
+		if done {
+			return body, nil
+		}
+
+		if poll.FailureCondition != nil {
+			failed, err := conditionMet(*poll.FailureCondition, body)
+			if err != nil {
+				return nil, errors.Wrap(err, "while checking failure condition")
+			}
+			if failed {
+				return nil, fmt.Errorf("failure condition %s=%q met", poll.FailureCondition.JSONPath, poll.FailureCondition.Value)
+			}
+		}
+
+		current, _ := evaluateJSONPath(poll.SuccessCondition.JSONPath, body)
+		p.log.Debug("Success condition not met yet", zap.String("jsonPath", poll.SuccessCondition.JSONPath), zap.String("current", current))
+		p.progress.Report(runner.Progress{
+			Phase:   pollPhase,
+			Message: fmt.Sprintf("waiting for %s=%q, current: %q", poll.SuccessCondition.JSONPath, poll.SuccessCondition.Value, current),
+		})
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// conditionMet returns true if the JSONPath expression evaluated against the data equals the condition value.
+// Missing keys are treated as empty values, as the status may not be populated yet.
+func conditionMet(cond Condition, data interface{}) (bool, error) {
+	if cond.JSONPath == "" {
+		return false, errors.New("JSONPath must be set")
+	}
+
+	got, err := evaluateJSONPath(cond.JSONPath, data)
+	if err != nil {
+		return false, err
+	}
+	return got == cond.Value, nil
+}
+
+func evaluateJSONPath(expr string, data interface{}) (string, error) {
+	jp := jsonpath.New("condition")
+	jp.AllowMissingKeys(true)
+	if err := jp.Parse(expr); err != nil {
+		return "", errors.Wrapf(err, "while parsing JSONPath %q", expr)
+	}
+
+	var buff bytes.Buffer
+	if err := jp.Execute(&buff, data); err != nil {
+		return "", errors.Wrapf(err, "while evaluating JSONPath %q", expr)
+	}
+	return buff.String(), nil
+}
+
+func renderTemplate(tmplData string, data interface{}) (string, error) {
+	tmpl, err := template.New("output").Parse(tmplData)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load template")
+	}
+
+	var buff bytes.Buffer
+	if err := tmpl.Execute(&buff, data); err != nil {
+		return "", errors.Wrap(err, "while rendering template")
+	}
+	return buff.String(), nil
+}
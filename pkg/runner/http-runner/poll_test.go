@@ -0,0 +1,70 @@
+package httprunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionMet(t *testing.T) {
+	data := map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase": "Succeeded",
+			"ready": true,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"name": "first"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		cond     Condition
+		expected bool
+	}{
+		{
+			name:     "string value",
+			cond:     Condition{JSONPath: "{.status.phase}", Value: "Succeeded"},
+			expected: true,
+		},
+		{
+			name:     "boolean value",
+			cond:     Condition{JSONPath: "{.status.ready}", Value: "true"},
+			expected: true,
+		},
+		{
+			name:     "array element",
+			cond:     Condition{JSONPath: "{.items[0].name}", Value: "first"},
+			expected: true,
+		},
+		{
+			name:     "different value",
+			cond:     Condition{JSONPath: "{.status.phase}", Value: "Failed"},
+			expected: false,
+		},
+		{
+			name:     "missing key",
+			cond:     Condition{JSONPath: "{.status.message}", Value: "done"},
+			expected: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got, err := conditionMet(tc.cond, data)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestConditionMetInvalidJSONPath(t *testing.T) {
+	// when
+	_, err := conditionMet(Condition{JSONPath: "{.status[", Value: "x"}, map[string]interface{}{})
+
+	// then
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package httprunner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+var _ runner.SyncRunner = &HTTPRunner{}
+
+// HTTPRunner provides functionality to execute HTTP API calls.
+type HTTPRunner struct {
+	cfg      Config
+	log      *zap.Logger
+	progress runner.ProgressReportFunc
+}
+
+// NewHTTPRunner returns new instance of HTTP API runner.
+func NewHTTPRunner(cfg Config) *HTTPRunner {
+	return &HTTPRunner{
+		cfg: cfg,
+	}
+}
+
+// Run sends an API request, optionally polls the operation status and renders the API response.
+func (r *HTTPRunner) Run(ctx context.Context, in runner.RunInput) (*runner.WaitForCompletionOutput, error) {
+	input, err := r.readInputData(in)
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading input data")
+	}
+
+	return r.Execute(ctx, input)
+}
+
+// Execute runs the HTTP API call for already parsed input. It allows to build API specific runners on top of the HTTP runner.
+// In the dry-run mode, only requests with safe methods, such as GET, are sent.
+func (r *HTTPRunner) Execute(ctx context.Context, input Input) (*runner.WaitForCompletionOutput, error) {
+	args := input.Args
+
+	if input.Ctx.DryRun && !isSafeMethod(args.Method) {
+		r.log.Info("Skipping request in dry-run mode", zap.String("method", args.Method), zap.String("path", args.Path))
+		return &runner.WaitForCompletionOutput{
+			Succeeded: true,
+			Message:   fmt.Sprintf("dry run: %s request not executed", args.Method),
+		}, nil
+	}
+
+	client, err := newAPIClient(ctx, r.log, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating HTTP API client")
+	}
+
+	r.progress.Report(runner.Progress{Phase: args.Method, Message: args.Path})
+	body, err := client.Do(ctx, args.Method, args.Path, args.QueryParameters, args.RequestBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "while executing request")
+	}
+
+	msg := fmt.Sprintf("%s request executed", args.Method)
+	if args.Poll != nil {
+		p := &poller{log: r.log, client: client, progress: r.progress}
+		body, err = p.Poll(ctx, *args.Poll, body)
+		if err != nil {
+			return nil, errors.Wrap(err, "while polling status")
+		}
+		msg = fmt.Sprintf("%s request executed and success condition met", args.Method)
+	}
+
+	artifact, err := r.renderOutput(args.Output.GoTemplate, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "while rendering additional data")
+	}
+
+	if err := r.saveOutput(artifact); err != nil {
+		return nil, err
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   msg,
+	}, nil
+}
+
+// Name returns the runner name.
+func (r *HTTPRunner) Name() string {
+	return "http"
+}
+
+// InjectLogger sets the logger on the runner.
+func (r *HTTPRunner) InjectLogger(logger *zap.Logger) {
+	r.log = logger
+}
+
+// InjectProgressReporter sets the progress reporting function on the runner.
+func (r *HTTPRunner) InjectProgressReporter(fn runner.ProgressReportFunc) {
+	r.progress = fn
+}
+
+func (r *HTTPRunner) saveOutput(data []byte) error {
+	if data == nil {
+		return nil
+	}
+
+	r.log.Debug("Saving additional output", zap.String("path", r.cfg.Output.AdditionalFilePath))
+	err := runner.SaveToFile(r.cfg.Output.AdditionalFilePath, data)
+	if err != nil {
+		return errors.Wrap(err, "while saving default output")
+	}
+
+	return nil
+}
+
+func (r *HTTPRunner) readInputData(in runner.RunInput) (Input, error) {
+	args := DefaultArguments()
+	err := yaml.Unmarshal(in.Args, &args)
+	if err != nil {
+		return Input{}, errors.Wrap(err, "while unmarshalling runner arguments")
+	}
+
+	return Input{
+		Args: args,
+		Ctx:  in.RunnerCtx,
+	}, nil
+}
+
+// isSafeMethod returns true for HTTP methods, which don't modify the server state.
+func isSafeMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func (r *HTTPRunner) renderOutput(artifactTemplate string, data interface{}) ([]byte, error) {
+	if artifactTemplate == "" {
+		return []byte{}, nil
+	}
+
+	out, err := renderTemplate(artifactTemplate, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "while rendering output")
+	}
+	return []byte(out), nil
+}
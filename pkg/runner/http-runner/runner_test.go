@@ -0,0 +1,457 @@
+package httprunner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRunnerSendsRequestWithHeadersAndToken(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/projects", r.URL.Path)
+		assert.Equal(t, "private", r.URL.Query().Get("visibility"))
+		assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "capact", r.Header.Get("X-Request-Source"))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "my-project", body["name"])
+
+		writeJSON(w, `{"id": 42, "name": "my-project"}`)
+	}))
+	defer srv.Close()
+
+	args := fmt.Sprintf(`
+method: POST
+baseURL: %s/api/v1
+path: projects
+headers:
+  X-Request-Source: capact
+queryParameters:
+  visibility: [private]
+auth:
+  token: secret-token
+body:
+  name: my-project
+output:
+  goTemplate: |
+    id: {{ .id }}
+    name: {{ .name }}
+`, srv.URL)
+
+	// when
+	out, additional, err := runHTTPRunner(t, args)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, out.Succeeded)
+	assert.Equal(t, "id: 42\nname: my-project\n", additional)
+}
+
+func TestRunnerRetriesOnServerErrors(t *testing.T) {
+	// given
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, `{"status": "ok"}`)
+	}))
+	defer srv.Close()
+
+	args := fmt.Sprintf(`
+baseURL: %s
+path: health
+retry:
+  attempts: 3
+  backoff: 1ms
+output:
+  goTemplate: "status: {{ .status }}"
+`, srv.URL)
+
+	// when
+	_, additional, err := runHTTPRunner(t, args)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "status: ok", additional)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestRunnerDoesNotRetryOnClientErrors(t *testing.T) {
+	// given
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	args := fmt.Sprintf(`
+baseURL: %s
+path: projects/1
+retry:
+  attempts: 3
+  backoff: 1ms
+`, srv.URL)
+
+	// when
+	_, _, err := runHTTPRunner(t, args)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "returned 404 Not Found")
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestRunnerRejectsMultipleAuthMethods(t *testing.T) {
+	// given
+	args := `
+baseURL: http://localhost
+path: projects
+auth:
+  token: secret-token
+  basic:
+    username: user
+    password: pass
+`
+
+	// when
+	_, _, err := runHTTPRunner(t, args)
+
+	// then
+	assert.EqualError(t, err, "while creating HTTP API client: only one of basic, token and oauth2 credentials can be provided")
+}
+
+func TestRunnerUsesOAuth2ClientCredentials(t *testing.T) {
+	// given
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "client-id", user)
+		assert.Equal(t, "client-secret", pass)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "api", r.PostForm.Get("audience"))
+
+		writeJSON(w, `{"access_token": "oauth2-token", "token_type": "bearer", "expires_in": 3600}`)
+	})
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer oauth2-token", r.Header.Get("Authorization"))
+		writeJSON(w, `{"value": "protected"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	args := fmt.Sprintf(`
+baseURL: %[1]s
+path: resource
+auth:
+  oauth2:
+    tokenURL: %[1]s/token
+    clientID: client-id
+    clientSecret: client-secret
+    endpointParams:
+      audience: api
+output:
+  goTemplate: "value: {{ .value }}"
+`, srv.URL)
+
+	// when
+	_, additional, err := runHTTPRunner(t, args)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "value: protected", additional)
+}
+
+func TestRunnerPollsStatusUntilSuccess(t *testing.T) {
+	// given
+	var polls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/operations", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, `{"id": "op-1"}`)
+	})
+	mux.HandleFunc("/operations/op-1", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&polls, 1) < 3 {
+			writeJSON(w, `{"status": {"phase": "Running"}}`)
+			return
+		}
+		writeJSON(w, `{"status": {"phase": "Succeeded"}, "result": "done"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	args := fmt.Sprintf(`
+method: POST
+baseURL: %s
+path: operations
+poll:
+  url: "operations/{{ .id }}"
+  interval: 1ms
+  successCondition:
+    jsonPath: "{.status.phase}"
+    value: Succeeded
+  failureCondition:
+    jsonPath: "{.status.phase}"
+    value: Failed
+output:
+  goTemplate: "result: {{ .result }}"
+`, srv.URL)
+
+	// when
+	out, additional, err := runHTTPRunner(t, args)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, out.Succeeded)
+	assert.Equal(t, "result: done", additional)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&polls))
+}
+
+func TestRunnerPollsStatusUntilFailure(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, `{"id": "op-1", "status": {"phase": "Failed"}}`)
+	}))
+	defer srv.Close()
+
+	args := fmt.Sprintf(`
+baseURL: %s
+path: operations/op-1
+poll:
+  url: "operations/{{ .id }}"
+  interval: 1ms
+  successCondition:
+    jsonPath: "{.status.phase}"
+    value: Succeeded
+  failureCondition:
+    jsonPath: "{.status.phase}"
+    value: Failed
+`, srv.URL)
+
+	// when
+	_, _, err := runHTTPRunner(t, args)
+
+	// then
+	assert.EqualError(t, err, `while polling status: failure condition {.status.phase}="Failed" met`)
+}
+
+func TestRunnerVerifiesServerWithCABundle(t *testing.T) {
+	// given
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, `{"secure": true}`)
+	}))
+	defer srv.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	t.Run("without CA bundle", func(t *testing.T) {
+		// when
+		_, _, err := runHTTPRunner(t, fmt.Sprintf("baseURL: %s\npath: status\n", srv.URL))
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("with CA bundle", func(t *testing.T) {
+		args := fmt.Sprintf(`
+baseURL: %s
+path: status
+tls:
+  caBundle: %q
+output:
+  goTemplate: "secure: {{ .secure }}"
+`, srv.URL, caBundle)
+
+		// when
+		_, additional, err := runHTTPRunner(t, args)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "secure: true", additional)
+	})
+}
+
+func TestRunnerSendsClientCertificate(t *testing.T) {
+	// given
+	clientCert, clientKey := generateClientCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(clientCert))
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, fmt.Sprintf(`{"client": %q}`, r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	args := fmt.Sprintf(`
+baseURL: %s
+path: whoami
+tls:
+  insecureSkipTLSVerify: true
+  clientCertificate: %q
+  clientKey: %q
+output:
+  goTemplate: "client: {{ .client }}"
+`, srv.URL, clientCert, clientKey)
+
+	// when
+	_, additional, err := runHTTPRunner(t, args)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "client: capact-runner", additional)
+}
+
+func TestRunnerSendsOnlySafeRequestsInDryRun(t *testing.T) {
+	tests := map[string]struct {
+		method     string
+		expCalls   int32
+		expOutput  bool
+		expMessage string
+	}{
+		"Should send GET request": {
+			method:     http.MethodGet,
+			expCalls:   1,
+			expOutput:  true,
+			expMessage: "GET request executed",
+		},
+		"Should not send POST request": {
+			method:     http.MethodPost,
+			expCalls:   0,
+			expOutput:  false,
+			expMessage: "dry run: POST request not executed",
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				writeJSON(w, `{"id": 42}`)
+			}))
+			defer srv.Close()
+
+			outputPath := path.Join(t.TempDir(), "additional.yaml")
+			cfg := Config{}
+			cfg.Output.AdditionalFilePath = outputPath
+
+			r := NewHTTPRunner(cfg)
+			r.InjectLogger(zap.NewNop())
+
+			args := fmt.Sprintf(`
+method: %s
+baseURL: %s
+path: projects
+output:
+  goTemplate: "id: {{ .id }}"
+`, tc.method, srv.URL)
+
+			// when
+			out, err := r.Run(context.Background(), runner.RunInput{
+				RunnerCtx: runner.Context{DryRun: true},
+				Args:      []byte(args),
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, out.Succeeded)
+			assert.Equal(t, tc.expMessage, out.Message)
+			assert.Equal(t, tc.expCalls, atomic.LoadInt32(&calls))
+
+			_, err = ioutil.ReadFile(outputPath)
+			assert.Equal(t, tc.expOutput, err == nil)
+		})
+	}
+}
+
+func runHTTPRunner(t *testing.T, args string) (*runner.WaitForCompletionOutput, string, error) {
+	t.Helper()
+
+	outputPath := path.Join(t.TempDir(), "additional.yaml")
+
+	cfg := Config{}
+	cfg.Output.AdditionalFilePath = outputPath
+
+	r := NewHTTPRunner(cfg)
+	r.InjectLogger(zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	out, err := r.Run(ctx, runner.RunInput{Args: []byte(args)})
+	if err != nil {
+		return out, "", err
+	}
+
+	additional, err := ioutil.ReadFile(outputPath)
+	require.NoError(t, err)
+
+	return out, string(additional), nil
+}
+
+func writeJSON(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(body))
+}
+
+func generateClientCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "capact-runner"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return cert, keyPEM
+}
@@ -0,0 +1,121 @@
+package httprunner
+
+import (
+	"net/url"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+)
+
+const (
+	defaultRetryBackoff = time.Second
+	defaultPollInterval = 5 * time.Second
+)
+
+// Config holds HTTPRunner related configuration.
+type Config struct {
+	Output struct {
+		// Extracting resource data from response body
+		AdditionalFilePath string `envconfig:"default=/tmp/additional.yaml"`
+	}
+}
+
+// Input stores the input configuration for the runner.
+type Input struct {
+	Args Arguments
+	Ctx  runner.Context
+}
+
+// Arguments stores the input arguments for the HTTP runner operation.
+type Arguments struct {
+	Method string `json:"method"`
+	// Path is resolved against the BaseURL. It can be also a full URL.
+	Path            string            `json:"path"`
+	BaseURL         string            `json:"baseURL"`
+	Headers         map[string]string `json:"headers"`
+	RequestBody     interface{}       `json:"body"`
+	QueryParameters *url.Values       `json:"queryParameters"`
+	Auth            Auth              `json:"auth"`
+	TLS             *TLS              `json:"tls"`
+	Retry           Retry             `json:"retry"`
+	Poll            *Poll             `json:"poll"`
+	Output          OutputArgs        `json:"output"`
+}
+
+// DefaultArguments returns Arguments with default values.
+func DefaultArguments() Arguments {
+	return Arguments{
+		Method: "GET",
+		Retry: Retry{
+			Attempts: 1,
+			Backoff:  runner.Duration(defaultRetryBackoff),
+		},
+	}
+}
+
+// Auth holds auth data for the HTTP API. Only one auth method can be set.
+type Auth struct {
+	Basic *BasicAuth `json:"basic"`
+	// Token is sent as the Bearer token in the Authorization header.
+	Token  *string                  `json:"token"`
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2"`
+}
+
+// BasicAuth holds basic auth data.
+type BasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// OAuth2ClientCredentials holds data for the OAuth2 client credentials flow.
+type OAuth2ClientCredentials struct {
+	TokenURL       string            `json:"tokenURL"`
+	ClientID       string            `json:"clientID"`
+	ClientSecret   string            `json:"clientSecret"`
+	Scopes         []string          `json:"scopes"`
+	EndpointParams map[string]string `json:"endpointParams"`
+}
+
+// TLS holds the TLS configuration of the HTTP client.
+type TLS struct {
+	// CABundle holds PEM encoded CA certificates used to verify the server certificate.
+	CABundle string `json:"caBundle"`
+	// ClientCertificate holds PEM encoded client certificate used for the TLS client authentication.
+	ClientCertificate string `json:"clientCertificate"`
+	// ClientKey holds PEM encoded client key used for the TLS client authentication.
+	ClientKey             string `json:"clientKey"`
+	InsecureSkipTLSVerify bool   `json:"insecureSkipTLSVerify"`
+}
+
+// Retry defines how requests are retried on connection errors and 5xx responses.
+type Retry struct {
+	// Attempts is the maximum number of attempts of a single request.
+	Attempts int `json:"attempts"`
+	// Backoff is the delay before the first retry. It is doubled after each retry.
+	Backoff runner.Duration `json:"backoff"`
+}
+
+// Poll defines how to poll the status of an asynchronous operation started by the request.
+type Poll struct {
+	// URL of the status endpoint. It is a Go template rendered with the response body of the request.
+	// Relative URLs are resolved against the BaseURL.
+	URL      string          `json:"url"`
+	Interval runner.Duration `json:"interval"`
+	// SuccessCondition must be met to finish the polling successfully.
+	SuccessCondition Condition `json:"successCondition"`
+	// FailureCondition stops the polling with an error, if met.
+	FailureCondition *Condition `json:"failureCondition"`
+}
+
+// Condition is met when the JSONPath expression evaluated against the response body returns the Value.
+type Condition struct {
+	// JSONPath expression, e.g. `{.status.phase}`.
+	JSONPath string `json:"jsonPath"`
+	Value    string `json:"value"`
+}
+
+// OutputArgs stores input arguments for generating the output artifacts.
+type OutputArgs struct {
+	// GoTemplate is rendered with the response body. If polling is configured, the last status response body is used.
+	GoTemplate string `json:"goTemplate"`
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"capact.io/capact/internal/logger"
 
@@ -23,7 +25,14 @@ type Manager struct {
 }
 
 // NewManager returns new Manager instance.
+// The runner must implement either AsyncRunner or SyncRunner interface.
 func NewManager(runner Runner, statusReporter StatusReporter) (*Manager, error) {
+	switch runner.(type) {
+	case AsyncRunner, SyncRunner:
+	default:
+		return nil, errors.Errorf("runner %q must implement either AsyncRunner or SyncRunner interface", runner.Name())
+	}
+
 	var cfg Config
 	err := envconfig.InitWithPrefix(&cfg, "RUNNER")
 	if err != nil {
@@ -47,6 +56,8 @@ func NewManager(runner Runner, statusReporter StatusReporter) (*Manager, error)
 }
 
 // Execute underlying runner function in a proper order.
+// The given context should be cancelled on SIGTERM, so the runner can be stopped gracefully.
+// Once the context is done, the runner has the configured shutdown grace period to return. Otherwise, it is abandoned.
 func (r *Manager) Execute(ctx context.Context) error {
 	runnerInputData, err := r.readRunnerInput()
 	if err != nil {
@@ -57,32 +68,130 @@ func (r *Manager) Execute(ctx context.Context) error {
 	defer cancel()
 
 	log := r.log.With(zap.String("runner", r.runner.Name()), zap.Bool("dryRun", runnerInputData.Context.DryRun))
+	progressReporterInto(r.progressReportFunc(ctx, log, runnerInputData.Context), r.runner)
+
+	var wout *WaitForCompletionOutput
+	switch runner := r.runner.(type) {
+	case SyncRunner:
+		wout, err = r.run(ctx, log, runner, runnerInputData)
+	case AsyncRunner:
+		wout, err = r.startAndWait(ctx, log, runner, runnerInputData)
+	default:
+		return errors.Errorf("runner %q must implement either AsyncRunner or SyncRunner interface", r.runner.Name())
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Runner job completed",
+		zap.Bool("success", wout.Succeeded),
+		zap.String("message", wout.Message),
+	)
+
+	if wout.Status != nil {
+		reportCtx := ctx
+		if ctx.Err() != nil {
+			// the runner returned within the shutdown grace period, so its final status is still reported
+			var cancelReport context.CancelFunc
+			reportCtx, cancelReport = context.WithTimeout(context.Background(), r.cfg.ShutdownGracePeriod)
+			defer cancelReport()
+		}
+		if err = r.statusReporter.Report(reportCtx, runnerInputData.Context, wout.Status); err != nil {
+			return errors.Wrap(err, "while setting final status")
+		}
+	}
+
+	return wout.ErrorOrNil()
+}
+
+func (r *Manager) run(ctx context.Context, log *zap.Logger, runner SyncRunner, in InputData) (*WaitForCompletionOutput, error) {
+	log.Debug("Running runner")
+	out, err := untilDone(ctx, r.cfg.ShutdownGracePeriod, func() (*WaitForCompletionOutput, error) {
+		return runner.Run(ctx, RunInput{
+			RunnerCtx: in.Context,
+			Args:      in.Args,
+		})
+	})
+	if err != nil {
+		log.Error("while running runner", zap.Error(err))
+		return nil, errors.Wrap(err, "while running action")
+	}
+
+	return out, nil
+}
+
+func (r *Manager) startAndWait(ctx context.Context, log *zap.Logger, runner AsyncRunner, in InputData) (*WaitForCompletionOutput, error) {
 	log.Debug("Starting runner")
-	sout, err := r.runner.Start(ctx, StartInput{
-		RunnerCtx: runnerInputData.Context,
-		Args:      runnerInputData.Args,
+	sout, err := runner.Start(ctx, StartInput{
+		RunnerCtx: in.Context,
+		Args:      in.Args,
 	})
 	if err != nil {
-		return errors.Wrap(err, "while starting action")
+		return nil, errors.Wrap(err, "while starting action")
 	}
 	log.Debug("Runner started", zap.Any("status", sout.Status))
 
-	if err = r.statusReporter.Report(ctx, runnerInputData.Context, sout.Status); err != nil {
-		return errors.Wrap(err, "while setting status")
+	if err = r.statusReporter.Report(ctx, in.Context, sout.Status); err != nil {
+		return nil, errors.Wrap(err, "while setting status")
 	}
 
 	log.Debug("Waiting for runner completion")
-	wout, err := r.runner.WaitForCompletion(ctx, WaitForCompletionInput{RunnerCtx: runnerInputData.Context})
+	wout, err := untilDone(ctx, r.cfg.ShutdownGracePeriod, func() (*WaitForCompletionOutput, error) {
+		return runner.WaitForCompletion(ctx, WaitForCompletionInput{RunnerCtx: in.Context})
+	})
 	if err != nil {
 		log.Error("while waiting for runner completion", zap.Error(err))
-		return errors.Wrap(err, "while waiting for completion")
+		return nil, errors.Wrap(err, "while waiting for completion")
 	}
-	log.Debug("Runner job completed",
-		zap.Bool("success", wout.Succeeded),
-		zap.String("message", wout.Message),
-	)
 
-	return wout.ErrorOrNil()
+	return wout, nil
+}
+
+// untilDone executes a given function and returns its result. Once the context is done, e.g. after SIGTERM
+// or when the runner timeout is exceeded, the function has the grace period to return.
+// Otherwise, the context error is returned.
+func untilDone(ctx context.Context, gracePeriod time.Duration, fn func() (*WaitForCompletionOutput, error)) (*WaitForCompletionOutput, error) {
+	type result struct {
+		out *WaitForCompletionOutput
+		err error
+	}
+
+	resultCh := make(chan result, 1)
+	go func() {
+		out, err := fn()
+		resultCh <- result{out: out, err: err}
+	}()
+
+	select {
+	case res := <-resultCh:
+		return res.out, res.err
+	case <-ctx.Done():
+	}
+
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+
+	select {
+	case res := <-resultCh:
+		return res.out, res.err
+	case <-timer.C:
+		return nil, ctx.Err()
+	}
+}
+
+// progressReportFunc returns a function, which reports progress using the status reporter.
+// Progress may be reported from multiple goroutines, so reports are serialized.
+func (r *Manager) progressReportFunc(ctx context.Context, log *zap.Logger, runnerCtx Context) ProgressReportFunc {
+	var mu sync.Mutex
+	return func(progress Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		log.Debug("Reporting progress", zap.Any("progress", progress))
+		if err := r.statusReporter.ReportProgress(ctx, runnerCtx, progress); err != nil {
+			log.Warn("Cannot report progress", zap.Error(err))
+		}
+	}
 }
 
 func (r *Manager) readRunnerInput() (InputData, error) {
@@ -128,3 +237,15 @@ func loggerInto(log *zap.Logger, runner interface{}) {
 		s.InjectLogger(log)
 	}
 }
+
+// ProgressReporterInjector is used by the Manager to inject progress reporting function to Runner.
+type ProgressReporterInjector interface {
+	InjectProgressReporter(ProgressReportFunc)
+}
+
+// progressReporterInto sets progress reporting function on `runner` if requested.
+func progressReporterInto(fn ProgressReportFunc, runner interface{}) {
+	if s, ok := runner.(ProgressReporterInjector); ok {
+		s.InjectProgressReporter(fn)
+	}
+}
@@ -0,0 +1,181 @@
+package runner
+
+import (
+	"context"
+	"io/ioutil"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManagerExecute(t *testing.T) {
+	tests := []struct {
+		name             string
+		runner           Runner
+		expectedStatuses []interface{}
+	}{
+		{
+			name:             "Synchronous runner",
+			runner:           &fakeSyncRunner{},
+			expectedStatuses: nil,
+		},
+		{
+			name:             "Asynchronous runner",
+			runner:           &fakeAsyncRunner{},
+			expectedStatuses: []interface{}{"started", "finished"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			reporter := &fakeStatusReporter{}
+			mgr := fixManager(t, tt.runner, reporter)
+
+			// when
+			err := mgr.Execute(context.Background())
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatuses, reporter.statuses)
+		})
+	}
+}
+
+func TestManagerExecuteCancelled(t *testing.T) {
+	tests := []struct {
+		name        string
+		runner      Runner
+		expectedErr string
+	}{
+		{
+			name:        "Synchronous runner",
+			runner:      &fakeSyncRunner{block: true},
+			expectedErr: "while running action: context canceled",
+		},
+		{
+			name:        "Asynchronous runner",
+			runner:      &fakeAsyncRunner{block: true},
+			expectedErr: "while waiting for completion: context canceled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mgr := fixManager(t, tt.runner, &fakeStatusReporter{})
+
+			// simulates SIGTERM
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			// when
+			err := mgr.Execute(ctx)
+
+			// then
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestManagerExecuteWaitsForCancelledRunner(t *testing.T) {
+	// given
+	reporter := &fakeStatusReporter{}
+	mgr := fixManager(t, &fakeSyncRunner{stopOnCancel: true}, reporter)
+
+	// simulates SIGTERM
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err := mgr.Execute(ctx)
+
+	// then
+	assert.EqualError(t, err, `finished unsuccessfully [details: "stopped"]`)
+	assert.Equal(t, []interface{}{"stopped"}, reporter.statuses)
+}
+
+func TestNewManagerUnsupportedRunner(t *testing.T) {
+	// when
+	_, err := NewManager(&fakeRunner{}, &fakeStatusReporter{})
+
+	// then
+	assert.EqualError(t, err, `runner "fake" must implement either AsyncRunner or SyncRunner interface`)
+}
+
+func fixManager(t *testing.T, runner Runner, reporter StatusReporter) *Manager {
+	t.Helper()
+
+	dir := t.TempDir()
+	ctxPath, argsPath := path.Join(dir, "context.yaml"), path.Join(dir, "args.yaml")
+	require.NoError(t, ioutil.WriteFile(ctxPath, []byte(`{"name": "test", "timeout": "1m"}`), 0600))
+	require.NoError(t, ioutil.WriteFile(argsPath, []byte(`{}`), 0600))
+
+	return &Manager{
+		runner: runner,
+		cfg: Config{
+			ContextPath: ctxPath,
+			ArgsPath:    argsPath,
+			// the runner returns immediately after cancellation, or never
+			ShutdownGracePeriod: 100 * time.Millisecond,
+		},
+		log:            zap.NewNop(),
+		statusReporter: reporter,
+	}
+}
+
+type fakeRunner struct{}
+
+func (r *fakeRunner) Name() string {
+	return "fake"
+}
+
+type fakeSyncRunner struct {
+	fakeRunner
+	block        bool
+	stopOnCancel bool
+}
+
+func (r *fakeSyncRunner) Run(ctx context.Context, _ RunInput) (*WaitForCompletionOutput, error) {
+	if r.block {
+		// ignores the context cancellation on purpose
+		select {}
+	}
+	if r.stopOnCancel {
+		<-ctx.Done()
+		return &WaitForCompletionOutput{Succeeded: false, Message: "stopped", Status: "stopped"}, nil
+	}
+	return &WaitForCompletionOutput{Succeeded: true}, nil
+}
+
+type fakeAsyncRunner struct {
+	fakeRunner
+	block bool
+}
+
+func (r *fakeAsyncRunner) Start(_ context.Context, _ StartInput) (*StartOutput, error) {
+	return &StartOutput{Status: "started"}, nil
+}
+
+func (r *fakeAsyncRunner) WaitForCompletion(ctx context.Context, _ WaitForCompletionInput) (*WaitForCompletionOutput, error) {
+	if r.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &WaitForCompletionOutput{Succeeded: true, Status: "finished"}, nil
+}
+
+type fakeStatusReporter struct {
+	statuses []interface{}
+}
+
+func (r *fakeStatusReporter) Report(_ context.Context, _ Context, status interface{}) error {
+	r.statuses = append(r.statuses, status)
+	return nil
+}
+
+func (r *fakeStatusReporter) ReportProgress(_ context.Context, _ Context, _ Progress) error {
+	return nil
+}
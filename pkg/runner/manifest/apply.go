@@ -0,0 +1,311 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	applyPhase = "apply"
+	prunePhase = "prune"
+	waitPhase  = "wait"
+
+	readinessPollInterval = 2 * time.Second
+)
+
+// applier applies objects with the server-side apply, prunes stale objects and waits for the objects readiness.
+type applier struct {
+	log      *zap.Logger
+	client   client.Client
+	mapper   meta.RESTMapper
+	progress runner.ProgressReportFunc
+}
+
+func newApplier(log *zap.Logger, cfg *rest.Config, progress runner.ProgressReportFunc) (*applier, error) {
+	mapper, err := apiutil.NewDynamicRESTMapper(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating REST mapper")
+	}
+
+	cli, err := client.New(cfg, client.Options{Mapper: mapper})
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating Kubernetes client")
+	}
+
+	return &applier{
+		log:      log,
+		client:   cli,
+		mapper:   mapper,
+		progress: progress,
+	}, nil
+}
+
+// Apply applies the objects labeled with a given inventory name. Namespaced objects without namespace
+// are created in a given default namespace. It returns references to all applied objects.
+func (a *applier) Apply(ctx context.Context, inventoryName, namespace string, objs []*unstructured.Unstructured, dryRun bool) ([]ObjectRef, error) {
+	opts := []client.PatchOption{client.FieldOwner(FieldManager), client.ForceOwnership}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	refs := make([]ObjectRef, 0, len(objs))
+	for i, obj := range objs {
+		if err := a.setDefaultNamespace(obj, namespace); err != nil {
+			return nil, err
+		}
+		setInventoryLabel(obj, inventoryName)
+		obj.SetResourceVersion("")
+
+		ref := objectRefFor(obj)
+		a.log.Debug("Applying object", zap.String("object", ref.String()))
+		if err := a.client.Patch(ctx, obj, client.Apply, opts...); err != nil {
+			return nil, errors.Wrapf(err, "while applying %s", ref)
+		}
+		refs = append(refs, ref)
+
+		a.progress.Report(runner.Progress{
+			Phase:     applyPhase,
+			Message:   fmt.Sprintf("applied %s", ref),
+			Completed: i + 1,
+			Total:     len(objs),
+		})
+	}
+
+	return refs, nil
+}
+
+// Prune deletes live objects labeled with a given inventory name, which are not a part of the current inventory.
+// The objects are listed by the inventory label for all kinds and namespaces used in the previous and the current inventory,
+// so also the objects applied by an interrupted run, which didn't save the inventory, are pruned.
+// It returns references to all pruned objects.
+func (a *applier) Prune(ctx context.Context, inventoryName string, previous, current []ObjectRef, dryRun bool) ([]ObjectRef, error) {
+	live, err := a.listInventoryObjects(ctx, inventoryName, append(previous, current...))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []client.DeleteOption{client.PropagationPolicy(metav1.DeletePropagationBackground)}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	stale := staleObjects(live, current)
+	for i, obj := range stale {
+		ref := objectRefFor(obj)
+		a.log.Debug("Pruning object", zap.String("object", ref.String()))
+		if err := a.client.Delete(ctx, obj, opts...); client.IgnoreNotFound(err) != nil {
+			return nil, errors.Wrapf(err, "while deleting %s", ref)
+		}
+
+		a.progress.Report(runner.Progress{
+			Phase:     prunePhase,
+			Message:   fmt.Sprintf("pruned %s", ref),
+			Completed: i + 1,
+			Total:     len(stale),
+		})
+	}
+
+	out := make([]ObjectRef, 0, len(stale))
+	for _, obj := range stale {
+		out = append(out, objectRefFor(obj))
+	}
+	return out, nil
+}
+
+// listInventoryObjects lists objects labeled with a given inventory name
+// for each API version, kind and namespace of the given object references.
+func (a *applier) listInventoryObjects(ctx context.Context, inventoryName string, refs []ObjectRef) ([]*unstructured.Unstructured, error) {
+	var out []*unstructured.Unstructured
+	for _, scope := range listScopes(refs) {
+		list := &unstructured.UnstructuredList{}
+		list.SetAPIVersion(scope.APIVersion)
+		list.SetKind(scope.Kind + "List")
+
+		err := a.client.List(ctx, list, client.InNamespace(scope.Namespace), client.MatchingLabels{InventoryLabelKey: inventoryName})
+		switch {
+		case err == nil:
+		case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+			a.log.Debug("Skipping listing of not served kind", zap.String("apiVersion", scope.APIVersion), zap.String("kind", scope.Kind))
+			continue
+		default:
+			return nil, errors.Wrapf(err, "while listing %s objects of inventory %q", scope.Kind, inventoryName)
+		}
+
+		for i := range list.Items {
+			out = append(out, &list.Items[i])
+		}
+	}
+
+	return out, nil
+}
+
+// WaitForReady blocks until all given objects are ready or the context is done.
+func (a *applier) WaitForReady(ctx context.Context, refs []ObjectRef) error {
+	for i, ref := range refs {
+		a.log.Debug("Waiting for object readiness", zap.String("object", ref.String()))
+		err := wait.PollImmediateUntil(readinessPollInterval, func() (bool, error) {
+			obj, err := a.get(ctx, ref)
+			if err != nil {
+				return false, errors.Wrapf(err, "while getting %s", ref)
+			}
+			ready, err := isReady(obj)
+			if err != nil || ready {
+				return ready, err
+			}
+			return a.isWaitingForFirstConsumer(ctx, obj)
+		}, ctx.Done())
+		if err != nil {
+			return errors.Wrapf(err, "while waiting for %s readiness", ref)
+		}
+
+		a.progress.Report(runner.Progress{
+			Phase:     waitPhase,
+			Message:   fmt.Sprintf("%s is ready", ref),
+			Completed: i + 1,
+			Total:     len(refs),
+		})
+	}
+
+	return nil
+}
+
+// isWaitingForFirstConsumer returns true if a given PersistentVolumeClaim is pending, because its StorageClass
+// binds volumes only when a Pod uses the claim. Such claims are treated as ready, as they are not bound until
+// the workload, which uses them, is scheduled.
+func (a *applier) isWaitingForFirstConsumer(ctx context.Context, obj *unstructured.Unstructured) (bool, error) {
+	if obj.GroupVersionKind().GroupKind().String() != "PersistentVolumeClaim" {
+		return false, nil
+	}
+
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	storageClassName, _, _ := unstructured.NestedString(obj.Object, "spec", "storageClassName")
+	if phase != "Pending" || storageClassName == "" {
+		return false, nil
+	}
+
+	storageClassRef := ObjectRef{APIVersion: "storage.k8s.io/v1", Kind: "StorageClass", Name: storageClassName}
+	storageClass, err := a.get(ctx, storageClassRef)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "while getting %s", storageClassRef)
+	}
+
+	bindingMode, _, _ := unstructured.NestedString(storageClass.Object, "volumeBindingMode")
+	return bindingMode == "WaitForFirstConsumer", nil
+}
+
+func (a *applier) get(ctx context.Context, ref ObjectRef) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+
+	err := a.client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, obj)
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (a *applier) setDefaultNamespace(obj *unstructured.Unstructured, namespace string) error {
+	gvk := obj.GroupVersionKind()
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return errors.Wrapf(err, "while getting REST mapping for %s", gvk)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj.SetNamespace("")
+		return nil
+	}
+
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(namespace)
+	}
+	return nil
+}
+
+func setInventoryLabel(obj *unstructured.Unstructured, inventoryName string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[InventoryLabelKey] = inventoryName
+	obj.SetLabels(labels)
+}
+
+func objectRefFor(obj *unstructured.Unstructured) ObjectRef {
+	return ObjectRef{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+// staleObjects returns live objects, which are not present in the current inventory.
+// The API version is ignored, so objects migrated to a different version of the same API group are not pruned,
+// and the same object listed with different API versions is returned only once.
+func staleObjects(live []*unstructured.Unstructured, current []ObjectRef) []*unstructured.Unstructured {
+	skipKeys := map[string]struct{}{}
+	for _, ref := range current {
+		skipKeys[ref.key()] = struct{}{}
+	}
+
+	var out []*unstructured.Unstructured
+	for _, obj := range live {
+		key := objectRefFor(obj).key()
+		if _, found := skipKeys[key]; found {
+			continue
+		}
+		skipKeys[key] = struct{}{}
+		out = append(out, obj)
+	}
+	return out
+}
+
+// listScopes returns unique API version, kind and namespace triples of given object references.
+// The name of the returned references is empty.
+func listScopes(refs []ObjectRef) []ObjectRef {
+	seen := map[ObjectRef]struct{}{}
+
+	var out []ObjectRef
+	for _, ref := range refs {
+		scope := ObjectRef{APIVersion: ref.APIVersion, Kind: ref.Kind, Namespace: ref.Namespace}
+		if _, found := seen[scope]; found {
+			continue
+		}
+		seen[scope] = struct{}{}
+		out = append(out, scope)
+	}
+	return out
+}
+
+// String returns a human readable representation of the object reference.
+func (r ObjectRef) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %q", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %q in namespace %q", r.Kind, r.Name, r.Namespace)
+}
+
+func (r ObjectRef) key() string {
+	gv, _ := schema.ParseGroupVersion(r.APIVersion)
+	return fmt.Sprintf("%s/%s/%s/%s", gv.Group, r.Kind, r.Namespace, r.Name)
+}
@@ -0,0 +1,191 @@
+package manifest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint:staticcheck
+)
+
+func TestStaleObjects(t *testing.T) {
+	// given
+	live := []*unstructured.Unstructured{
+		fixUnstructured("v1", "ConfigMap", "default", "app-config"),
+		fixUnstructured("v1", "Secret", "default", "app-creds"),
+		fixUnstructured("networking.k8s.io/v1beta1", "Ingress", "default", "app"),
+		fixUnstructured("rbac.authorization.k8s.io/v1", "ClusterRole", "", "app"),
+		fixUnstructured("rbac.authorization.k8s.io/v1beta1", "ClusterRole", "", "app"),
+	}
+	current := []ObjectRef{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "app-config"},
+		{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Namespace: "default", Name: "app"},
+		{APIVersion: "v1", Kind: "Secret", Namespace: "other", Name: "app-creds"},
+	}
+
+	// when
+	stale := staleObjects(live, current)
+
+	// then
+	assert.Equal(t, []*unstructured.Unstructured{
+		fixUnstructured("v1", "Secret", "default", "app-creds"),
+		fixUnstructured("rbac.authorization.k8s.io/v1", "ClusterRole", "", "app"),
+	}, stale)
+}
+
+func TestListScopes(t *testing.T) {
+	// given
+	refs := []ObjectRef{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "app-config"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "app-env"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "other", Name: "app-config"},
+		{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "app"},
+	}
+
+	// when
+	scopes := listScopes(refs)
+
+	// then
+	assert.Equal(t, []ObjectRef{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "other"},
+		{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+	}, scopes)
+}
+
+func TestApplierPrune(t *testing.T) {
+	// given
+	ctx := context.Background()
+	k8sCli := fake.NewClientBuilder().WithObjects(
+		fixConfigMap("current", "inventory"),
+		// applied by an interrupted run, so it's not in the previous inventory
+		fixConfigMap("not-in-inventory", "inventory"),
+		fixConfigMap("other-inventory", "other"),
+		fixConfigMap("not-labeled", ""),
+	).Build()
+	a := &applier{log: zap.NewNop(), client: k8sCli}
+
+	previous := []ObjectRef{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "other-inventory"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "deleted"},
+	}
+	current := []ObjectRef{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "current"},
+	}
+
+	// when
+	pruned, err := a.Prune(ctx, "inventory", previous, current, false)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []ObjectRef{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "not-in-inventory"},
+	}, pruned)
+
+	for name, expExists := range map[string]bool{
+		"current":          true,
+		"not-in-inventory": false,
+		"other-inventory":  true,
+		"not-labeled":      true,
+	} {
+		err := k8sCli.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &corev1.ConfigMap{})
+		if expExists {
+			assert.NoError(t, err, "ConfigMap %q should exist", name)
+			continue
+		}
+		assert.True(t, apierrors.IsNotFound(err), "ConfigMap %q should be pruned", name)
+	}
+}
+
+func TestApplierIsWaitingForFirstConsumer(t *testing.T) {
+	tests := map[string]struct {
+		storageClassName string
+		phase            corev1.PersistentVolumeClaimPhase
+		expWaiting       bool
+	}{
+		"Pending claim with WaitForFirstConsumer binding mode should be waiting": {
+			storageClassName: "local-path",
+			phase:            corev1.ClaimPending,
+			expWaiting:       true,
+		},
+		"Pending claim with Immediate binding mode should not be waiting": {
+			storageClassName: "standard",
+			phase:            corev1.ClaimPending,
+		},
+		"Pending claim without StorageClass should not be waiting": {
+			phase: corev1.ClaimPending,
+		},
+		"Pending claim with not existing StorageClass should not be waiting": {
+			storageClassName: "not-existing",
+			phase:            corev1.ClaimPending,
+		},
+		"Lost claim should not be waiting": {
+			storageClassName: "local-path",
+			phase:            corev1.ClaimLost,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			k8sCli := fake.NewClientBuilder().WithObjects(
+				fixStorageClass("local-path", storagev1.VolumeBindingWaitForFirstConsumer),
+				fixStorageClass("standard", storagev1.VolumeBindingImmediate),
+			).Build()
+			a := &applier{log: zap.NewNop(), client: k8sCli}
+
+			pvc := fixUnstructured("v1", "PersistentVolumeClaim", "default", "data")
+			if tc.storageClassName != "" {
+				require.NoError(t, unstructured.SetNestedField(pvc.Object, tc.storageClassName, "spec", "storageClassName"))
+			}
+			require.NoError(t, unstructured.SetNestedField(pvc.Object, string(tc.phase), "status", "phase"))
+
+			// when
+			waiting, err := a.isWaitingForFirstConsumer(ctx, pvc)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expWaiting, waiting)
+		})
+	}
+}
+
+func fixUnstructured(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func fixConfigMap(name, inventoryName string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+	}
+	if inventoryName != "" {
+		cm.Labels = map[string]string{InventoryLabelKey: inventoryName}
+	}
+	return cm
+}
+
+func fixStorageClass(name string, bindingMode storagev1.VolumeBindingMode) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Provisioner:       "rancher.io/local-path",
+		VolumeBindingMode: &bindingMode,
+	}
+}
@@ -0,0 +1,53 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+)
+
+// KubeconfigTypeInstanceFieldKey defines property name under which the kubeconfig is stored in TypeInstance.
+const KubeconfigTypeInstanceFieldKey = "config"
+
+// loadKubeconfig returns the kubeconfig from the optional TypeInstance if it exists.
+// Otherwise, the default loading rules are used.
+func loadKubeconfig(path string, log *zap.Logger) (*rest.Config, error) {
+	if path == "" {
+		log.Debug("optional Kubeconfig TI not specified")
+		return config.GetConfig()
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		log.Debug("optional Kubeconfig TI specified but file does not exist")
+		return config.GetConfig()
+	default:
+		return nil, errors.Wrap(err, "while reading Kubeconfig TI")
+	}
+
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling Kubeconfig TI")
+	}
+
+	rawKubeconfig, found := raw[KubeconfigTypeInstanceFieldKey]
+	if !found {
+		return nil, fmt.Errorf("TypeInstance doesn't have %q field", KubeconfigTypeInstanceFieldKey)
+	}
+
+	kcfg, err := yaml.Marshal(rawKubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling kubeconfig")
+	}
+
+	return clientcmd.RESTConfigFromKubeConfig(kcfg)
+}
@@ -0,0 +1,84 @@
+package manifest
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// isReady checks whether a given object is ready to use.
+// Only well-known kinds are checked. Other objects are ready as soon as they are applied.
+// Error is returned if the object will never become ready, e.g. the Job failed.
+func isReady(obj *unstructured.Unstructured) (bool, error) {
+	observedGeneration, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if found && observedGeneration < obj.GetGeneration() {
+		return false, nil
+	}
+
+	switch obj.GroupVersionKind().GroupKind().String() {
+	case "Deployment.apps":
+		replicas := desiredReplicas(obj)
+		updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+		available, _, _ := unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+		return updated >= replicas && available >= replicas, nil
+	case "StatefulSet.apps":
+		replicas := desiredReplicas(obj)
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		return ready >= replicas, nil
+	case "DaemonSet.apps":
+		desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberReady")
+		return updated >= desired && ready >= desired, nil
+	case "Pod":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		switch phase {
+		case "Succeeded":
+			return true, nil
+		case "Failed":
+			return false, fmt.Errorf("pod %q failed", obj.GetName())
+		}
+		return hasCondition(obj, "Ready"), nil
+	case "Job.batch":
+		if hasCondition(obj, "Failed") {
+			return false, fmt.Errorf("job %q failed", obj.GetName())
+		}
+		return hasCondition(obj, "Complete"), nil
+	case "PersistentVolumeClaim":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		return phase == "Bound", nil
+	case "Service":
+		svcType, _, _ := unstructured.NestedString(obj.Object, "spec", "type")
+		if svcType != "LoadBalancer" {
+			return true, nil
+		}
+		ingress, _, _ := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
+		return len(ingress) > 0, nil
+	case "CustomResourceDefinition.apiextensions.k8s.io":
+		return hasCondition(obj, "Established"), nil
+	}
+
+	return true, nil
+}
+
+func desiredReplicas(obj *unstructured.Unstructured) int64 {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return replicas
+}
+
+func hasCondition(obj *unstructured.Unstructured, condType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, item := range conditions {
+		cond, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == condType && cond["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestIsReady(t *testing.T) {
+	tests := map[string]struct {
+		obj      string
+		expReady bool
+		expErr   string
+	}{
+		"Deployment with all replicas available should be ready": {
+			obj: `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: app, generation: 2}
+spec: {replicas: 2}
+status: {observedGeneration: 2, updatedReplicas: 2, availableReplicas: 2}`,
+			expReady: true,
+		},
+		"Deployment with not observed generation should not be ready": {
+			obj: `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: app, generation: 3}
+spec: {replicas: 2}
+status: {observedGeneration: 2, updatedReplicas: 2, availableReplicas: 2}`,
+			expReady: false,
+		},
+		"Deployment without default replicas available should not be ready": {
+			obj: `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: app}
+status: {updatedReplicas: 1}`,
+			expReady: false,
+		},
+		"Job with Complete condition should be ready": {
+			obj: `
+apiVersion: batch/v1
+kind: Job
+metadata: {name: migrate}
+status:
+  conditions:
+  - {type: Complete, status: "True"}`,
+			expReady: true,
+		},
+		"Failed Job should return error": {
+			obj: `
+apiVersion: batch/v1
+kind: Job
+metadata: {name: migrate}
+status:
+  conditions:
+  - {type: Failed, status: "True"}`,
+			expErr: `job "migrate" failed`,
+		},
+		"Pending PersistentVolumeClaim should not be ready": {
+			obj: `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata: {name: data}
+status: {phase: Pending}`,
+			expReady: false,
+		},
+		"LoadBalancer Service without ingress should not be ready": {
+			obj: `
+apiVersion: v1
+kind: Service
+metadata: {name: app}
+spec: {type: LoadBalancer}`,
+			expReady: false,
+		},
+		"ConfigMap should be ready": {
+			obj: `
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: app-config}`,
+			expReady: true,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			data, err := yaml.YAMLToJSON([]byte(tc.obj))
+			require.NoError(t, err)
+
+			obj := &unstructured.Unstructured{}
+			require.NoError(t, obj.UnmarshalJSON(data))
+
+			// when
+			ready, err := isReady(obj)
+
+			// then
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expReady, ready)
+		})
+	}
+}
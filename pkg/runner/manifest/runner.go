@@ -0,0 +1,203 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"capact.io/capact/internal/getter"
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const downloadPhase = "download"
+
+var _ runner.SyncRunner = &manifestRunner{}
+
+// manifestRunner provides functionality to apply Kubernetes manifests and kustomizations.
+type manifestRunner struct {
+	cfg      Config
+	log      *zap.Logger
+	progress runner.ProgressReportFunc
+}
+
+// NewRunner returns a new Kubernetes manifest runner instance.
+func NewRunner(cfg Config) runner.SyncRunner {
+	return &manifestRunner{
+		cfg: cfg,
+	}
+}
+
+// Run applies the manifests from the source, prunes stale objects and waits until all objects are ready.
+func (r *manifestRunner) Run(ctx context.Context, in runner.RunInput) (*runner.WaitForCompletionOutput, error) {
+	args := DefaultArguments()
+	if err := yaml.Unmarshal(in.Args, &args); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling runner arguments")
+	}
+
+	if args.Source == "" {
+		return nil, errors.New("source of the manifests is required")
+	}
+
+	previous, err := r.loadPreviousInventory()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading previous inventory")
+	}
+
+	inventory := Inventory{
+		Name:      firstNonEmpty(args.Name, previous.Name, in.RunnerCtx.Name),
+		Namespace: firstNonEmpty(args.Namespace, in.RunnerCtx.Platform.Namespace),
+		Source:    args.Source,
+	}
+
+	// go-getter is using envs, so setting them globally
+	// it can be used to set credentials or paths to credentials
+	if err := r.setEnvVars(args.Env); err != nil {
+		return nil, errors.Wrap(err, "while proceeding Environment variables")
+	}
+
+	objs, err := r.download(ctx, args.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	k8sCfg, err := loadKubeconfig(r.cfg.OptionalKubeconfigTI, r.log)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading kubeconfig")
+	}
+
+	k8sApplier, err := newApplier(r.log, k8sCfg, r.progress)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun := in.RunnerCtx.DryRun
+	inventory.Objects, err = k8sApplier.Apply(ctx, inventory.Name, inventory.Namespace, objs, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []ObjectRef
+	if args.Prune {
+		pruned, err = k8sApplier.Prune(ctx, inventory.Name, previous.Objects, inventory.Objects, dryRun)
+		if err != nil {
+			return nil, errors.Wrap(err, "while pruning stale objects")
+		}
+	}
+
+	if args.Wait && !dryRun {
+		if err := k8sApplier.WaitForReady(ctx, inventory.Objects); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.saveInventory(inventory); err != nil {
+		return nil, errors.Wrap(err, "while saving inventory")
+	}
+
+	msg := fmt.Sprintf("applied %d and pruned %d objects of inventory %q", len(inventory.Objects), len(pruned), inventory.Name)
+	if dryRun {
+		msg = "dry run: " + msg
+	}
+
+	return &runner.WaitForCompletionOutput{Succeeded: true, Message: msg}, nil
+}
+
+// Name returns the runner name.
+func (r *manifestRunner) Name() string {
+	return "manifest"
+}
+
+// InjectLogger sets the logger on the runner.
+func (r *manifestRunner) InjectLogger(logger *zap.Logger) {
+	r.log = logger
+}
+
+// InjectProgressReporter sets the progress reporting function on the runner.
+func (r *manifestRunner) InjectProgressReporter(fn runner.ProgressReportFunc) {
+	r.progress = fn
+}
+
+func (r *manifestRunner) download(ctx context.Context, source string) ([]*unstructured.Unstructured, error) {
+	r.progress.Report(runner.Progress{Phase: downloadPhase, Message: source})
+
+	// in case of git repository as a source, the download needs to be done in empty directory
+	r.log.Debug("Downloading source into workdir", zap.String("workdir", r.cfg.WorkDir))
+	_, err := os.Stat(r.cfg.WorkDir)
+	if strings.HasPrefix(source, "git") && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("the workdir directory %q must not exist when cloning git repository", r.cfg.WorkDir)
+	}
+
+	if err := getter.Download(ctx, source, r.cfg.WorkDir, nil); err != nil {
+		return nil, errors.Wrap(err, "while downloading manifests")
+	}
+
+	objs, err := loadObjects(r.cfg.WorkDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading manifests")
+	}
+	return objs, nil
+}
+
+func (r *manifestRunner) loadPreviousInventory() (Inventory, error) {
+	if r.cfg.OptionalInventoryTI == "" {
+		r.log.Debug("optional Inventory TI not specified")
+		return Inventory{}, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(r.cfg.OptionalInventoryTI))
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		r.log.Debug("optional Inventory TI specified but file does not exist")
+		return Inventory{}, nil
+	default:
+		return Inventory{}, errors.Wrapf(err, "while reading file %q", r.cfg.OptionalInventoryTI)
+	}
+
+	var inventory Inventory
+	if err := yaml.Unmarshal(data, &inventory); err != nil {
+		return Inventory{}, errors.Wrap(err, "while unmarshaling Inventory")
+	}
+	return inventory, nil
+}
+
+func (r *manifestRunner) saveInventory(inventory Inventory) error {
+	data, err := yaml.Marshal(inventory)
+	if err != nil {
+		return errors.Wrap(err, "while marshaling inventory")
+	}
+
+	r.log.Debug("Saving inventory output", zap.String("path", r.cfg.Output.InventoryFilePath))
+	return runner.SaveToFile(r.cfg.Output.InventoryFilePath, data)
+}
+
+func (r *manifestRunner) setEnvVars(env []string) error {
+	for _, e := range env {
+		s := strings.SplitN(e, "=", 2)
+		if len(s) < 2 {
+			return fmt.Errorf("invalid env variable %s", e)
+		}
+		k, v := s[0], s[1]
+
+		if err := os.Setenv(k, v); err != nil {
+			return errors.Wrapf(err, "while setting env %s", k)
+		}
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+package manifest
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/kustomize/api/filesys"
+	"sigs.k8s.io/kustomize/api/krusty"
+)
+
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// loadObjects returns objects defined in a given directory.
+// If the directory contains a kustomization file, the kustomization is built.
+// Otherwise, all YAML and JSON files from the directory and its subdirectories are read.
+func loadObjects(dir string) ([]*unstructured.Unstructured, error) {
+	isKustomization, err := hasKustomization(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if isKustomization {
+		data, err := buildKustomization(dir)
+		if err != nil {
+			return nil, err
+		}
+		return decodeObjects(bytes.NewReader(data))
+	}
+
+	var files []string
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// skip hidden directories, e.g. `.git` from cloned repository
+			if path != dir && info.Name()[0] == '.' {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while listing manifest files in %q", dir)
+	}
+	sort.Strings(files)
+
+	var out []*unstructured.Unstructured
+	for _, path := range files {
+		objs, err := decodeFile(path)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, objs...)
+	}
+
+	return out, nil
+}
+
+func hasKustomization(dir string) (bool, error) {
+	for _, name := range kustomizationFileNames {
+		_, err := os.Stat(filepath.Join(dir, name))
+		switch {
+		case err == nil:
+			return true, nil
+		case os.IsNotExist(err):
+		default:
+			return false, errors.Wrapf(err, "while checking kustomization file %q", name)
+		}
+	}
+	return false, nil
+}
+
+func buildKustomization(dir string) ([]byte, error) {
+	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := k.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while building kustomization %q", dir)
+	}
+
+	data, err := resMap.AsYaml()
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling kustomization output")
+	}
+	return data, nil
+}
+
+func decodeFile(path string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "while opening file %q", path)
+	}
+	defer f.Close()
+
+	objs, err := decodeObjects(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while decoding file %q", path)
+	}
+	return objs, nil
+}
+
+// decodeObjects decodes a multi-document YAML or JSON stream. Empty documents are skipped
+// and `List` objects are flattened.
+func decodeObjects(r io.Reader) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	var out []*unstructured.Unstructured
+	for {
+		obj := &unstructured.Unstructured{}
+		err := decoder.Decode(&obj.Object)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "while decoding object")
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if obj.IsList() {
+			err := obj.EachListItem(func(item runtime.Object) error {
+				out = append(out, item.(*unstructured.Unstructured))
+				return nil
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "while reading List items")
+			}
+			continue
+		}
+
+		if obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, errors.Errorf("object %v must have kind and name", obj.Object)
+		}
+		out = append(out, obj)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,86 @@
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadObjects(t *testing.T) {
+	tests := map[string]struct {
+		dir     string
+		expRefs []ObjectRef
+	}{
+		"Should read all manifest files from directory": {
+			dir: "testdata/plain",
+			expRefs: []ObjectRef{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "app-config"},
+				{APIVersion: "v1", Kind: "Service", Name: "app"},
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"},
+			},
+		},
+		"Should build kustomization": {
+			dir: "testdata/kustomization",
+			expRefs: []ObjectRef{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "dev-app-config"},
+			},
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			objs, err := loadObjects(tc.dir)
+
+			// then
+			require.NoError(t, err)
+
+			var gotRefs []ObjectRef
+			for _, obj := range objs {
+				gotRefs = append(gotRefs, objectRefFor(obj))
+			}
+			assert.Equal(t, tc.expRefs, gotRefs)
+		})
+	}
+}
+
+func TestDecodeObjects(t *testing.T) {
+	t.Run("Should flatten List objects", func(t *testing.T) {
+		// given
+		in := `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: first
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: second
+`
+		// when
+		objs, err := decodeObjects(strings.NewReader(in))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, objs, 2)
+		assert.Equal(t, "first", objs[0].GetName())
+		assert.Equal(t, "second", objs[1].GetName())
+	})
+
+	t.Run("Should return error for object without name", func(t *testing.T) {
+		// given
+		in := `
+apiVersion: v1
+kind: ConfigMap
+`
+		// when
+		_, err := decodeObjects(strings.NewReader(in))
+
+		// then
+		assert.EqualError(t, err, `object map[apiVersion:v1 kind:ConfigMap] must have kind and name`)
+	})
+}
@@ -0,0 +1,63 @@
+package manifest
+
+const (
+	// InventoryLabelKey is the label set on all applied objects. Its value is the inventory name.
+	// It is used to find objects, which can be pruned.
+	InventoryLabelKey = "manifest.runner.capact.io/inventory"
+	// FieldManager is the name of the field manager used for the server-side apply.
+	FieldManager = "capact-manifest-runner"
+)
+
+// Config holds Runner related configuration.
+type Config struct {
+	OptionalKubeconfigTI string `envconfig:"optional"`
+	// OptionalInventoryTI is a path to the Inventory TypeInstance from a previous apply.
+	// Kinds and namespaces of objects listed there are searched for objects to prune,
+	// in addition to the ones from the current source.
+	OptionalInventoryTI string `envconfig:"optional"`
+	WorkDir             string `envconfig:"default=/workspace"`
+	Output              struct {
+		InventoryFilePath string `envconfig:"default=/tmp/inventory.yaml"`
+	}
+}
+
+// Arguments stores the input arguments for the runner operation.
+type Arguments struct {
+	// Name of the inventory. If not set, the runner context name is used.
+	Name string `json:"name"`
+	// Source holds the go-getter URL of the directory with manifests or a kustomization.
+	Source string `json:"source"`
+	// Namespace is used for namespaced objects without the namespace set.
+	// If not set, the runner context namespace is used.
+	Namespace string   `json:"namespace"`
+	Env       []string `json:"env"`
+	// Prune enables removing objects, which were applied previously, but are not a part of the source anymore.
+	Prune bool `json:"prune"`
+	// Wait enables waiting until all applied objects are ready.
+	Wait bool `json:"wait"`
+}
+
+// DefaultArguments returns Arguments with default values.
+func DefaultArguments() Arguments {
+	return Arguments{
+		Prune: true,
+		Wait:  true,
+	}
+}
+
+// Inventory describes the set of objects applied by the runner.
+// It is emitted as a TypeInstance, so the following apply can prune stale objects.
+type Inventory struct {
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace"`
+	Source    string      `json:"source"`
+	Objects   []ObjectRef `json:"objects"`
+}
+
+// ObjectRef identifies a single Kubernetes object.
+type ObjectRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
@@ -22,3 +22,8 @@ func NewNoop() *NoopReporter {
 func (n NoopReporter) Report(ctx context.Context, runnerCtx runner.Context, status interface{}) error {
 	return nil
 }
+
+// ReportProgress does nothing and returns always nil.
+func (n NoopReporter) ReportProgress(ctx context.Context, runnerCtx runner.Context, progress runner.Progress) error {
+	return nil
+}
@@ -0,0 +1,127 @@
+package terraform
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+)
+
+// progressReportInterval is the minimal interval between progress reports for a single Terraform command.
+// Each report updates the runner status Secret, so reports for resources applied in a quick succession are coalesced.
+const progressReportInterval = 5 * time.Second
+
+var (
+	// planSummaryRegex matches the plan summary, e.g. `Plan: 3 to add, 0 to change, 1 to destroy.`
+	planSummaryRegex = regexp.MustCompile(`Plan: (\d+) to add, (\d+) to change, (\d+) to destroy`)
+	// resourceCompleteRegex matches the message printed once a single resource is applied,
+	// e.g. `google_sql_database_instance.instance: Creation complete after 5m2s [id=db]`
+	resourceCompleteRegex = regexp.MustCompile(`: (Creation|Modifications|Destruction) complete after`)
+)
+
+// progressTracker tracks the number of applied resources based on the Terraform command output.
+// Progress changes within a single command are reported at most once per minInterval.
+type progressTracker struct {
+	report      runner.ProgressReportFunc
+	minInterval time.Duration
+	now         func() time.Time
+
+	phase        string
+	completed    int
+	total        int
+	lastReported time.Time
+	pending      *runner.Progress
+}
+
+func newProgressTracker(report runner.ProgressReportFunc) *progressTracker {
+	return &progressTracker{
+		report:      report,
+		minInterval: progressReportInterval,
+		now:         time.Now,
+	}
+}
+
+// SetPhase sets the currently executed Terraform command. The number of resources planned to change is preserved.
+// The progress of the previous command, which was not reported yet, is reported first.
+func (p *progressTracker) SetPhase(phase string) {
+	p.Flush()
+
+	p.phase = phase
+	p.completed = 0
+	p.reportNow(p.progress(""))
+}
+
+// Flush reports the progress, which was not reported yet because of the report interval.
+func (p *progressTracker) Flush() {
+	if p.pending == nil {
+		return
+	}
+	p.reportNow(*p.pending)
+}
+
+// ObserveLine updates the progress based on a single line of the Terraform command output.
+func (p *progressTracker) ObserveLine(line string) {
+	line = strings.TrimSpace(line)
+
+	if m := planSummaryRegex.FindStringSubmatch(line); m != nil {
+		p.total = 0
+		for _, count := range m[1:] {
+			n, _ := strconv.Atoi(count) // the regex allows only digits
+			p.total += n
+		}
+		p.reportThrottled(p.progress(line))
+		return
+	}
+
+	if resourceCompleteRegex.MatchString(line) {
+		p.completed++
+		p.reportThrottled(p.progress(line))
+	}
+}
+
+func (p *progressTracker) reportThrottled(progress runner.Progress) {
+	if p.now().Sub(p.lastReported) < p.minInterval {
+		p.pending = &progress
+		return
+	}
+	p.reportNow(progress)
+}
+
+func (p *progressTracker) reportNow(progress runner.Progress) {
+	p.report.Report(progress)
+	p.lastReported = p.now()
+	p.pending = nil
+}
+
+func (p *progressTracker) progress(msg string) runner.Progress {
+	return runner.Progress{
+		Phase:     p.phase,
+		Message:   msg,
+		Completed: p.completed,
+		Total:     p.total,
+	}
+}
+
+// lineWriter calls a given function for each line written to it.
+type lineWriter struct {
+	buf    []byte
+	onLine func(line string)
+}
+
+// Write implements io.Writer interface.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		w.onLine(string(w.buf[:idx]))
+		w.buf = w.buf[idx+1:]
+	}
+
+	return len(p), nil
+}
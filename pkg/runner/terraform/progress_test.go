@@ -0,0 +1,79 @@
+package terraform
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressTracker(t *testing.T) {
+	// given
+	var reported []runner.Progress
+	tracker := newProgressTracker(func(progress runner.Progress) {
+		reported = append(reported, progress)
+	})
+	tracker.minInterval = 0
+	w := &lineWriter{onLine: tracker.ObserveLine}
+
+	// when
+	tracker.SetPhase(PlanCommand)
+	fmt.Fprint(w, "Terraform will perform the following actions:\n\nPlan: 2 to add, 0 to change, 0 to destroy.\n")
+	tracker.SetPhase(ApplyCommand)
+	fmt.Fprint(w, "google_sql_database_instance.instance: Creating...\ngoogle_sql_database_instance.instance: Creation ")
+	fmt.Fprint(w, "complete after 5m2s [id=db]\ngoogle_sql_user.user: Creation complete after 2s [id=user]\nApply complete!")
+
+	// then
+	assert.Equal(t, []runner.Progress{
+		{Phase: PlanCommand},
+		{Phase: PlanCommand, Message: "Plan: 2 to add, 0 to change, 0 to destroy.", Total: 2},
+		{Phase: ApplyCommand, Total: 2},
+		{Phase: ApplyCommand, Message: "google_sql_database_instance.instance: Creation complete after 5m2s [id=db]", Completed: 1, Total: 2},
+		{Phase: ApplyCommand, Message: "google_sql_user.user: Creation complete after 2s [id=user]", Completed: 2, Total: 2},
+	}, reported)
+}
+
+func TestProgressTrackerCoalescesReports(t *testing.T) {
+	// given
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var reported []runner.Progress
+	tracker := newProgressTracker(func(progress runner.Progress) {
+		reported = append(reported, progress)
+	})
+	tracker.now = func() time.Time { return now }
+	tracker.total = 3
+
+	// when
+	tracker.SetPhase(ApplyCommand)
+	tracker.ObserveLine("a.a: Creation complete after 1s")
+	tracker.ObserveLine("b.b: Creation complete after 1s")
+
+	// then
+	assert.Equal(t, []runner.Progress{
+		{Phase: ApplyCommand, Total: 3},
+	}, reported)
+
+	// when
+	tracker.Flush()
+	tracker.Flush()
+
+	// then
+	assert.Equal(t, []runner.Progress{
+		{Phase: ApplyCommand, Total: 3},
+		{Phase: ApplyCommand, Message: "b.b: Creation complete after 1s", Completed: 2, Total: 3},
+	}, reported)
+
+	// when
+	now = now.Add(progressReportInterval)
+	tracker.ObserveLine("c.c: Creation complete after 1s")
+
+	// then
+	assert.Equal(t, []runner.Progress{
+		{Phase: ApplyCommand, Total: 3},
+		{Phase: ApplyCommand, Message: "b.b: Creation complete after 1s", Completed: 2, Total: 3},
+		{Phase: ApplyCommand, Message: "c.c: Creation complete after 1s", Completed: 3, Total: 3},
+	}, reported)
+}
@@ -17,18 +17,19 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-var _ runner.Runner = &terraformRunner{}
+var _ runner.AsyncRunner = &terraformRunner{}
 
 // Runner provides functionality to run and wait for Helm operations.
 type terraformRunner struct {
-	cfg Config
-	log *zap.Logger
+	cfg      Config
+	log      *zap.Logger
+	progress runner.ProgressReportFunc
 
 	terraform *terraform
 }
 
 // NewTerraformRunner returns a new Terraform runner instance.
-func NewTerraformRunner(cfg Config) runner.Runner {
+func NewTerraformRunner(cfg Config) runner.AsyncRunner {
 	return &terraformRunner{
 		cfg: cfg,
 	}
@@ -42,6 +43,10 @@ func (r *terraformRunner) Start(ctx context.Context, in runner.StartInput) (*run
 		return nil, errors.Wrap(err, "while unmarshaling runner arguments")
 	}
 
+	if args.Command == DestroyCommand && r.cfg.StateTypeInstanceFilepath == "" {
+		return nil, errors.New("state TypeInstance from a previous apply is required to destroy Terraform resources")
+	}
+
 	// both go-getter and terraform are using envs so setting them globally
 	// it can be used to set credentials, paths to credentials, variables, args...
 	err = r.setEnvVars(args.Env)
@@ -72,7 +77,7 @@ func (r *terraformRunner) Start(ctx context.Context, in runner.StartInput) (*run
 		return nil, errors.Wrap(err, "while merging variables")
 	}
 
-	r.terraform = newTerraform(r.log, r.cfg.WorkDir, args)
+	r.terraform = newTerraform(r.log, r.cfg.WorkDir, args, r.progress)
 
 	err = r.terraform.Start(in.RunnerCtx.DryRun)
 	if err != nil {
@@ -104,10 +109,7 @@ func (r *terraformRunner) WaitForCompletion(ctx context.Context, in runner.WaitF
 	if err != nil {
 		return &runner.WaitForCompletionOutput{}, errors.New("failed to get release info")
 	}
-	additional, err := r.terraform.renderOutput()
-	if err != nil {
-		return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while getting additional info")
-	}
+	additional, outputErr := r.additionalOutput()
 	tfstate, err := r.terraform.tfstate()
 	if err != nil {
 		return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while getting terraform.tfstate file")
@@ -121,6 +123,7 @@ func (r *terraformRunner) WaitForCompletion(ctx context.Context, in runner.WaitF
 	state := StateTypeInstance{
 		State:     tfstate,
 		Variables: variables,
+		Deleted:   r.terraform.destroyed(in.RunnerCtx.DryRun),
 	}
 
 	output := Output{
@@ -134,7 +137,43 @@ func (r *terraformRunner) WaitForCompletion(ctx context.Context, in runner.WaitF
 		return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while saving output files")
 	}
 
-	return &runner.WaitForCompletionOutput{Succeeded: true, Message: "Terraform finished"}, nil
+	if outputErr != nil {
+		r.log.Warn("Terraform state persisted, but outputs cannot be produced", zap.Error(outputErr))
+		r.progress.Report(runner.Progress{
+			Phase:   string(AppliedWithOutputErrorsStatusPhase),
+			Message: outputErr.Error(),
+		})
+		return &runner.WaitForCompletionOutput{
+			Succeeded: true,
+			Message:   "Terraform finished with output errors",
+			Status: Status{
+				Phase:   AppliedWithOutputErrorsStatusPhase,
+				Message: outputErr.Error(),
+			},
+		}, nil
+	}
+
+	msg := "Terraform finished"
+	if state.Deleted {
+		msg = "Terraform resources destroyed"
+	}
+
+	return &runner.WaitForCompletionOutput{Succeeded: true, Message: msg}, nil
+}
+
+// additionalOutput returns the rendered additional output. Errors don't stop the runner, as the resources
+// were already applied. In such case, empty additional output is returned, so the state can be still persisted.
+func (r *terraformRunner) additionalOutput() ([]byte, error) {
+	if r.terraform.outputErr != nil {
+		return []byte{}, r.terraform.outputErr
+	}
+
+	additional, err := r.terraform.renderOutput()
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "while getting additional info")
+	}
+
+	return additional, nil
 }
 
 // Name returns the runner name.
@@ -147,6 +186,11 @@ func (r *terraformRunner) InjectLogger(logger *zap.Logger) {
 	r.log = logger
 }
 
+// InjectProgressReporter sets the progress reporting function on the runner.
+func (r *terraformRunner) InjectProgressReporter(fn runner.ProgressReportFunc) {
+	r.progress = fn
+}
+
 func (r *terraformRunner) setEnvVars(env []string) error {
 	for _, e := range env {
 		s := strings.Split(e, "=")
@@ -0,0 +1,149 @@
+package terraform
+
+import (
+	"context"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRunnerStartDestroyWithoutState(t *testing.T) {
+	// given
+	r := NewTerraformRunner(Config{WorkDir: t.TempDir()})
+	r.InjectLogger(zap.NewNop())
+
+	// when
+	out, err := r.Start(context.Background(), runner.StartInput{
+		Args: []byte(`{"command": "destroy"}`),
+	})
+
+	// then
+	assert.EqualError(t, err, "state TypeInstance from a previous apply is required to destroy Terraform resources")
+	assert.Nil(t, out)
+}
+
+func TestRunnerInjectStateTypeInstance(t *testing.T) {
+	// given
+	workdir := t.TempDir()
+	stateTIPath := path.Join(t.TempDir(), "state.yaml")
+
+	stateTI, err := yaml.Marshal(StateTypeInstance{
+		State:     []byte(`{"version": 4}`),
+		Variables: []byte("user_name = \"capact\"\n"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(stateTIPath, stateTI, 0600))
+
+	r := &terraformRunner{
+		cfg: Config{
+			WorkDir:                   workdir,
+			StateTypeInstanceFilepath: stateTIPath,
+		},
+		log: zap.NewNop(),
+	}
+
+	// when
+	err = r.injectStateTypeInstance()
+
+	// then
+	require.NoError(t, err)
+
+	state, err := ioutil.ReadFile(path.Join(workdir, stateFile))
+	require.NoError(t, err)
+	assert.Equal(t, `{"version": 4}`, string(state))
+
+	variables, err := ioutil.ReadFile(path.Join(workdir, variablesFile))
+	require.NoError(t, err)
+	assert.Equal(t, "user_name = \"capact\"\n", string(variables))
+}
+
+func TestRunnerSaveOutputMarksDestroyedState(t *testing.T) {
+	// given
+	tfstatePath := path.Join(t.TempDir(), "terraform.tfstate")
+	r := &terraformRunner{
+		cfg: Config{
+			Output: OutputConfig{
+				TfstateFilePath: tfstatePath,
+			},
+		},
+		log: zap.NewNop(),
+	}
+
+	// when
+	err := r.saveOutput(Output{
+		State: StateTypeInstance{
+			State:     []byte(`{"version": 4}`),
+			Variables: []byte("\n"),
+			Deleted:   true,
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(tfstatePath)
+	require.NoError(t, err)
+
+	var got StateTypeInstance
+	require.NoError(t, yaml.Unmarshal(data, &got))
+	assert.True(t, got.Deleted)
+	assert.Equal(t, []byte(`{"version": 4}`), got.State)
+}
+
+func TestRunnerWaitForCompletionPersistsStateOnOutputErrors(t *testing.T) {
+	// given
+	workdir, outDir := t.TempDir(), t.TempDir()
+	require.NoError(t, ioutil.WriteFile(path.Join(workdir, stateFile), []byte(`{"version": 4}`), 0600))
+	require.NoError(t, ioutil.WriteFile(path.Join(workdir, variablesFile), []byte("\n"), 0600))
+
+	waitCh := make(chan error)
+	close(waitCh)
+
+	r := &terraformRunner{
+		cfg: Config{
+			WorkDir: workdir,
+			Output: OutputConfig{
+				TerraformReleaseFilePath: path.Join(outDir, "terraform-release.yaml"),
+				AdditionalFilePath:       path.Join(outDir, "additional.yaml"),
+				TfstateFilePath:          path.Join(outDir, "terraform.tfstate"),
+			},
+		},
+		log: zap.NewNop(),
+		terraform: &terraform{
+			log:       zap.NewNop(),
+			workdir:   workdir,
+			args:      Arguments{Command: ApplyCommand},
+			_waitCh:   waitCh,
+			outputErr: errors.New("while getting terraform output: exit status 1"),
+		},
+	}
+
+	// when
+	out, err := r.WaitForCompletion(context.Background(), runner.WaitForCompletionInput{})
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, out.Succeeded)
+	assert.Equal(t, Status{
+		Phase:   AppliedWithOutputErrorsStatusPhase,
+		Message: "while getting terraform output: exit status 1",
+	}, out.Status)
+
+	data, err := ioutil.ReadFile(r.cfg.Output.TfstateFilePath)
+	require.NoError(t, err)
+	var got StateTypeInstance
+	require.NoError(t, yaml.Unmarshal(data, &got))
+	assert.Equal(t, []byte(`{"version": 4}`), got.State)
+
+	additional, err := ioutil.ReadFile(r.cfg.Output.AdditionalFilePath)
+	require.NoError(t, err)
+	assert.Empty(t, additional)
+}
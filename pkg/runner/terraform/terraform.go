@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
 
+	"capact.io/capact/pkg/runner"
+
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"sigs.k8s.io/yaml"
@@ -27,13 +30,17 @@ type terraform struct {
 	args      Arguments
 	_waitCh   chan error
 	runOutput []byte
+	// outputErr holds the error, which occurred while getting outputs after a successful run.
+	outputErr error
+	progress  *progressTracker
 }
 
-func newTerraform(log *zap.Logger, workdir string, args Arguments) *terraform {
+func newTerraform(log *zap.Logger, workdir string, args Arguments, progress runner.ProgressReportFunc) *terraform {
 	return &terraform{
-		log:     log,
-		workdir: workdir,
-		args:    args,
+		log:      log,
+		workdir:  workdir,
+		args:     args,
+		progress: newProgressTracker(progress),
 	}
 }
 
@@ -53,10 +60,18 @@ func (t *terraform) Start(dryRun bool) error {
 			t._waitCh <- errors.Wrap(err, "while running terraform")
 			return
 		}
-		// TODO returning error here is misleading as resources were deployed
+
+		if t.destroyed(dryRun) {
+			// there are no outputs after all resources were destroyed
+			close(t._waitCh)
+			return
+		}
+
 		out, err := t.output()
 		if err != nil {
-			t._waitCh <- errors.Wrap(err, "while running terraform")
+			// resources were already deployed, so the state needs to be persisted anyway
+			t.outputErr = errors.Wrap(err, "while getting terraform output")
+			close(t._waitCh)
 			return
 		}
 		t.runOutput = out
@@ -98,10 +113,16 @@ func (t *terraform) _execute(command string, arg ...string) ([]byte, error) {
 	cmd.Dir = t.workdir
 	cmd.Env = append(os.Environ(), "TF_IN_AUTOMATION=true")
 
+	// output is parsed on the fly to report the progress of long-running commands
+	var out bytes.Buffer
+	w := io.MultiWriter(&out, &lineWriter{onLine: t.progress.ObserveLine})
+	cmd.Stdout, cmd.Stderr = w, w
+
 	t.log.Info("Running terraform command", zap.Strings("args", allArgs))
-	out, err := cmd.CombinedOutput()
-	t.log.Debug("Terraform output", zap.ByteString("output", out))
-	return out, err
+	err := cmd.Run()
+	t.progress.Flush()
+	t.log.Debug("Terraform output", zap.ByteString("output", out.Bytes()))
+	return out.Bytes(), err
 }
 
 func (t *terraform) init() error {
@@ -117,35 +138,52 @@ func (t *terraform) output() ([]byte, error) {
 func (t *terraform) run(dryRun bool) error {
 	if dryRun {
 		return t._plan()
-	} else if t.args.Command == ApplyCommand {
+	}
+
+	switch t.args.Command {
+	case ApplyCommand:
 		if err := t._plan(); err != nil {
 			return err
 		}
 
 		return t._apply()
-	} else if t.args.Command == DestroyCommand {
+	case DestroyCommand:
+		if err := t._plan("-destroy"); err != nil {
+			return err
+		}
+
 		return t._destroy()
+	case PlanCommand:
+		return t._plan()
 	}
 	return fmt.Errorf("command `%s` is not supported", t.args.Command)
 }
 
-func (t *terraform) _plan() error {
-	_, err := t._execute("plan")
+// destroyed returns true if the resources tracked by the state were destroyed by the operation.
+func (t *terraform) destroyed(dryRun bool) bool {
+	return !dryRun && t.args.Command == DestroyCommand
+}
+
+func (t *terraform) _plan(arg ...string) error {
+	t.progress.SetPhase(PlanCommand)
+	_, err := t._execute("plan", arg...)
 	return err
 }
 
 func (t *terraform) _apply() error {
+	t.progress.SetPhase(ApplyCommand)
 	_, err := t._execute("apply", "-auto-approve")
 	return err
 }
 
 func (t *terraform) _destroy() error {
+	t.progress.SetPhase(DestroyCommand)
 	_, err := t._execute("destroy", "-auto-approve")
 	return err
 }
 
 func (t *terraform) tfstate() ([]byte, error) {
-	return ioutil.ReadFile(path.Join(t.workdir, "terraform.tfstate"))
+	return ioutil.ReadFile(path.Join(t.workdir, stateFile))
 }
 
 func (t *terraform) variables() ([]byte, error) {
@@ -1,6 +1,11 @@
 package terraform
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+
+	"capact.io/capact/pkg/runner"
+)
 
 // CommandType represents the operation type to be performed by the runner.
 type CommandType string
@@ -22,7 +27,6 @@ type Arguments struct {
 	Env       []string         `yaml:"env"`
 	Variables string           `yaml:"variables"`
 	Output    AdditionalOutput `yaml:"output"`
-	// TODO destroy needs tfstate file
 }
 
 // Module stores the source details of the Terraform module.
@@ -61,6 +65,34 @@ type Release struct {
 type StateTypeInstance struct {
 	State     []byte `json:"state"`
 	Variables []byte `json:"variables"`
+	// Deleted indicates that all resources tracked by the state were destroyed.
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// StatusPhase describes the phase of the finished Terraform operation.
+type StatusPhase string
+
+const (
+	// AppliedWithOutputErrorsStatusPhase indicates that the resources were applied and the state was persisted,
+	// but the outputs cannot be produced.
+	AppliedWithOutputErrorsStatusPhase StatusPhase = "applied-with-output-errors"
+)
+
+// Status stores the Terraform runner status reported after the operation finished.
+type Status struct {
+	Phase   StatusPhase `json:"phase"`
+	Message string      `json:"message,omitempty"`
+}
+
+var _ runner.WarningStatus = Status{}
+
+// Warning returns a message, which describes why the Terraform operation requires attention.
+// It is empty, if the operation finished without issues.
+func (s Status) Warning() string {
+	if s.Phase != AppliedWithOutputErrorsStatusPhase {
+		return ""
+	}
+	return fmt.Sprintf("Terraform resources applied, but outputs cannot be produced: %s", s.Message)
 }
 
 // Output stores the generated output artifacts.
@@ -14,8 +14,8 @@ import (
 	"capact.io/capact/pkg/engine/k8s/policy"
 	hubpublicapi "capact.io/capact/pkg/hub/api/graphql/public"
 	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"capact.io/capact/pkg/sdk/renderer"
 
-	"github.com/Knetic/govaluate"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -35,13 +35,16 @@ type dedicatedRenderer struct {
 	inputParametersCollection types.ParametersCollection
 	inputTypeInstances        []types.InputTypeInstanceRef
 	ownerID                   *string
+	advancedRendering         *advancedRendering
 
 	// internal vars
-	currentIteration   int
-	processedTemplates []*Template
-	rootTemplate       *Template
-	entrypointStep     *WorkflowStep
-	tplInputArguments  map[string][]InputArtifact
+	renderingIteration       *RenderingIteration
+	implementationSelections []ImplementationSelection
+	currentIteration         int
+	processedTemplates       []*Template
+	rootTemplate             *Template
+	entrypointStep           *WorkflowStep
+	tplInputArguments        map[string][]InputArtifact
 
 	typeInstancesToOutput             *OutputTypeInstances
 	typeInstancesToUpdate             UpdateTypeInstances
@@ -49,6 +52,15 @@ type dedicatedRenderer struct {
 	log                               *zap.Logger
 }
 
+// advancedRendering holds the state of the advanced rendering mode.
+type advancedRendering struct {
+	approvedIterationName    string
+	approvedIterationReached bool
+}
+
+// errRenderingIterationNotApproved is used to stop rendering when a given rendering iteration waits for user approval.
+var errRenderingIterationNotApproved = errors.New("rendering iteration was not approved")
+
 // InputArtifact is an Argo artifact with a reference to a Capact TypeInstance.
 // It is used to track the TypeInstance, which is handled in the workflow.
 type InputArtifact struct {
@@ -212,6 +224,7 @@ func (r *dedicatedRenderer) RenderTemplateSteps(ctx context.Context, workflow *W
 		availableTypeInstances := getAvailableTypeInstancesFromInputArtifacts(r.tplInputArguments[tpl.Name])
 
 		artifactMappings := map[string]string{}
+		skippedSteps := map[string]struct{}{}
 		var newStepGroup []ParallelSteps
 
 		for _, parallelSteps := range tpl.Steps {
@@ -222,47 +235,44 @@ func (r *dedicatedRenderer) RenderTemplateSteps(ctx context.Context, workflow *W
 				// whether steps in referenced template are satisfied
 				r.registerTemplateInputArguments(step, availableTypeInstances)
 
-				// 2. Check step with `capact-when` statements if it can be satisfied by input arguments
-				satisfiedArg, err := r.getInputArgWhichSatisfyStep(tpl.Name, step)
+				// 2. Check step with `capact-when` statements if it can be satisfied by input arguments or input TypeInstances
+				satisfiedBy, skip, err := r.getInputsWhichSatisfyStep(tpl.Name, step, typeInstances)
 				if err != nil {
 					return nil, err
 				}
 
-				// 2.1 Replace step and emit input arguments as step output
-				if satisfiedArg != "" {
-					emitStep, wfTpl := r.emitWorkflowInputArgsAsStepOutput(tpl.Name, step, satisfiedArg)
+				// 2.0 Skip step if its statement is false or it consumes artifacts of already skipped step.
+				// Satisfied steps are replaced with steps, which output the inputs, so they don't depend on other steps.
+				if skip || (len(satisfiedBy) == 0 && referencesSkippedStep(step, skippedSteps)) {
+					skipStep(step)
+					skippedSteps[step.Name] = struct{}{}
+				}
+
+				// 2.1 Replace step and emit input arguments and input TypeInstances as step output
+				if len(satisfiedBy) > 0 {
+					emitStep, wfTpl := r.emitWorkflowInputsAsStepOutput(tpl.Name, prefix, step, satisfiedBy)
 					step = emitStep
 					r.addToRootTemplates(wfTpl)
 
-					artifact := findInputArtifact(r.tplInputArguments[tpl.Name], satisfiedArg)
-					if artifact == nil {
-						return nil, errors.Errorf("failed to find InputArtifact %s for step %s", satisfiedArg, step)
-					}
-
-					availableTypeInstances[argoArtifactRef{step.Name, satisfiedArg}] = artifact.typeInstanceReference
-				}
-
-				// 2.2 Check step with `capact-when` statements if it can be satisfied by input TypeInstances
-				if satisfiedArg == "" {
-					satisfiedArg, err = r.getInputTypeInstanceWhichSatisfyStep(step, typeInstances)
-					if err != nil {
-						return nil, err
-					}
+					for _, input := range satisfiedBy {
+						if !input.isTypeInstance {
+							artifact := findInputArtifact(r.tplInputArguments[tpl.Name], input.name)
+							if artifact == nil {
+								return nil, errors.Errorf("failed to find InputArtifact %s for step %s", input.name, step)
+							}
 
-					// 2. Replace step and emit input TypeInstance as step output
-					if satisfiedArg != "" {
-						emitStep, wfTpl := r.emitWorkflowInputTypeInstanceAsStepOutput(tpl.Name, step, satisfiedArg)
-						step = emitStep
-						r.addToRootTemplates(wfTpl)
+							availableTypeInstances[argoArtifactRef{step.Name, input.name}] = artifact.typeInstanceReference
+							continue
+						}
 
-						typeInstance := findTypeInstanceInputRef(r.inputTypeInstances, satisfiedArg)
+						typeInstance := findTypeInstanceInputRef(typeInstances, input.name)
 						if typeInstance == nil {
-							return nil, errors.Errorf("failed to find InputTypeInstanceRef for %s", satisfiedArg)
+							return nil, errors.Errorf("failed to find InputTypeInstanceRef for %s", input.name)
 						}
-						r.tryReplaceTypeInstanceName(satisfiedArg, typeInstance.ID)
+						r.tryReplaceTypeInstanceName(input.name, typeInstance.ID)
 
 						namePtr := r.findTypeInstanceName(typeInstance.ID)
-						availableTypeInstances[argoArtifactRef{step.Name, satisfiedArg}] = namePtr
+						availableTypeInstances[argoArtifactRef{step.Name, input.name}] = namePtr
 					}
 				}
 
@@ -302,15 +312,20 @@ func (r *dedicatedRenderer) RenderTemplateSteps(ctx context.Context, workflow *W
 							step.Name, actionRef.Path, actionRef.Revision)
 					}
 
+					workflowPrefix := addPrefix(tpl.Name, step.Name)
+
 					// 3.4 Pick one of the Implementations
-					implementation, err := r.PickImplementationRevision(implementations)
+					implementation, err := r.PickImplementationRevision(workflowPrefix, *actionRef, rule, implementations)
 					if err != nil {
 						return nil, errors.Wrapf(err,
 							`while picking ImplementationRevision for step %q with action reference with action reference "%s:%s"`,
 							step.Name, actionRef.Path, actionRef.Revision)
 					}
 
-					workflowPrefix := addPrefix(tpl.Name, step.Name)
+					// 3.5 In advanced rendering mode, stop if user didn't approve this rendering iteration yet
+					if err := r.StopOnRenderingIterationIfNotApproved(workflowPrefix, implementation); err != nil {
+						return nil, err
+					}
 
 					// 3.6 Extract workflow from the imported `capact-action`. Prefix it to avoid artifacts name collision.
 					importedWorkflow, newArtifactMappings, err := r.UnmarshalWorkflowFromImplementation(workflowPrefix, &implementation)
@@ -357,8 +372,8 @@ func (r *dedicatedRenderer) RenderTemplateSteps(ctx context.Context, workflow *W
 					}
 
 					// 3.10 Render imported Workflow templates and add them to root templates
-					// TODO(advanced-rendering): currently not supported.
-					actionOutputTypeInstances, err := r.RenderTemplateSteps(ctx, importedWorkflow, RootImplementation{Revision: implementation, Rule: rule}, nil, workflowPrefix)
+					iterationTypeInstances := r.InputTypeInstancesForRenderingIteration(workflowPrefix, implementation)
+					actionOutputTypeInstances, err := r.RenderTemplateSteps(ctx, importedWorkflow, RootImplementation{Revision: implementation, Rule: rule}, iterationTypeInstances, workflowPrefix)
 					if err != nil {
 						return nil, err
 					}
@@ -407,15 +422,19 @@ func (r *dedicatedRenderer) RenderTemplateSteps(ctx context.Context, workflow *W
 	return outputTypeInstances, nil
 }
 
-// TODO: take into account the runner revision. Respect that also in k8s engine when scheduling runner job
-func (r *dedicatedRenderer) ResolveRunnerInterface(impl hubpublicapi.ImplementationRevision) (string, error) {
+// ResolveRunnerInterface returns the runner Interface path and revision imported by a given Implementation.
+// The revision is empty, if it is not specified in imports. In such case, the Engine uses the latest runner revision.
+func (r *dedicatedRenderer) ResolveRunnerInterface(impl hubpublicapi.ImplementationRevision) (types.ManifestRef, error) {
 	imports, rInterface := impl.Spec.Imports, impl.Spec.Action.RunnerInterface
 	fullRef, err := hubpublicapi.ResolveActionPathFromImports(imports, rInterface)
 	if err != nil {
-		return "", err
+		return types.ManifestRef{}, err
 	}
 
-	return fullRef.Path, nil
+	return types.ManifestRef{
+		Path:     fullRef.Path,
+		Revision: fullRef.Revision,
+	}, nil
 }
 
 type artefactNameWithBackend struct {
@@ -597,13 +616,122 @@ func (r *dedicatedRenderer) InjectAdditionalInput(step *WorkflowStep, additional
 	}
 }
 
-func (r *dedicatedRenderer) PickImplementationRevision(in []hubpublicapi.ImplementationRevision) (hubpublicapi.ImplementationRevision, error) {
-	if len(in) == 0 {
-		return hubpublicapi.ImplementationRevision{}, errors.New("No Implementations found with current policy for given Interface")
+// PickImplementationRevision selects one of the Implementations using the selection strategy from a given Policy rule.
+// The selection details are recorded under a given name.
+func (r *dedicatedRenderer) PickImplementationRevision(name string, interfaceRef hubpublicapi.InterfaceReference, rule policy.Rule, in []hubpublicapi.ImplementationRevision) (hubpublicapi.ImplementationRevision, error) {
+	impl, selection, err := selectImplementationRevision(in, rule)
+	if err != nil {
+		return hubpublicapi.ImplementationRevision{}, err
+	}
+
+	selection.Name = name
+	selection.Interface = types.ManifestRef{
+		Path:     interfaceRef.Path,
+		Revision: interfaceRef.Revision,
 	}
+	r.implementationSelections = append(r.implementationSelections, selection)
+
+	return impl, nil
+}
 
-	// business decision - pick first Implementation
-	return in[0], nil
+// GetImplementationSelections returns details of all Implementation selections made during rendering.
+func (r *dedicatedRenderer) GetImplementationSelections() []ImplementationSelection {
+	return r.implementationSelections
+}
+
+// StopOnRenderingIterationIfNotApproved checks whether a given rendering iteration was already approved by user.
+// If not, it records the rendering iteration details and returns errRenderingIterationNotApproved error.
+// Only Implementations which have optional input TypeInstances are treated as rendering iterations.
+// It is a no-op if the advanced rendering mode is disabled.
+func (r *dedicatedRenderer) StopOnRenderingIterationIfNotApproved(name string, impl hubpublicapi.ImplementationRevision) error {
+	if r.advancedRendering == nil {
+		return nil
+	}
+
+	typeInstancesToProvide := optionalInputTypeInstancesToProvide(impl)
+	if len(typeInstancesToProvide) == 0 {
+		return nil
+	}
+	for idx := range typeInstancesToProvide {
+		typeInstancesToProvide[idx].Name = renderingIterationTypeInstanceName(name, typeInstancesToProvide[idx].Name)
+	}
+
+	// Iterations are always processed in the same order, so all iterations before the approved one
+	// were approved too.
+	if !r.advancedRendering.approvedIterationReached {
+		if name == r.advancedRendering.approvedIterationName {
+			r.advancedRendering.approvedIterationReached = true
+		}
+		return nil
+	}
+
+	r.renderingIteration = &RenderingIteration{
+		Name:                        name,
+		InputTypeInstancesToProvide: typeInstancesToProvide,
+	}
+	return errRenderingIterationNotApproved
+}
+
+// GetRenderingIteration returns the rendering iteration which waits for user approval.
+func (r *dedicatedRenderer) GetRenderingIteration() *RenderingIteration {
+	return r.renderingIteration
+}
+
+// InputTypeInstancesForRenderingIteration returns input TypeInstances provided by user for a given
+// rendering iteration in the advanced rendering mode. User provides them under names prefixed with the iteration name,
+// so the returned TypeInstances are renamed back to the names used in the Implementation.
+func (r *dedicatedRenderer) InputTypeInstancesForRenderingIteration(iterationName string, impl hubpublicapi.ImplementationRevision) []types.InputTypeInstanceRef {
+	if r.advancedRendering == nil {
+		return nil
+	}
+
+	optional := map[string]string{}
+	for _, ti := range optionalInputTypeInstancesToProvide(impl) {
+		optional[renderingIterationTypeInstanceName(iterationName, ti.Name)] = ti.Name
+	}
+
+	var out []types.InputTypeInstanceRef
+	for _, ti := range r.inputTypeInstances {
+		implName, found := optional[ti.Name]
+		if !found {
+			continue
+		}
+		out = append(out, types.InputTypeInstanceRef{
+			Name: implName,
+			ID:   ti.ID,
+		})
+	}
+
+	return out
+}
+
+// InputTypeInstancesToValidate returns input TypeInstances, which should be validated against a given Interface.
+// In the advanced rendering mode, TypeInstances provided for rendering iterations are not a part of the Interface input,
+// and they are validated by Engine against the list of TypeInstances to provide.
+func (r *dedicatedRenderer) InputTypeInstancesToValidate(iface *hubpublicapi.InterfaceRevision) []types.InputTypeInstanceRef {
+	if r.advancedRendering == nil {
+		return r.inputTypeInstances
+	}
+
+	ifaceTypeInstances := map[string]struct{}{}
+	if iface != nil && iface.Spec != nil && iface.Spec.Input != nil {
+		for _, ti := range iface.Spec.Input.TypeInstances {
+			if ti == nil {
+				continue
+			}
+			ifaceTypeInstances[ti.Name] = struct{}{}
+		}
+	}
+
+	var out []types.InputTypeInstanceRef
+	for _, ti := range r.inputTypeInstances {
+		if _, found := ifaceTypeInstances[ti.Name]; !found {
+			continue
+		}
+		out = append(out, ti)
+	}
+
+	return out
 }
 
 // Internal helpers
@@ -707,7 +835,12 @@ func (r *dedicatedRenderer) getOutputTypeInstanceTemplate(step *WorkflowStep, ou
 	}
 }
 
-//  This function checks if a given step is satisfied by input arguments
+//  This function checks if a given step is satisfied by input arguments or input TypeInstances.
+//
+//  Input arguments of the template and input TypeInstances of the rendering iteration share a single namespace,
+//  so a statement can combine both of them, e.g. `isNotDefined(postgresql, app-config)`, where `postgresql`
+//  is an input argument and `app-config` is an input TypeInstance. If an input argument and an input TypeInstance
+//  have the same name, the input argument is used.
 //
 //  Example:
 //
@@ -725,84 +858,78 @@ func (r *dedicatedRenderer) getOutputTypeInstanceTemplate(step *WorkflowStep, ou
 //        - name: input-parameters
 //        - name: postgresql
 //          optional: true
+//        - name: app-config
+//          optional: true
 //    steps:
-//      - - capact-when: postgresql == nil						# Check whether this step is satisfied by input arguments.
+//      - - capact-when: isNotDefined(postgresql, app-config)	# Check whether this step is satisfied by input arguments.
 //          name: install-db									# For that we need to have option to check which arguments were passed
 //																# to this step.
-func (r *dedicatedRenderer) getInputArgWhichSatisfyStep(tplOwnerName string, step *WorkflowStep) (string, error) {
+//
+//  If the statement is false and none of the inputs determined that, the step should be skipped.
+func (r *dedicatedRenderer) getInputsWhichSatisfyStep(tplOwnerName string, step *WorkflowStep, typeInstances []types.InputTypeInstanceRef) ([]stepInput, bool, error) {
 	if step.CapactWhen == nil {
-		return "", nil
+		return nil, false, nil
 	}
 
-	args, found := r.tplInputArguments[tplOwnerName]
-	if !found {
-		return "", nil
+	evalCtx := whenEvalContext{
+		definedArtifacts: map[string]struct{}{},
+		parameters:       r.inputParametersCollection,
 	}
-
-	params := &mapEvalParameters{}
-	for _, a := range args {
-		params.Set(a.artifact.Name)
+	for _, t := range typeInstances {
+		evalCtx.definedArtifacts[t.Name] = struct{}{}
 	}
-
-	notSatisfied, err := r.evaluateWhenExpression(params, *step.CapactWhen)
-	if err != nil {
-		return "", errors.Wrap(err, "while evaluating OCFWhen")
+	inputArgNames := map[string]struct{}{}
+	for _, a := range r.tplInputArguments[tplOwnerName] {
+		evalCtx.definedArtifacts[a.artifact.Name] = struct{}{}
+		inputArgNames[a.artifact.Name] = struct{}{}
 	}
 
-	if notSatisfied == true {
-		return "", nil
+	result, err := evaluateWhenExpression(evalCtx, *step.CapactWhen)
+	if err != nil {
+		return nil, false, renderer.NewPermanentError(renderer.InvalidManifestReason, errors.Wrap(err, "while evaluating OCFWhen"))
 	}
 
+	// zero value to mark as handled
 	step.CapactWhen = nil
-	return params.lastAccessed, nil
-}
 
-func (r *dedicatedRenderer) getInputTypeInstanceWhichSatisfyStep(step *WorkflowStep, typeInstances []types.InputTypeInstanceRef) (string, error) {
-	if step.CapactWhen == nil {
-		return "", nil
+	if result.value {
+		return nil, false, nil
 	}
 
-	params := &mapEvalParameters{}
-	for _, t := range typeInstances {
-		params.Set(t.Name)
+	if len(result.satisfiedBy) == 0 {
+		return nil, true, nil
 	}
 
-	notSatisfied, err := r.evaluateWhenExpression(params, *step.CapactWhen)
-	if err != nil {
-		return "", errors.Wrap(err, "while evaluating OCFWhen")
-	}
-
-	// zero value to mark as handled
-	step.CapactWhen = nil
-
-	if notSatisfied == true {
-		return "", nil
+	var out []stepInput
+	for _, name := range result.satisfiedBy {
+		_, isInputArg := inputArgNames[name]
+		out = append(out, stepInput{name: name, isTypeInstance: !isInputArg})
 	}
 
-	return params.lastAccessed, nil
+	return out, false, nil
 }
 
-// TODO:
-//   We can change lib to `github.com/antonmedv/expr` and create our own functions which will allow us to introspect which artifact satisfied a given step:
-//    - isDefined(foo,bar,baz)
-//    - isNotDefined(foo,bar,baz)
-//    - isDefined(foo,bar,baz) && isNotDefined(foo,bar,baz)
-//    - isDefined(foo,bar,baz) || isNotDefined(foo,bar,baz)
-func (*dedicatedRenderer) evaluateWhenExpression(params *mapEvalParameters, exprString string) (interface{}, error) {
-	// Dashes need to be escaped, otherwise statement is treated as expression,
-	// see: https://github.com/Knetic/govaluate/tree/v3.0.0#escaping-characters
-	escapeDashes := strings.Replace(exprString, "-", "\\-", -1)
-	expr, err := govaluate.NewEvaluableExpression(escapeDashes)
-	if err != nil {
-		return nil, errors.Wrap(err, "while parsing expression")
+// skipStep makes Argo skip a given step, keeping its original `when` condition.
+func skipStep(step *WorkflowStep) {
+	if step.When == "" {
+		step.When = "false"
+		return
 	}
+	step.When = fmt.Sprintf("(%s) && false", step.When)
+}
 
-	result, err := expr.Eval(params)
-	if err != nil {
-		return nil, errors.Wrap(err, "while evaluating expression")
+// referencesSkippedStep returns true if a given step consumes artifacts produced by one of the skipped steps.
+func referencesSkippedStep(step *WorkflowStep, skippedSteps map[string]struct{}) bool {
+	for _, art := range step.Arguments.Artifacts {
+		match := stepArtifactRefRegex.FindStringSubmatch(art.From)
+		if len(match) != 2 {
+			continue
+		}
+		if _, skipped := skippedSteps[match[1]]; skipped {
+			return true
+		}
 	}
-
-	return result, nil
+	return false
 }
 
 func (r *dedicatedRenderer) maxDepthExceeded() bool {
@@ -887,54 +1014,44 @@ func (r *dedicatedRenderer) addUserInputFromSecret(rootWorkflow *Workflow, param
 	})
 }
 
-// TODO: current limitation: we handle properly only one artifacts `capact-when: postgres == nil` but not `capact-when: postgres == nil && app-config == nil`
-func (r *dedicatedRenderer) emitWorkflowInputAsStepOutput(tplName string, step *WorkflowStep, inputArgName string, reference string) (*WorkflowStep, *Template) {
-	var artifactPath = fmt.Sprintf("output/%s", inputArgName)
-
-	// 1. Create step which outputs workflow input argument as step artifact
+// emitWorkflowInputsAsStepOutput replaces a given step with a step, which outputs workflow input arguments
+// and input TypeInstances as step artifacts.
+func (r *dedicatedRenderer) emitWorkflowInputsAsStepOutput(tplName, prefix string, step *WorkflowStep, inputs []stepInput) (*WorkflowStep, *Template) {
+	// 1. Create step which outputs workflow input arguments as step artifacts
 	userInputWfTpl := &wfv1.Template{
 		Name:      fmt.Sprintf("mock-%s-%s", tplName, step.Name),
 		Container: r.sleepContainer(),
-		Outputs: wfv1.Outputs{
-			Artifacts: wfv1.Artifacts{
-				{
-					Name: inputArgName,
-					Path: artifactPath,
-				},
-			},
-		},
-		Inputs: wfv1.Inputs{
-			Artifacts: wfv1.Artifacts{
-				{
-					Name:     inputArgName,
-					Optional: false,
-					Path:     artifactPath,
-				},
-			},
-		},
 	}
-
 	userInputWfStep := &wfv1.WorkflowStep{
 		Name:     step.Name,
 		Template: userInputWfTpl.Name,
-		Arguments: wfv1.Arguments{
-			Artifacts: wfv1.Artifacts{
-				{
-					Name: inputArgName,
-					From: fmt.Sprintf(reference, inputArgName),
-				},
-			},
-		},
 	}
-	return &WorkflowStep{WorkflowStep: userInputWfStep}, &Template{Template: userInputWfTpl}
-}
 
-func (r *dedicatedRenderer) emitWorkflowInputArgsAsStepOutput(tplName string, step *WorkflowStep, inputArgName string) (*WorkflowStep, *Template) {
-	return r.emitWorkflowInputAsStepOutput(tplName, step, inputArgName, "{{inputs.artifacts.%s}}")
-}
+	for _, input := range inputs {
+		var artifactPath = fmt.Sprintf("output/%s", input.name)
 
-func (r *dedicatedRenderer) emitWorkflowInputTypeInstanceAsStepOutput(tplName string, step *WorkflowStep, inputArgName string) (*WorkflowStep, *Template) {
-	return r.emitWorkflowInputAsStepOutput(tplName, step, inputArgName, "{{workflow.outputs.artifacts.%s}}")
+		reference := fmt.Sprintf("{{inputs.artifacts.%s}}", input.name)
+		if input.isTypeInstance {
+			// input TypeInstances are downloaded in the root workflow under the names provided by user
+			reference = fmt.Sprintf("{{workflow.outputs.artifacts.%s}}", renderingIterationTypeInstanceName(prefix, input.name))
+		}
+
+		userInputWfTpl.Outputs.Artifacts = append(userInputWfTpl.Outputs.Artifacts, wfv1.Artifact{
+			Name: input.name,
+			Path: artifactPath,
+		})
+		userInputWfTpl.Inputs.Artifacts = append(userInputWfTpl.Inputs.Artifacts, wfv1.Artifact{
+			Name:     input.name,
+			Optional: false,
+			Path:     artifactPath,
+		})
+		userInputWfStep.Arguments.Artifacts = append(userInputWfStep.Arguments.Artifacts, wfv1.Artifact{
+			Name: input.name,
+			From: reference,
+		})
+	}
+
+	return &WorkflowStep{WorkflowStep: userInputWfStep}, &Template{Template: userInputWfTpl}
 }
 
 func (r *dedicatedRenderer) registerTemplateInputArguments(step *WorkflowStep, availableTypeInstances map[argoArtifactRef]*string) {
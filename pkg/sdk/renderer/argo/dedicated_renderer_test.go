@@ -4,10 +4,15 @@ import (
 	"testing"
 
 	"capact.io/capact/internal/logger"
+	"capact.io/capact/internal/ptr"
+	hubpublicapi "capact.io/capact/pkg/hub/api/graphql/public"
 	hubclient "capact.io/capact/pkg/hub/client"
 	"capact.io/capact/pkg/hub/client/fake"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
 	policyvalidation "capact.io/capact/pkg/sdk/validation/policy"
 
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -30,15 +35,211 @@ func createFakeDedicatedRendererObject(t *testing.T) *dedicatedRenderer {
 func TestCapactWhenContainDashes(t *testing.T) {
 	//given
 	dedicatedRenderer := createFakeDedicatedRendererObject(t)
-	params := &mapEvalParameters{}
-	params.Set("postgresql-db")
+	dedicatedRenderer.tplInputArguments["app-install"] = []InputArtifact{
+		{artifact: wfv1.Artifact{Name: "postgresql-db"}},
+	}
+	step := &WorkflowStep{
+		WorkflowStep: &wfv1.WorkflowStep{Name: "install-db"},
+		CapactWhen:   ptr.String("postgresql-db == nil"),
+	}
 
 	//when
-	result, err := dedicatedRenderer.evaluateWhenExpression(params, "postgresql-db == nil")
-	isNotExist, ok := result.(bool)
-	require.True(t, ok)
+	satisfiedBy, skip, err := dedicatedRenderer.getInputsWhichSatisfyStep("app-install", step, nil)
 
 	//then
 	require.NoError(t, err)
-	require.False(t, isNotExist)
+	assert.False(t, skip)
+	assert.Equal(t, []stepInput{{name: "postgresql-db"}}, satisfiedBy)
+	assert.Nil(t, step.CapactWhen)
+}
+
+func TestGetInputsWhichSatisfyStep(t *testing.T) {
+	tests := []struct {
+		name                string
+		capactWhen          string
+		expectedSatisfiedBy []stepInput
+		expectedSkip        bool
+	}{
+		{
+			name:       "Satisfied by input argument and input TypeInstance",
+			capactWhen: "isNotDefined(postgresql, app-config)",
+			expectedSatisfiedBy: []stepInput{
+				{name: "postgresql"},
+				{name: "app-config", isTypeInstance: true},
+			},
+		},
+		{
+			name:       "Not satisfied",
+			capactWhen: "isNotDefined(mysql)",
+		},
+		{
+			name:         "Skipped based on input parameters",
+			capactWhen:   "parameters.input-parameters.db.create == true",
+			expectedSkip: true,
+		},
+		{
+			name:         "Skipped because of missing artifact",
+			capactWhen:   "isDefined(postgresql, mysql)",
+			expectedSkip: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			dedicatedRenderer := createFakeDedicatedRendererObject(t)
+			dedicatedRenderer.inputParametersCollection = types.ParametersCollection{
+				"input-parameters": `{"db": {"create": false}}`,
+			}
+			dedicatedRenderer.tplInputArguments["app-install"] = []InputArtifact{
+				{artifact: wfv1.Artifact{Name: "postgresql"}},
+			}
+			typeInstances := []types.InputTypeInstanceRef{
+				{Name: "app-config", ID: "123"},
+			}
+			step := &WorkflowStep{
+				WorkflowStep: &wfv1.WorkflowStep{Name: "install-db"},
+				CapactWhen:   ptr.String(tt.capactWhen),
+			}
+
+			// when
+			satisfiedBy, skip, err := dedicatedRenderer.getInputsWhichSatisfyStep("app-install", step, typeInstances)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSatisfiedBy, satisfiedBy)
+			assert.Equal(t, tt.expectedSkip, skip)
+			assert.Nil(t, step.CapactWhen)
+		})
+	}
+}
+
+func TestSkipStep(t *testing.T) {
+	tests := []struct {
+		name         string
+		when         string
+		expectedWhen string
+	}{
+		{
+			name:         "Without Argo when",
+			expectedWhen: "false",
+		},
+		{
+			name:         "With Argo when",
+			when:         "{{steps.flip-coin.outputs.result}} == heads",
+			expectedWhen: "({{steps.flip-coin.outputs.result}} == heads) && false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			step := &WorkflowStep{
+				WorkflowStep: &wfv1.WorkflowStep{Name: "install-db", When: tt.when},
+			}
+
+			// when
+			skipStep(step)
+
+			// then
+			assert.Equal(t, tt.expectedWhen, step.When)
+		})
+	}
+}
+
+func TestReferencesSkippedStep(t *testing.T) {
+	// given
+	skippedSteps := map[string]struct{}{"install-db": {}}
+	newStep := func(from string) *WorkflowStep {
+		return &WorkflowStep{
+			WorkflowStep: &wfv1.WorkflowStep{
+				Name: "create-user",
+				Arguments: wfv1.Arguments{
+					Artifacts: wfv1.Artifacts{
+						{Name: "input-parameters", From: "{{inputs.artifacts.input-parameters}}"},
+						{Name: "postgresql", From: from},
+					},
+				},
+			},
+		}
+	}
+
+	// when
+	dependent := referencesSkippedStep(newStep("{{steps.install-db.outputs.artifacts.postgresql}}"), skippedSteps)
+	independent := referencesSkippedStep(newStep("{{steps.install-app.outputs.artifacts.postgresql}}"), skippedSteps)
+
+	// then
+	assert.True(t, dependent)
+	assert.False(t, independent)
+}
+
+func TestInputTypeInstancesForRenderingIteration(t *testing.T) {
+	// given
+	dedicatedRenderer := createFakeDedicatedRendererObject(t)
+	WithAdvancedRendering("")(dedicatedRenderer)
+	dedicatedRenderer.inputTypeInstances = []types.InputTypeInstanceRef{
+		{Name: "postgresql", ID: "root-id"},
+		{Name: "app1-install-postgresql", ID: "app1-id"},
+		{Name: "app2-install-postgresql", ID: "app2-id"},
+	}
+	impl := hubpublicapi.ImplementationRevision{
+		Spec: &hubpublicapi.ImplementationSpec{
+			AdditionalInput: &hubpublicapi.ImplementationAdditionalInput{
+				TypeInstances: []*hubpublicapi.InputTypeInstance{
+					{
+						Name:    "postgresql",
+						TypeRef: &hubpublicapi.TypeReference{Path: "cap.type.database.postgresql.config", Revision: "0.1.0"},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		iterationName         string
+		expectedTypeInstances []types.InputTypeInstanceRef
+	}{
+		{
+			iterationName:         rootRenderingIterationName,
+			expectedTypeInstances: []types.InputTypeInstanceRef{{Name: "postgresql", ID: "root-id"}},
+		},
+		{
+			iterationName:         "app1-install",
+			expectedTypeInstances: []types.InputTypeInstanceRef{{Name: "postgresql", ID: "app1-id"}},
+		},
+		{
+			iterationName:         "app2-install",
+			expectedTypeInstances: []types.InputTypeInstanceRef{{Name: "postgresql", ID: "app2-id"}},
+		},
+		{
+			iterationName: "app3-install",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.iterationName, func(t *testing.T) {
+			// when
+			out := dedicatedRenderer.InputTypeInstancesForRenderingIteration(tt.iterationName, impl)
+
+			// then
+			assert.Equal(t, tt.expectedTypeInstances, out)
+		})
+	}
+}
+
+func TestEmitWorkflowInputsAsStepOutputForRenderingIteration(t *testing.T) {
+	// given
+	dedicatedRenderer := createFakeDedicatedRendererObject(t)
+	step := &WorkflowStep{
+		WorkflowStep: &wfv1.WorkflowStep{Name: "install-db"},
+	}
+	inputs := []stepInput{
+		{name: "input-parameters"},
+		{name: "postgresql", isTypeInstance: true},
+	}
+
+	// when
+	emitStep, _ := dedicatedRenderer.emitWorkflowInputsAsStepOutput("app1-install-app-install", "app1-install", step, inputs)
+
+	// then
+	require.Len(t, emitStep.Arguments.Artifacts, 2)
+	assert.Equal(t, "{{inputs.artifacts.input-parameters}}", emitStep.Arguments.Artifacts[0].From)
+	assert.Equal(t, "{{workflow.outputs.artifacts.app1-install-postgresql}}", emitStep.Arguments.Artifacts[1].From)
 }
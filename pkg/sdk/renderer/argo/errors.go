@@ -1,14 +1,17 @@
 package argo
 
 import (
+	"capact.io/capact/pkg/sdk/renderer"
+
 	"github.com/pkg/errors"
 )
 
 // NOTE: Change the error to Go struct if needed, e.g. someone needs to do such assertion `errors.Is(err, MaxDepthError)`
 
 // NewMaxDepthError indicates that the maximum depth of the nested actions was reached.
+// It is a permanent error, as rendering the same manifests again reaches the limit as well.
 func NewMaxDepthError(limit int) error {
-	return errors.Errorf("Exceeded maximum render depth level [max depth %d]", limit)
+	return renderer.NewPermanentError(renderer.InvalidManifestReason, errors.Errorf("Exceeded maximum render depth level [max depth %d]", limit))
 }
 
 // NewRunnerContextRefEmptyError indicates that the reference for the runner context is empty.
@@ -160,6 +160,36 @@ func findTypeInstanceInputRef(refs []types.InputTypeInstanceRef, name string) *t
 	return nil
 }
 
+func optionalInputTypeInstancesToProvide(impl hubpublicgraphql.ImplementationRevision) []InputTypeInstanceToProvide {
+	if impl.Spec == nil || impl.Spec.AdditionalInput == nil {
+		return nil
+	}
+
+	var out []InputTypeInstanceToProvide
+	for _, ti := range impl.Spec.AdditionalInput.TypeInstances {
+		if ti == nil || ti.TypeRef == nil {
+			continue
+		}
+		out = append(out, InputTypeInstanceToProvide{
+			Name:    ti.Name,
+			TypeRef: types.TypeRef(*ti.TypeRef),
+		})
+	}
+
+	return out
+}
+
+// renderingIterationTypeInstanceName returns the name under which user provides an optional input TypeInstance
+// for a given rendering iteration. The same Implementation may be imported multiple times, so the names are prefixed
+// with the unique iteration name. The root Implementation TypeInstances are provided as the Action input TypeInstances,
+// so their names are not prefixed.
+func renderingIterationTypeInstanceName(iterationName, name string) string {
+	if iterationName == "" || iterationName == rootRenderingIterationName {
+		return name
+	}
+	return addPrefix(iterationName, name)
+}
+
 func addPrefix(prefix, s string) string {
 	return fmt.Sprintf("%s-%s", prefix, s)
 }
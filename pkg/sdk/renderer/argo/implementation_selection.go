@@ -0,0 +1,279 @@
+package argo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"capact.io/capact/pkg/engine/k8s/policy"
+	hubpublicapi "capact.io/capact/pkg/hub/api/graphql/public"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"capact.io/capact/pkg/sdk/renderer"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+)
+
+// implementationCandidate holds an Implementation, which matched the Policy rule, with details used to rank it.
+type implementationCandidate struct {
+	impl hubpublicapi.ImplementationRevision
+
+	// discardReason is set if the Implementation doesn't meet requirements of a given selection strategy.
+	discardReason string
+
+	score   int
+	version *semver.Version
+	details string
+}
+
+// selectImplementationRevision selects a single Implementation from the Implementations, which matched a given Policy rule.
+// The input Implementations are sorted by path ascending and revision descending,
+// so Implementations ranked equally by the strategy are resolved in a deterministic way.
+func selectImplementationRevision(in []hubpublicapi.ImplementationRevision, rule policy.Rule) (hubpublicapi.ImplementationRevision, ImplementationSelection, error) {
+	if len(in) == 0 {
+		return hubpublicapi.ImplementationRevision{}, ImplementationSelection{}, renderer.NewPermanentError(renderer.ImplementationNotFoundReason, errors.New("No Implementations found with current policy for given Interface"))
+	}
+
+	// The Policy is validated when it is set. This covers Policies which bypassed it, e.g. edited directly in the ConfigMap.
+	if err := rule.ImplementationSelection.Validate(); err != nil {
+		return hubpublicapi.ImplementationRevision{}, ImplementationSelection{}, renderer.NewPermanentError(renderer.InvalidPolicyReason, errors.Wrap(err, "while validating Implementation selection"))
+	}
+
+	strategy := rule.SelectionStrategy()
+	candidates := make([]implementationCandidate, 0, len(in))
+	for _, impl := range in {
+		candidates = append(candidates, implementationCandidate{impl: impl})
+	}
+
+	var (
+		less func(a, b implementationCandidate) bool
+		err  error
+	)
+	switch strategy {
+	case policy.FirstImplementationSelection:
+		// business decision - pick first Implementation
+		less = func(a, b implementationCandidate) bool { return false }
+	case policy.AttributePreferenceImplementationSelection:
+		less, err = rankByAttributePreference(candidates, rule.ImplementationSelection.Attributes)
+	case policy.NewestRevisionImplementationSelection:
+		less = rankByNewestRevision(candidates)
+	case policy.HighestAppVersionImplementationSelection:
+		less, err = rankByHighestAppVersion(candidates, rule.ImplementationSelection.AppVersionConstraint)
+	case policy.WeightedScoreImplementationSelection:
+		less, err = rankByWeightedScore(candidates, rule.ImplementationSelection.Weights)
+	default:
+		err = errors.Errorf("unknown Implementation selection strategy %q", strategy)
+	}
+	if err != nil {
+		return hubpublicapi.ImplementationRevision{}, ImplementationSelection{}, renderer.NewPermanentError(renderer.InvalidPolicyReason, errors.Wrapf(err, "while ranking Implementations using %s strategy", strategy))
+	}
+
+	var eligible, discarded []implementationCandidate
+	for _, candidate := range candidates {
+		if candidate.discardReason != "" {
+			discarded = append(discarded, candidate)
+			continue
+		}
+		eligible = append(eligible, candidate)
+	}
+
+	if len(eligible) == 0 {
+		var reasons []string
+		for _, candidate := range discarded {
+			ref := implementationRef(candidate.impl)
+			reasons = append(reasons, fmt.Sprintf("%s: %s", ref.String(), candidate.discardReason))
+		}
+		return hubpublicapi.ImplementationRevision{}, ImplementationSelection{}, renderer.NewPermanentError(renderer.ImplementationNotFoundReason,
+			errors.Errorf("none of the Implementations can be selected using %s strategy: %s", strategy, strings.Join(reasons, ", ")))
+	}
+
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return less(eligible[i], eligible[j])
+	})
+
+	selected := eligible[0]
+	out := ImplementationSelection{
+		Strategy: strategy,
+		Selected: implementationRef(selected.impl),
+	}
+	for _, candidate := range eligible[1:] {
+		out.Discarded = append(out.Discarded, DiscardedImplementation{
+			Implementation: implementationRef(candidate.impl),
+			Reason:         rankedLowerReason(strategy, less, candidate, selected),
+		})
+	}
+	for _, candidate := range discarded {
+		out.Discarded = append(out.Discarded, DiscardedImplementation{
+			Implementation: implementationRef(candidate.impl),
+			Reason:         candidate.discardReason,
+		})
+	}
+
+	return selected.impl, out, nil
+}
+
+// rankByAttributePreference ranks Implementations by the most preferred Attribute they have.
+// Implementations without any of the preferred Attributes are ranked last.
+func rankByAttributePreference(candidates []implementationCandidate, attributes []types.ManifestRefWithOptRevision) (func(a, b implementationCandidate) bool, error) {
+	if len(attributes) == 0 {
+		return nil, errors.New("preferred Attributes must be provided")
+	}
+
+	for i := range candidates {
+		candidates[i].score = len(attributes)
+		candidates[i].details = "none of the preferred Attributes"
+		for idx, attr := range attributes {
+			if hasAttribute(candidates[i].impl, attr) {
+				candidates[i].score = idx
+				candidates[i].details = fmt.Sprintf("preferred Attribute %q", attr.Path)
+				break
+			}
+		}
+	}
+
+	return func(a, b implementationCandidate) bool {
+		return a.score < b.score
+	}, nil
+}
+
+// rankByNewestRevision ranks Implementations by revision. Implementations with invalid revision are discarded.
+func rankByNewestRevision(candidates []implementationCandidate) func(a, b implementationCandidate) bool {
+	for i := range candidates {
+		version, err := semver.NewVersion(candidates[i].impl.Revision)
+		if err != nil {
+			candidates[i].discardReason = fmt.Sprintf("invalid revision %q: %s", candidates[i].impl.Revision, err)
+			continue
+		}
+		candidates[i].version = version
+		candidates[i].details = fmt.Sprintf("revision %s", version.Original())
+	}
+
+	return func(a, b implementationCandidate) bool {
+		return a.version.GreaterThan(b.version)
+	}
+}
+
+// rankByHighestAppVersion ranks Implementations by the highest application version, which satisfies a given constraint.
+// Implementations without any application version satisfying the constraint are discarded.
+func rankByHighestAppVersion(candidates []implementationCandidate, constraintStr *string) (func(a, b implementationCandidate) bool, error) {
+	var constraint *semver.Constraints
+	if constraintStr != nil && *constraintStr != "" {
+		var err error
+		constraint, err = semver.NewConstraint(*constraintStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing appVersion constraint %q", *constraintStr)
+		}
+	}
+
+	for i := range candidates {
+		appVersion := ""
+		if candidates[i].impl.Spec != nil {
+			appVersion = candidates[i].impl.Spec.AppVersion
+		}
+
+		version := highestAppVersion(appVersion, constraint)
+		if version == nil && constraint == nil {
+			candidates[i].discardReason = fmt.Sprintf("appVersion %q doesn't contain any valid version", appVersion)
+			continue
+		}
+		if version == nil {
+			candidates[i].discardReason = fmt.Sprintf("appVersion %q doesn't satisfy constraint %q", appVersion, *constraintStr)
+			continue
+		}
+		candidates[i].version = version
+		candidates[i].details = fmt.Sprintf("appVersion %s", version.String())
+	}
+
+	return func(a, b implementationCandidate) bool {
+		return a.version.GreaterThan(b.version)
+	}, nil
+}
+
+// rankByWeightedScore ranks Implementations by the sum of weights of Attributes they have.
+func rankByWeightedScore(candidates []implementationCandidate, weights []policy.ImplementationAttributeWeight) (func(a, b implementationCandidate) bool, error) {
+	if len(weights) == 0 {
+		return nil, errors.New("Attribute weights must be provided")
+	}
+
+	for i := range candidates {
+		for _, weight := range weights {
+			if hasAttribute(candidates[i].impl, weight.Attribute) {
+				candidates[i].score += weight.Weight
+			}
+		}
+		candidates[i].details = fmt.Sprintf("score %d", candidates[i].score)
+	}
+
+	return func(a, b implementationCandidate) bool {
+		return a.score > b.score
+	}, nil
+}
+
+// highestAppVersion returns the highest version from the Implementation appVersion range, which satisfies a given constraint.
+// The appVersion is a comma-separated list of versions, where wildcard (`x`, `X` or `*`) components are treated as zero.
+func highestAppVersion(appVersion string, constraint *semver.Constraints) *semver.Version {
+	var highest *semver.Version
+	for _, item := range strings.Split(appVersion, ",") {
+		version, err := semver.NewVersion(normalizeVersionWildcards(strings.TrimSpace(item)))
+		if err != nil {
+			continue
+		}
+
+		if constraint != nil && !constraint.Check(version) {
+			continue
+		}
+
+		if highest == nil || version.GreaterThan(highest) {
+			highest = version
+		}
+	}
+
+	return highest
+}
+
+func normalizeVersionWildcards(in string) string {
+	parts := strings.Split(in, ".")
+	for i, part := range parts {
+		switch part {
+		case "x", "X", "*":
+			parts[i] = "0"
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func hasAttribute(impl hubpublicapi.ImplementationRevision, ref types.ManifestRefWithOptRevision) bool {
+	if impl.Metadata == nil {
+		return false
+	}
+
+	for _, attr := range impl.Metadata.Attributes {
+		if attr == nil || attr.Metadata == nil || attr.Metadata.Path != ref.Path {
+			continue
+		}
+		if ref.Revision != nil && *ref.Revision != attr.Revision {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
+
+func rankedLowerReason(strategy policy.ImplementationSelectionStrategy, less func(a, b implementationCandidate) bool, candidate, selected implementationCandidate) string {
+	if strategy == policy.FirstImplementationSelection {
+		return "not the first matching Implementation"
+	}
+	if !less(selected, candidate) {
+		return fmt.Sprintf("ranked equally with the selected Implementation (%s), which is listed first", candidate.details)
+	}
+	return fmt.Sprintf("ranked lower than the selected Implementation: has %s, the selected one has %s", candidate.details, selected.details)
+}
+
+func implementationRef(impl hubpublicapi.ImplementationRevision) types.ManifestRef {
+	ref := types.ManifestRef{Revision: impl.Revision}
+	if impl.Metadata != nil {
+		ref.Path = impl.Metadata.Path
+	}
+	return ref
+}
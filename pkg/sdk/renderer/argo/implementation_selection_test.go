@@ -0,0 +1,192 @@
+package argo
+
+import (
+	"testing"
+
+	"capact.io/capact/internal/ptr"
+	"capact.io/capact/pkg/engine/k8s/policy"
+	hubpublicapi "capact.io/capact/pkg/hub/api/graphql/public"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectImplementationRevision(t *testing.T) {
+	// given
+	implementations := []hubpublicapi.ImplementationRevision{
+		fixImplementationRevision("cap.implementation.aws.postgresql.install", "0.1.0", "11.x, 12.x", "cap.attribute.cloud.provider.aws"),
+		fixImplementationRevision("cap.implementation.bitnami.postgresql.install", "0.2.0", "10.x", "cap.attribute.infra.kubernetes"),
+		fixImplementationRevision("cap.implementation.gcp.postgresql.install", "0.1.1", "13.1.0", "cap.attribute.cloud.provider.gcp", "cap.attribute.infra.managed"),
+	}
+
+	tests := []struct {
+		name              string
+		selection         *policy.ImplementationSelection
+		expectedPath      string
+		expectedDiscarded []DiscardedImplementation
+		expectedErr       string
+	}{
+		{
+			name:         "Default strategy picks first Implementation",
+			expectedPath: "cap.implementation.aws.postgresql.install",
+			expectedDiscarded: []DiscardedImplementation{
+				{
+					Implementation: types.ManifestRef{Path: "cap.implementation.bitnami.postgresql.install", Revision: "0.2.0"},
+					Reason:         "not the first matching Implementation",
+				},
+				{
+					Implementation: types.ManifestRef{Path: "cap.implementation.gcp.postgresql.install", Revision: "0.1.1"},
+					Reason:         "not the first matching Implementation",
+				},
+			},
+		},
+		{
+			name: "Attribute preference",
+			selection: &policy.ImplementationSelection{
+				Strategy: policy.AttributePreferenceImplementationSelection,
+				Attributes: []types.ManifestRefWithOptRevision{
+					{Path: "cap.attribute.cloud.provider.gcp"},
+					{Path: "cap.attribute.infra.kubernetes"},
+				},
+			},
+			expectedPath: "cap.implementation.gcp.postgresql.install",
+			expectedDiscarded: []DiscardedImplementation{
+				{
+					Implementation: types.ManifestRef{Path: "cap.implementation.bitnami.postgresql.install", Revision: "0.2.0"},
+					Reason:         `ranked lower than the selected Implementation: has preferred Attribute "cap.attribute.infra.kubernetes", the selected one has preferred Attribute "cap.attribute.cloud.provider.gcp"`,
+				},
+				{
+					Implementation: types.ManifestRef{Path: "cap.implementation.aws.postgresql.install", Revision: "0.1.0"},
+					Reason:         `ranked lower than the selected Implementation: has none of the preferred Attributes, the selected one has preferred Attribute "cap.attribute.cloud.provider.gcp"`,
+				},
+			},
+		},
+		{
+			name: "Newest revision",
+			selection: &policy.ImplementationSelection{
+				Strategy: policy.NewestRevisionImplementationSelection,
+			},
+			expectedPath: "cap.implementation.bitnami.postgresql.install",
+		},
+		{
+			name: "Highest appVersion satisfying constraint",
+			selection: &policy.ImplementationSelection{
+				Strategy:             policy.HighestAppVersionImplementationSelection,
+				AppVersionConstraint: ptr.String("< 13"),
+			},
+			expectedPath: "cap.implementation.aws.postgresql.install",
+			expectedDiscarded: []DiscardedImplementation{
+				{
+					Implementation: types.ManifestRef{Path: "cap.implementation.bitnami.postgresql.install", Revision: "0.2.0"},
+					Reason:         "ranked lower than the selected Implementation: has appVersion 10.0.0, the selected one has appVersion 12.0.0",
+				},
+				{
+					Implementation: types.ManifestRef{Path: "cap.implementation.gcp.postgresql.install", Revision: "0.1.1"},
+					Reason:         `appVersion "13.1.0" doesn't satisfy constraint "< 13"`,
+				},
+			},
+		},
+		{
+			name: "Weighted score",
+			selection: &policy.ImplementationSelection{
+				Strategy: policy.WeightedScoreImplementationSelection,
+				Weights: []policy.ImplementationAttributeWeight{
+					{Attribute: types.ManifestRefWithOptRevision{Path: "cap.attribute.cloud.provider.aws"}, Weight: 5},
+					{Attribute: types.ManifestRefWithOptRevision{Path: "cap.attribute.cloud.provider.gcp"}, Weight: 3},
+					{Attribute: types.ManifestRefWithOptRevision{Path: "cap.attribute.infra.managed"}, Weight: 3},
+				},
+			},
+			expectedPath: "cap.implementation.gcp.postgresql.install",
+		},
+		{
+			name: "Equal score resolved by Hub order",
+			selection: &policy.ImplementationSelection{
+				Strategy: policy.WeightedScoreImplementationSelection,
+				Weights: []policy.ImplementationAttributeWeight{
+					{Attribute: types.ManifestRefWithOptRevision{Path: "cap.attribute.cloud.provider.aws"}, Weight: 1},
+					{Attribute: types.ManifestRefWithOptRevision{Path: "cap.attribute.infra.kubernetes"}, Weight: 1},
+				},
+			},
+			expectedPath: "cap.implementation.aws.postgresql.install",
+		},
+		{
+			name: "No Implementation satisfies constraint",
+			selection: &policy.ImplementationSelection{
+				Strategy:             policy.HighestAppVersionImplementationSelection,
+				AppVersionConstraint: ptr.String(">= 14"),
+			},
+			expectedErr: `none of the Implementations can be selected using HIGHEST_APP_VERSION strategy: ` +
+				`cap.implementation.aws.postgresql.install:0.1.0: appVersion "11.x, 12.x" doesn't satisfy constraint ">= 14", ` +
+				`cap.implementation.bitnami.postgresql.install:0.2.0: appVersion "10.x" doesn't satisfy constraint ">= 14", ` +
+				`cap.implementation.gcp.postgresql.install:0.1.1: appVersion "13.1.0" doesn't satisfy constraint ">= 14"`,
+		},
+		{
+			name: "Missing Attributes for attribute preference",
+			selection: &policy.ImplementationSelection{
+				Strategy: policy.AttributePreferenceImplementationSelection,
+			},
+			expectedErr: "while validating Implementation selection: preferred Attributes must be provided for ATTRIBUTE_PREFERENCE strategy",
+		},
+		{
+			name: "Unknown strategy",
+			selection: &policy.ImplementationSelection{
+				Strategy: "RANDOM",
+			},
+			expectedErr: `while validating Implementation selection: unknown Implementation selection strategy "RANDOM"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := policy.Rule{ImplementationSelection: tt.selection}
+
+			// when
+			impl, selection, err := selectImplementationRevision(implementations, rule)
+
+			// then
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPath, impl.Metadata.Path)
+			assert.Equal(t, rule.SelectionStrategy(), selection.Strategy)
+			assert.Equal(t, tt.expectedPath, selection.Selected.Path)
+			assert.Len(t, selection.Discarded, len(implementations)-1)
+			if tt.expectedDiscarded != nil {
+				assert.Equal(t, tt.expectedDiscarded, selection.Discarded)
+			}
+		})
+	}
+}
+
+func TestSelectImplementationRevisionNoImplementations(t *testing.T) {
+	// when
+	_, _, err := selectImplementationRevision(nil, policy.Rule{})
+
+	// then
+	assert.EqualError(t, err, "No Implementations found with current policy for given Interface")
+}
+
+func fixImplementationRevision(path, revision, appVersion string, attributes ...string) hubpublicapi.ImplementationRevision {
+	var attrs []*hubpublicapi.AttributeRevision
+	for _, attr := range attributes {
+		attrs = append(attrs, &hubpublicapi.AttributeRevision{
+			Revision: "0.1.0",
+			Metadata: &hubpublicapi.GenericMetadata{
+				Path: attr,
+			},
+		})
+	}
+
+	return hubpublicapi.ImplementationRevision{
+		Revision: revision,
+		Metadata: &hubpublicapi.ImplementationMetadata{
+			Path:       path,
+			Attributes: attrs,
+		},
+		Spec: &hubpublicapi.ImplementationSpec{
+			AppVersion: appVersion,
+		},
+	}
+}
@@ -52,3 +52,15 @@ func WithOwnerID(ownerID string) RendererOption {
 		r.ownerID = &ownerID
 	}
 }
+
+// WithAdvancedRendering returns a RendererOption, which enables the advanced rendering mode.
+// In this mode, rendering stops on each rendering iteration which comes after the approved one.
+// If approvedIterationName is empty, rendering stops on the first rendering iteration.
+func WithAdvancedRendering(approvedIterationName string) RendererOption {
+	return func(r *dedicatedRenderer) {
+		r.advancedRendering = &advancedRendering{
+			approvedIterationName:    approvedIterationName,
+			approvedIterationReached: approvedIterationName == "",
+		}
+	}
+}
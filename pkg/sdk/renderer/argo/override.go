@@ -0,0 +1,216 @@
+package argo
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/pkg/errors"
+)
+
+const (
+	workflowArgKey = "workflow"
+	appActionEnv   = "APP_ACTION"
+)
+
+// typeInstanceHandlerActions holds actions used by the TypeInstance handler templates.
+// Such templates download, upload, and update TypeInstances, so they cannot be changed by the Action override.
+var typeInstanceHandlerActions = []string{"DownloadAction", "UploadAction", "UpdateAction"}
+
+// ValidateActionOverride validates the rendered Action override against the Action rendered by Engine.
+// The override must use the same runner Interface and contain the same TypeInstance download, upload and update
+// templates and steps, as they are generated by the renderer and are required to handle TypeInstances properly.
+// The steps are compared together with their arguments and conditions, as the arguments hold the TypeInstance payloads.
+// Other parts of the workflow, such as image tags or retry strategies, can be changed freely.
+func ValidateActionOverride(rendered, override *types.Action) error {
+	if rendered == nil || override == nil {
+		return errors.New("both rendered Action and its override must be provided")
+	}
+
+	if override.RunnerInterface != rendered.RunnerInterface {
+		return errors.Errorf("runner Interface %q from the override doesn't match the rendered one %q", override.RunnerInterface, rendered.RunnerInterface)
+	}
+
+	renderedWf, err := workflowSpecFromAction(rendered)
+	if err != nil {
+		return errors.Wrap(err, "while getting workflow from the rendered Action")
+	}
+
+	overrideWf, err := workflowSpecFromAction(override)
+	if err != nil {
+		return errors.Wrap(err, "while getting workflow from the Action override")
+	}
+
+	for _, action := range typeInstanceHandlerActions {
+		expected, err := typeInstanceHandlerTemplates(renderedWf, action)
+		if err != nil {
+			return errors.Wrapf(err, "while getting %s templates from the rendered Action", action)
+		}
+
+		got, err := typeInstanceHandlerTemplates(overrideWf, action)
+		if err != nil {
+			return errors.Wrapf(err, "while getting %s templates from the Action override", action)
+		}
+
+		if !reflect.DeepEqual(expected, got) {
+			return errors.Errorf("templates with %s in the override don't match the rendered ones", action)
+		}
+	}
+
+	if err := ensureTypeInstanceHandlerTemplatesUsed(overrideWf); err != nil {
+		return errors.Wrap(err, "while validating Action override")
+	}
+
+	for _, action := range typeInstanceHandlerActions {
+		expected, err := typeInstanceHandlerSteps(renderedWf, action)
+		if err != nil {
+			return errors.Wrapf(err, "while getting %s steps from the rendered Action", action)
+		}
+
+		got, err := typeInstanceHandlerSteps(overrideWf, action)
+		if err != nil {
+			return errors.Wrapf(err, "while getting %s steps from the Action override", action)
+		}
+
+		if !reflect.DeepEqual(expected, got) {
+			return errors.Errorf("steps with %s in the override don't match the rendered ones", action)
+		}
+	}
+
+	return nil
+}
+
+func workflowSpecFromAction(action *types.Action) (*wfv1.WorkflowSpec, error) {
+	rawWf, found := action.Args[workflowArgKey]
+	if !found {
+		return nil, errors.Errorf("missing %q argument", workflowArgKey)
+	}
+
+	data, err := json.Marshal(rawWf)
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling workflow")
+	}
+
+	spec := &wfv1.WorkflowSpec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling workflow")
+	}
+
+	return spec, nil
+}
+
+// typeInstanceHandlerTemplates returns sorted JSON representations of templates for a given TypeInstance handler action.
+// Template names are skipped, as they contain randomly generated suffixes.
+func typeInstanceHandlerTemplates(spec *wfv1.WorkflowSpec, action string) ([]string, error) {
+	var out []string
+	for i := range spec.Templates {
+		tpl := &spec.Templates[i]
+		if typeInstanceHandlerAction(tpl) != action {
+			continue
+		}
+
+		tplCpy := tpl.DeepCopy()
+		tplCpy.Name = ""
+		data, err := json.Marshal(tplCpy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while marshaling template %q", tpl.Name)
+		}
+		out = append(out, string(data))
+	}
+
+	sort.Strings(out)
+	return out, nil
+}
+
+// typeInstanceHandlerSteps returns sorted JSON representations of steps, which call templates for a given
+// TypeInstance handler action. Template names are replaced with the template content, as they contain randomly
+// generated suffixes. For the same reason, step names derived from the template name are stored without the template name.
+func typeInstanceHandlerSteps(spec *wfv1.WorkflowSpec, action string) ([]string, error) {
+	handlerTemplates := map[string]*wfv1.Template{}
+	for i := range spec.Templates {
+		tpl := &spec.Templates[i]
+		if typeInstanceHandlerAction(tpl) != action {
+			continue
+		}
+		handlerTemplates[tpl.Name] = tpl
+	}
+
+	var out []string
+	for i := range spec.Templates {
+		for _, parallelSteps := range spec.Templates[i].Steps {
+			for _, step := range parallelSteps.Steps {
+				tpl, found := handlerTemplates[step.Template]
+				if !found {
+					continue
+				}
+
+				tplCpy := tpl.DeepCopy()
+				tplCpy.Name = ""
+				stepCpy := step.DeepCopy()
+				stepCpy.Template = ""
+				stepCpy.Name = strings.TrimPrefix(stepCpy.Name, tpl.Name)
+
+				data, err := json.Marshal(struct {
+					Step     *wfv1.WorkflowStep `json:"step"`
+					Template *wfv1.Template     `json:"template"`
+				}{
+					Step:     stepCpy,
+					Template: tplCpy,
+				})
+				if err != nil {
+					return nil, errors.Wrapf(err, "while marshaling step %q", step.Name)
+				}
+				out = append(out, string(data))
+			}
+		}
+	}
+
+	sort.Strings(out)
+	return out, nil
+}
+
+func ensureTypeInstanceHandlerTemplatesUsed(spec *wfv1.WorkflowSpec) error {
+	usedTemplates := map[string]struct{}{}
+	for i := range spec.Templates {
+		for _, parallelSteps := range spec.Templates[i].Steps {
+			for _, step := range parallelSteps.Steps {
+				usedTemplates[step.Template] = struct{}{}
+			}
+		}
+	}
+
+	for i := range spec.Templates {
+		tpl := &spec.Templates[i]
+		if typeInstanceHandlerAction(tpl) == "" {
+			continue
+		}
+		if _, found := usedTemplates[tpl.Name]; !found {
+			return errors.Errorf("template %q is not used by any workflow step", tpl.Name)
+		}
+	}
+
+	return nil
+}
+
+func typeInstanceHandlerAction(tpl *wfv1.Template) string {
+	if tpl.Container == nil {
+		return ""
+	}
+
+	for _, env := range tpl.Container.Env {
+		if env.Name != appActionEnv {
+			continue
+		}
+		for _, action := range typeInstanceHandlerActions {
+			if env.Value == action {
+				return action
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,235 @@
+package argo
+
+import (
+	"testing"
+
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateActionOverride(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(action *types.Action)
+		expectedErr string
+	}{
+		{
+			name: "Changed image of Implementation template",
+			modify: func(action *types.Action) {
+				workflowTemplates(action)[0].(map[string]interface{})["container"].(map[string]interface{})["image"] = "alpine:3.14"
+			},
+		},
+		{
+			name: "Renamed TypeInstance handler template",
+			modify: func(action *types.Action) {
+				tpls := workflowTemplates(action)
+				tpls[1].(map[string]interface{})["name"] = "upload-renamed"
+				uploadStep(action)["template"] = "upload-renamed"
+				uploadStep(action)["name"] = "upload-renamed-step"
+			},
+		},
+		{
+			name: "Changed runner Interface",
+			modify: func(action *types.Action) {
+				action.RunnerInterface = "cap.interface.runner.helm.install"
+			},
+			expectedErr: `runner Interface "cap.interface.runner.helm.install" from the override doesn't match the rendered one "cap.interface.runner.argo.run"`,
+		},
+		{
+			name: "Missing workflow",
+			modify: func(action *types.Action) {
+				delete(action.Args, "workflow")
+			},
+			expectedErr: `while getting workflow from the Action override: missing "workflow" argument`,
+		},
+		{
+			name: "Changed upload template",
+			modify: func(action *types.Action) {
+				workflowTemplates(action)[1].(map[string]interface{})["container"].(map[string]interface{})["image"] = "alpine:3.14"
+			},
+			expectedErr: "templates with UploadAction in the override don't match the rendered ones",
+		},
+		{
+			name: "Removed upload step",
+			modify: func(action *types.Action) {
+				wf := action.Args["workflow"].(map[string]interface{})
+				tpl := wf["templates"].([]interface{})[2].(map[string]interface{})
+				tpl["steps"] = tpl["steps"].([]interface{})[:1]
+			},
+			expectedErr: `while validating Action override: template "upload-output-type-instances" is not used by any workflow step`,
+		},
+		{
+			name: "Renamed upload step",
+			modify: func(action *types.Action) {
+				uploadStep(action)["name"] = "upload"
+			},
+			expectedErr: "steps with UploadAction in the override don't match the rendered ones",
+		},
+		{
+			name: "Changed TypeInstance backend in upload payload",
+			modify: func(action *types.Action) {
+				setPayload(uploadStep(action), fixUploadPayload("owner", "other-backend"))
+			},
+			expectedErr: "steps with UploadAction in the override don't match the rendered ones",
+		},
+		{
+			name: "Changed TypeInstance creator in upload payload",
+			modify: func(action *types.Action) {
+				setPayload(uploadStep(action), fixUploadPayload("other-owner", "backend-id"))
+			},
+			expectedErr: "steps with UploadAction in the override don't match the rendered ones",
+		},
+		{
+			name: "Changed owner ID in update payload",
+			modify: func(action *types.Action) {
+				setPayload(updateStep(action), fixUpdatePayload("other-owner"))
+			},
+			expectedErr: "steps with UpdateAction in the override don't match the rendered ones",
+		},
+		{
+			name: "Added condition to update step",
+			modify: func(action *types.Action) {
+				updateStep(action)["when"] = "false"
+			},
+			expectedErr: "steps with UpdateAction in the override don't match the rendered ones",
+		},
+		{
+			name: "Duplicated upload step",
+			modify: func(action *types.Action) {
+				steps := rootTemplateSteps(action)
+				wf := action.Args["workflow"].(map[string]interface{})
+				wf["templates"].([]interface{})[2].(map[string]interface{})["steps"] = append(steps, steps[1])
+			},
+			expectedErr: "steps with UploadAction in the override don't match the rendered ones",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			rendered := fixRenderedAction()
+			override := fixRenderedAction()
+			tt.modify(override)
+
+			// when
+			err := ValidateActionOverride(rendered, override)
+
+			// then
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func workflowTemplates(action *types.Action) []interface{} {
+	return action.Args["workflow"].(map[string]interface{})["templates"].([]interface{})
+}
+
+func rootTemplateSteps(action *types.Action) []interface{} {
+	return workflowTemplates(action)[2].(map[string]interface{})["steps"].([]interface{})
+}
+
+func uploadStep(action *types.Action) map[string]interface{} {
+	return rootTemplateSteps(action)[1].([]interface{})[0].(map[string]interface{})
+}
+
+func updateStep(action *types.Action) map[string]interface{} {
+	return rootTemplateSteps(action)[2].([]interface{})[0].(map[string]interface{})
+}
+
+func setPayload(step map[string]interface{}, payload string) {
+	step["arguments"] = fixPayloadArguments(payload)
+}
+
+func fixPayloadArguments(payload string) map[string]interface{} {
+	return map[string]interface{}{
+		"artifacts": []interface{}{
+			map[string]interface{}{
+				"name": "payload",
+				"raw": map[string]interface{}{
+					"data": payload,
+				},
+			},
+		},
+	}
+}
+
+func fixUploadPayload(createdBy, backendID string) string {
+	return "typeInstances:\n- alias: config\n  createdBy: " + createdBy + "\n  backend:\n    id: " + backendID + "\n"
+}
+
+func fixUpdatePayload(ownerID string) string {
+	return "- id: ti-id\n  ownerID: " + ownerID + "\n"
+}
+
+func fixRenderedAction() *types.Action {
+	return &types.Action{
+		RunnerInterface: "cap.interface.runner.argo.run",
+		Args: map[string]interface{}{
+			"workflow": map[string]interface{}{
+				"entrypoint": "capact-root",
+				"templates": []interface{}{
+					map[string]interface{}{
+						"name": "install",
+						"container": map[string]interface{}{
+							"image": "alpine:3.7",
+						},
+					},
+					map[string]interface{}{
+						"name": "upload-output-type-instances",
+						"container": map[string]interface{}{
+							"image": "hub-actions:0.1.0",
+							"env": []interface{}{
+								map[string]interface{}{
+									"name":  "APP_ACTION",
+									"value": "UploadAction",
+								},
+							},
+						},
+					},
+					map[string]interface{}{
+						"name": "capact-root",
+						"steps": []interface{}{
+							[]interface{}{
+								map[string]interface{}{
+									"name":     "install-step",
+									"template": "install",
+								},
+							},
+							[]interface{}{
+								map[string]interface{}{
+									"name":      "upload-output-type-instances-step",
+									"template":  "upload-output-type-instances",
+									"arguments": fixPayloadArguments(fixUploadPayload("owner", "backend-id")),
+								},
+							},
+							[]interface{}{
+								map[string]interface{}{
+									"name":      "upload-update-type-instances-step",
+									"template":  "upload-update-type-instances",
+									"arguments": fixPayloadArguments(fixUpdatePayload("owner")),
+								},
+							},
+						},
+					},
+					map[string]interface{}{
+						"name": "upload-update-type-instances",
+						"container": map[string]interface{}{
+							"image": "hub-actions:0.1.0",
+							"env": []interface{}{
+								map[string]interface{}{
+									"name":  "APP_ACTION",
+									"value": "UpdateAction",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -18,6 +18,9 @@ import (
 
 const (
 	runnerContext = "runner-context"
+
+	// rootRenderingIterationName is the name of the advanced rendering iteration for the root Implementation.
+	rootRenderingIterationName = "capact-root"
 )
 
 // PolicyEnforcedHubClient is a interfaces used to interact with the Capact Hubs
@@ -89,6 +92,10 @@ func (r *Renderer) Render(ctx context.Context, input *RenderInput) (*RenderOutpu
 	if err != nil {
 		return nil, err
 	}
+	if iface == nil {
+		return nil, renderer.NewPermanentError(renderer.InterfaceNotFoundReason,
+			errors.Errorf(`Interface "%s:%s" not found in Hub`, interfaceRef.Path, interfaceRef.Revision))
+	}
 
 	// 1.2 Get all ImplementationRevisions for a given Interface
 	implementations, rule, err := policyEnforcedClient.ListImplementationRevisionForInterface(ctxWithTimeout, interfaceRef)
@@ -99,17 +106,21 @@ func (r *Renderer) Render(ctx context.Context, input *RenderInput) (*RenderOutpu
 	}
 
 	// 1.3 Pick one of the Implementations
-	implementation, err := dedicatedRenderer.PickImplementationRevision(implementations)
+	implementation, err := dedicatedRenderer.PickImplementationRevision(rootRenderingIterationName, interfaceRef, rule, implementations)
 	if err != nil {
 		return nil, errors.Wrapf(err, `while picking ImplementationRevision for Interface "%s:%s"`,
 			interfaceRef.Path, interfaceRef.Revision)
 	}
 
+	// 1.4 In advanced rendering mode, stop if user didn't approve the root rendering iteration yet
+	if err := dedicatedRenderer.StopOnRenderingIterationIfNotApproved(rootRenderingIterationName, implementation); err != nil {
+		return r.renderingIterationOutputOrError(dedicatedRenderer, err)
+	}
+
 	// 2. Ensure that the runner was defined in imports section
-	// TODO: we should check whether imported revision is valid for this render algorithm
 	runnerInterface, err := dedicatedRenderer.ResolveRunnerInterface(implementation)
 	if err != nil {
-		return nil, errors.Wrap(err, "while resolving runner Interface")
+		return nil, renderer.NewPermanentError(renderer.InvalidManifestReason, errors.Wrap(err, "while resolving runner Interface"))
 	}
 
 	// 3. Extract workflow from the root Implementation
@@ -161,7 +172,7 @@ func (r *Renderer) Render(ctx context.Context, input *RenderInput) (*RenderOutpu
 	validateInput := renderer.InterfaceInput{
 		Interface:     iface,
 		Parameters:    dedicatedRenderer.inputParametersCollection,
-		TypeInstances: dedicatedRenderer.inputTypeInstances,
+		TypeInstances: dedicatedRenderer.InputTypeInstancesToValidate(iface),
 	}
 	err = r.wfValidator.ValidateInterfaceInput(ctx, validateInput)
 	if err != nil {
@@ -197,7 +208,7 @@ func (r *Renderer) Render(ctx context.Context, input *RenderInput) (*RenderOutpu
 		Rule:     rule,
 	}, dedicatedRenderer.inputTypeInstances, "")
 	if err != nil {
-		return nil, err
+		return r.renderingIterationOutputOrError(dedicatedRenderer, err)
 	}
 
 	rootWorkflow.Templates = dedicatedRenderer.GetRootTemplates()
@@ -206,6 +217,11 @@ func (r *Renderer) Render(ctx context.Context, input *RenderInput) (*RenderOutpu
 		return nil, err
 	}
 
+	// 11. Inject retry strategy based on Policy
+	if err := injectRetryStrategy(rootWorkflow, policyEnforcedClient.MergedPolicy().Retry); err != nil {
+		return nil, renderer.NewPermanentError(renderer.InvalidPolicyReason, errors.Wrap(err, "while injecting retry strategy based on Policy"))
+	}
+
 	out, err := r.toMapStringInterface(rootWorkflow)
 	if err != nil {
 		return nil, err
@@ -214,9 +230,24 @@ func (r *Renderer) Render(ctx context.Context, input *RenderInput) (*RenderOutpu
 	return &RenderOutput{
 		Action: &types.Action{
 			Args:            out,
-			RunnerInterface: runnerInterface,
+			RunnerInterface: runnerInterface.Path,
 		},
-		TypeInstancesToLock: dedicatedRenderer.GetTypeInstancesToLock(),
+		RunnerInterface:          runnerInterface,
+		TypeInstancesToLock:      dedicatedRenderer.GetTypeInstancesToLock(),
+		ImplementationSelections: dedicatedRenderer.GetImplementationSelections(),
+	}, nil
+}
+
+// renderingIterationOutputOrError returns output with the rendering iteration details, if the rendering was stopped
+// in the advanced rendering mode. Otherwise, it returns a given error.
+func (r *Renderer) renderingIterationOutputOrError(dedicatedRenderer *dedicatedRenderer, err error) (*RenderOutput, error) {
+	if !errors.Is(err, errRenderingIterationNotApproved) {
+		return nil, err
+	}
+
+	return &RenderOutput{
+		RenderingIteration:       dedicatedRenderer.GetRenderingIteration(),
+		ImplementationSelections: dedicatedRenderer.GetImplementationSelections(),
 	}, nil
 }
 
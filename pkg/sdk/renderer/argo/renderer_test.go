@@ -177,6 +177,7 @@ func TestRenderHappyPath(t *testing.T) {
 			require.NoError(t, err)
 			assertYAMLGoldenFile(t, renderOutput.Action, t.Name())
 			assert.Equal(t, tt.typeInstancesToLock, renderOutput.TypeInstancesToLock)
+			assert.Equal(t, types.ManifestRef{Path: "cap.interface.runner.argo.run", Revision: "0.1.0"}, renderOutput.RunnerInterface)
 		})
 	}
 }
@@ -340,6 +341,153 @@ func TestRenderHappyPathWithCustomPolicies(t *testing.T) {
 	}
 }
 
+func TestRenderAdvancedRenderingIteration(t *testing.T) {
+	// given
+	fakeCli, err := fake.NewFromLocal("testdata/hub", false)
+	require.NoError(t, err)
+
+	policy := policy.NewAllowAll()
+	typeInstanceHandler := NewTypeInstanceHandler(hubActionsImage, localHubEndpoint, publicHubEndpoint)
+	typeInstanceHandler.SetGenUUID(genUUIDFn(""))
+
+	interfaceIOValidator := actionvalidation.NewValidator(fakeCli)
+	policyIOValidator := policyvalidation.NewValidator(fakeCli)
+	wfValidator := renderer.NewWorkflowInputValidator(interfaceIOValidator, policyIOValidator)
+
+	argoRenderer := NewRenderer(logger.Noop(), renderer.Config{
+		RenderTimeout: time.Second,
+		MaxDepth:      20,
+	}, fakeCli, typeInstanceHandler, wfValidator)
+
+	interfaceRef := types.InterfaceRef{
+		Path: "cap.interface.productivity.mattermost.install",
+	}
+
+	expIteration := &RenderingIteration{
+		Name: rootRenderingIterationName,
+		InputTypeInstancesToProvide: []InputTypeInstanceToProvide{
+			{
+				Name: "postgresql",
+				TypeRef: types.TypeRef{
+					Path:     "cap.type.database.postgresql.config",
+					Revision: "0.1.0",
+				},
+			},
+		},
+	}
+
+	// when
+	renderOutput, err := argoRenderer.Render(
+		context.Background(),
+		&RenderInput{
+			RunnerContextSecretRef: RunnerContextSecretRef{Name: "secret", Key: "key"},
+			InterfaceRef:           interfaceRef,
+			Options: []RendererOption{
+				WithGlobalPolicy(policy),
+				WithSecretUserInput(&UserInputSecretRef{
+					Name: "user-input",
+				}, types.ParametersCollection{
+					"input-parameters": `{"host":"mattermost.local"}`,
+				}),
+				WithAdvancedRendering(""),
+			},
+		},
+	)
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, renderOutput)
+	assert.Nil(t, renderOutput.Action)
+	assert.Empty(t, renderOutput.TypeInstancesToLock)
+	assert.Equal(t, expIteration, renderOutput.RenderingIteration)
+}
+
+func TestRenderAdvancedRenderingContinueIterations(t *testing.T) {
+	// given
+	fakeCli, err := fake.NewFromLocal("testdata/hub", false)
+	require.NoError(t, err)
+
+	policy := policy.NewAllowAll()
+	typeInstanceHandler := NewTypeInstanceHandler(hubActionsImage, localHubEndpoint, publicHubEndpoint)
+	typeInstanceHandler.SetGenUUID(genUUIDFn(""))
+
+	interfaceIOValidator := actionvalidation.NewValidator(fakeCli)
+	policyIOValidator := policyvalidation.NewValidator(fakeCli)
+	wfValidator := renderer.NewWorkflowInputValidator(interfaceIOValidator, policyIOValidator)
+
+	argoRenderer := NewRenderer(logger.Noop(), renderer.Config{
+		RenderTimeout: time.Second,
+		MaxDepth:      20,
+	}, fakeCli, typeInstanceHandler, wfValidator)
+
+	// Both app1 and app2 Implementations have the same optional `postgresql` input TypeInstance.
+	render := func(approvedIterationName string, typeInstances []types.InputTypeInstanceRef) *RenderOutput {
+		renderOutput, err := argoRenderer.Render(
+			context.Background(),
+			&RenderInput{
+				RunnerContextSecretRef: RunnerContextSecretRef{Name: "secret", Key: "key"},
+				InterfaceRef: types.InterfaceRef{
+					Path: "cap.interface.app-stack.stack.install",
+				},
+				Options: []RendererOption{
+					WithGlobalPolicy(policy),
+					WithOwnerID("default/action"),
+					WithSecretUserInput(&UserInputSecretRef{
+						Name: "user-input",
+					}, types.ParametersCollection{
+						"input-parameters": `{"key":true}`,
+					}),
+					WithTypeInstances(typeInstances),
+					WithAdvancedRendering(approvedIterationName),
+				},
+			},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, renderOutput)
+		return renderOutput
+	}
+
+	expTypeInstanceToProvide := func(iterationName string) []InputTypeInstanceToProvide {
+		return []InputTypeInstanceToProvide{
+			{
+				Name: iterationName + "-postgresql",
+				TypeRef: types.TypeRef{
+					Path:     "cap.type.database.postgresql.config",
+					Revision: "0.1.0",
+				},
+			},
+		}
+	}
+
+	// when
+	firstOutput := render("", nil)
+
+	// then
+	first := firstOutput.RenderingIteration
+	require.NotNil(t, first)
+	assert.Equal(t, expTypeInstanceToProvide(first.Name), first.InputTypeInstancesToProvide)
+
+	// when
+	typeInstances := []types.InputTypeInstanceRef{
+		{Name: first.Name + "-postgresql", ID: "f2421415-b8a4-464b-be12-b617794411c5"},
+	}
+	secondOutput := render(first.Name, typeInstances)
+
+	// then
+	second := secondOutput.RenderingIteration
+	require.NotNil(t, second)
+	assert.NotEqual(t, first.Name, second.Name)
+	assert.Equal(t, expTypeInstanceToProvide(second.Name), second.InputTypeInstancesToProvide)
+
+	// when
+	finalOutput := render(second.Name, typeInstances)
+
+	// then
+	assert.Nil(t, finalOutput.RenderingIteration)
+	require.NotNil(t, finalOutput.Action)
+	assert.NotEmpty(t, finalOutput.Action.Args)
+}
+
 func TestRendererMaxDepth(t *testing.T) {
 	// given
 	fakeCli, err := fake.NewFromLocal("testdata/hub", false)
@@ -376,6 +524,7 @@ func TestRendererMaxDepth(t *testing.T) {
 
 	// then
 	assert.EqualError(t, err, "Exceeded maximum render depth level [max depth 3]")
+	assertPermanentErrorReason(t, err, renderer.InvalidManifestReason)
 	assert.Nil(t, renderOutput)
 }
 
@@ -416,9 +565,56 @@ func TestRendererDenyAllPolicy(t *testing.T) {
 	// then
 	assert.EqualError(t, err,
 		`while picking ImplementationRevision for Interface "cap.interface.productivity.mattermost.install:": No Implementations found with current policy for given Interface`)
+	assertPermanentErrorReason(t, err, renderer.ImplementationNotFoundReason)
+	assert.Nil(t, renderOutput)
+}
+
+func TestRendererInterfaceNotFound(t *testing.T) {
+	// given
+	fakeCli, err := fake.NewFromLocal("testdata/hub", false)
+	require.NoError(t, err)
+
+	typeInstanceHandler := NewTypeInstanceHandler(hubActionsImage, localHubEndpoint, publicHubEndpoint)
+
+	interfaceIOValidator := actionvalidation.NewValidator(fakeCli)
+	policyIOValidator := policyvalidation.NewValidator(fakeCli)
+	wfValidator := renderer.NewWorkflowInputValidator(interfaceIOValidator, policyIOValidator)
+
+	argoRenderer := NewRenderer(logger.Noop(), renderer.Config{
+		RenderTimeout: time.Second,
+		MaxDepth:      3,
+	}, fakeCli, typeInstanceHandler, wfValidator)
+
+	interfaceRef := types.InterfaceRef{
+		Path: "cap.interface.not.existing",
+	}
+
+	// when
+	renderOutput, err := argoRenderer.Render(
+		context.Background(),
+		&RenderInput{
+			RunnerContextSecretRef: RunnerContextSecretRef{Name: "secret", Key: "key"},
+			InterfaceRef:           interfaceRef,
+			Options: []RendererOption{
+				WithGlobalPolicy(policy.NewAllowAll()),
+			},
+		},
+	)
+
+	// then
+	assert.EqualError(t, err, `Interface "cap.interface.not.existing:" not found in Hub`)
+	assertPermanentErrorReason(t, err, renderer.InterfaceNotFoundReason)
 	assert.Nil(t, renderOutput)
 }
 
+func assertPermanentErrorReason(t *testing.T, err error, expReason renderer.PermanentErrorReason) {
+	t.Helper()
+
+	permErr, ok := renderer.AsPermanentError(err)
+	require.True(t, ok, "expected permanent error, got %v", err)
+	assert.Equal(t, expReason, permErr.Reason)
+}
+
 func assertYAMLGoldenFile(t *testing.T, actualYAMLData interface{}, filename string, msgAndArgs ...interface{}) {
 	t.Helper()
 
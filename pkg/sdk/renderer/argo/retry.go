@@ -0,0 +1,70 @@
+package argo
+
+import (
+	"capact.io/capact/pkg/engine/k8s/policy"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var retryOnToArgoRetryPolicy = map[policy.RetryOn]wfv1.RetryPolicy{
+	policy.AlwaysRetryOn:           wfv1.RetryPolicyAlways,
+	policy.OnFailureRetryOn:        wfv1.RetryPolicyOnFailure,
+	policy.OnErrorRetryOn:          wfv1.RetryPolicyOnError,
+	policy.OnTransientErrorRetryOn: wfv1.RetryPolicyOnTransientError,
+}
+
+// injectRetryStrategy sets the retry strategy based on a given retry policy for all workflow templates,
+// which execute a container, script or Kubernetes resource.
+// Templates, which already define their own retry strategy, are not modified.
+func injectRetryStrategy(workflow *Workflow, retry *policy.RetryPolicy) error {
+	if workflow == nil || retry == nil || retry.Limit == 0 {
+		return nil
+	}
+
+	strategy, err := toArgoRetryStrategy(retry)
+	if err != nil {
+		return err
+	}
+
+	for _, tpl := range workflow.Templates {
+		if tpl == nil || tpl.Template == nil || tpl.RetryStrategy != nil {
+			continue
+		}
+		if tpl.Container == nil && tpl.Script == nil && tpl.Resource == nil {
+			continue
+		}
+
+		tpl.RetryStrategy = strategy.DeepCopy()
+	}
+
+	return nil
+}
+
+func toArgoRetryStrategy(retry *policy.RetryPolicy) (*wfv1.RetryStrategy, error) {
+	if err := retry.Validate(); err != nil {
+		return nil, errors.Wrap(err, "while validating retry policy")
+	}
+
+	limit := intstr.FromInt(retry.Limit)
+	strategy := &wfv1.RetryStrategy{
+		Limit:       &limit,
+		RetryPolicy: retryOnToArgoRetryPolicy[retry.RetryOnOrDefault()],
+	}
+
+	if retry.Backoff != nil {
+		strategy.Backoff = &wfv1.Backoff{
+			Duration: retry.Backoff.Duration,
+		}
+		if retry.Backoff.Factor != nil {
+			factor := intstr.FromInt(*retry.Backoff.Factor)
+			strategy.Backoff.Factor = &factor
+		}
+		if retry.Backoff.MaxDuration != nil {
+			strategy.Backoff.MaxDuration = *retry.Backoff.MaxDuration
+		}
+	}
+
+	return strategy, nil
+}
@@ -0,0 +1,101 @@
+package argo
+
+import (
+	"testing"
+
+	"capact.io/capact/internal/ptr"
+	"capact.io/capact/pkg/engine/k8s/policy"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestInjectRetryStrategy(t *testing.T) {
+	// given
+	ownLimit := intstr.FromInt(1)
+	ownStrategy := &wfv1.RetryStrategy{Limit: &ownLimit}
+
+	workflow := &Workflow{
+		WorkflowSpec: &wfv1.WorkflowSpec{},
+		Templates: []*Template{
+			{Template: &wfv1.Template{Name: "steps"}},
+			{Template: &wfv1.Template{Name: "container", Container: &apiv1.Container{Image: "alpine"}}},
+			{Template: &wfv1.Template{Name: "script", Script: &wfv1.ScriptTemplate{Source: "echo"}}},
+			{Template: &wfv1.Template{Name: "own-strategy", Container: &apiv1.Container{Image: "alpine"}, RetryStrategy: ownStrategy}},
+		},
+	}
+
+	retry := &policy.RetryPolicy{
+		Limit:   3,
+		RetryOn: policy.OnTransientErrorRetryOn,
+		Backoff: &policy.RetryBackoff{
+			Duration:    "30s",
+			Factor:      ptr.Int(2),
+			MaxDuration: ptr.String("1h"),
+		},
+	}
+
+	limit, factor := intstr.FromInt(3), intstr.FromInt(2)
+	expectedStrategy := &wfv1.RetryStrategy{
+		Limit:       &limit,
+		RetryPolicy: wfv1.RetryPolicyOnTransientError,
+		Backoff: &wfv1.Backoff{
+			Duration:    "30s",
+			Factor:      &factor,
+			MaxDuration: "1h",
+		},
+	}
+
+	// when
+	err := injectRetryStrategy(workflow, retry)
+
+	// then
+	require.NoError(t, err)
+	assert.Nil(t, workflow.Templates[0].RetryStrategy)
+	assert.Equal(t, expectedStrategy, workflow.Templates[1].RetryStrategy)
+	assert.Equal(t, expectedStrategy, workflow.Templates[2].RetryStrategy)
+	assert.Equal(t, ownStrategy, workflow.Templates[3].RetryStrategy)
+}
+
+func TestInjectRetryStrategyErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		retry       *policy.RetryPolicy
+		expectedErr string
+	}{
+		{
+			name:        "Negative limit",
+			retry:       &policy.RetryPolicy{Limit: -1},
+			expectedErr: "while validating retry policy: retry limit must not be negative, got -1",
+		},
+		{
+			name:        "Unknown retry classification",
+			retry:       &policy.RetryPolicy{Limit: 1, RetryOn: "SOMETIMES"},
+			expectedErr: `while validating retry policy: unknown retry classification "SOMETIMES"`,
+		},
+		{
+			name:        "Invalid backoff duration",
+			retry:       &policy.RetryPolicy{Limit: 1, Backoff: &policy.RetryBackoff{Duration: "soon"}},
+			expectedErr: `while validating retry policy: while parsing retry backoff duration "soon": time: invalid duration "soon"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			workflow := &Workflow{
+				Templates: []*Template{
+					{Template: &wfv1.Template{Name: "container", Container: &apiv1.Container{Image: "alpine"}}},
+				},
+			}
+
+			// when
+			err := injectRetryStrategy(workflow, tt.retry)
+
+			// then
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -76,29 +76,60 @@ type RenderInput struct {
 type RenderOutput struct {
 	Action              *types.Action
 	TypeInstancesToLock []string
+
+	// RunnerInterface refers to the runner Interface revision imported by the root Implementation.
+	// The Action RunnerInterface holds only its path.
+	RunnerInterface types.ManifestRef
+
+	// RenderingIteration is set only in the advanced rendering mode, if the rendering was stopped
+	// and waits for user approval. In such case, Action and TypeInstancesToLock are empty.
+	RenderingIteration *RenderingIteration
+
+	// ImplementationSelections describes how Implementations were selected during rendering.
+	ImplementationSelections []ImplementationSelection
 }
 
-var workflowArtifactRefRegex = regexp.MustCompile(`{{workflow\.outputs\.artifacts\.(.+)}}`)
+// ImplementationSelection describes how the Implementation was selected for a given Interface.
+type ImplementationSelection struct {
+	// Name is the name of the workflow step, which imports the Interface.
+	// For the root Interface, it is the root step name.
+	Name string
+	// Interface refers to the Interface manifest.
+	Interface types.ManifestRef
+	// Strategy is the Implementation selection strategy.
+	Strategy policy.ImplementationSelectionStrategy
+	// Selected refers to the selected Implementation.
+	Selected types.ManifestRef
+	// Discarded contains Implementations, which matched the Policy rule, but were not selected.
+	Discarded []DiscardedImplementation
+}
 
-type mapEvalParameters struct {
-	items        map[string]interface{}
-	lastAccessed string
+// DiscardedImplementation describes the Implementation, which was not selected.
+type DiscardedImplementation struct {
+	Implementation types.ManifestRef
+	Reason         string
 }
 
-func (p *mapEvalParameters) Set(name string) {
-	if p.items == nil {
-		p.items = map[string]interface{}{}
-	}
+// RenderingIteration holds details about the advanced rendering iteration, which waits for user approval.
+type RenderingIteration struct {
+	// Name is a unique name of a given rendering iteration.
+	Name string
+	// InputTypeInstancesToProvide describes which optional input TypeInstances might be provided in a given rendering iteration.
+	InputTypeInstancesToProvide []InputTypeInstanceToProvide
+}
 
-	p.items[name] = name
+// InputTypeInstanceToProvide describes optional input TypeInstance for advanced rendering mode iteration.
+type InputTypeInstanceToProvide struct {
+	Name    string
+	TypeRef types.TypeRef
 }
 
-func (p *mapEvalParameters) Get(name string) (interface{}, error) {
-	value, found := p.items[name]
-	if !found {
-		return nil, nil
-	}
+var workflowArtifactRefRegex = regexp.MustCompile(`{{workflow\.outputs\.artifacts\.(.+)}}`)
+
+var stepArtifactRefRegex = regexp.MustCompile(`{{steps\.([^.]+)\.outputs\.artifacts\..+}}`)
 
-	p.lastAccessed = name
-	return value, nil
+// stepInput is an input argument or input TypeInstance, which satisfied a step with `capact-when` statement.
+type stepInput struct {
+	name           string
+	isTypeInstance bool
 }
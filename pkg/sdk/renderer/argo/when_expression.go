@@ -0,0 +1,272 @@
+package argo
+
+import (
+	"strings"
+
+	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/ast"
+	"github.com/antonmedv/expr/parser"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// parametersPathRoot is the first segment of a path, which refers to the user input parameters,
+	// e.g. `parameters.input-parameters.db.enabled == true`.
+	parametersPathRoot = "parameters"
+
+	isDefinedFn    = "isDefined"
+	isNotDefinedFn = "isNotDefined"
+)
+
+// whenEvalContext holds data available for `capact-when` statement.
+type whenEvalContext struct {
+	// definedArtifacts holds names of artifacts available for a given step.
+	definedArtifacts map[string]struct{}
+	// parameters holds user input parameters indexed by the parameter name.
+	parameters map[string]string
+}
+
+// whenResult is a result of `capact-when` statement evaluation.
+type whenResult struct {
+	value bool
+	// satisfiedBy holds names of defined artifacts, which made the statement false, in order of their occurrence in statement.
+	satisfiedBy []string
+}
+
+// evaluateWhenExpression evaluates a given `capact-when` statement.
+//
+// Supported syntax:
+//   - `foo == nil`, `foo != nil`
+//   - `isDefined(foo,bar,baz)` - true if all artifacts are defined
+//   - `isNotDefined(foo,bar,baz)` - true if none of the artifacts is defined
+//   - `parameters.<parameter name>.<field path>` - user input parameter value
+//   - all operators supported by expr, e.g. `==`, `>=`, `&&`, `||`, `!` and parentheses
+//
+// Dashes are treated as a part of the artifact and parameter names.
+//
+// If the statement is false, the result contains the defined artifacts, which are used in a negated condition,
+// e.g. `postgresql == nil`, `isNotDefined(postgresql)` or `!isDefined(postgresql)`.
+// For example, for `isNotDefined(postgresql, app-config)` it holds both artifacts, if both are defined,
+// but it is empty for `isDefined(postgresql, app-config)`, as the statement is false because of a missing artifact.
+func evaluateWhenExpression(ctx whenEvalContext, exprString string) (whenResult, error) {
+	tree, err := parser.Parse(exprString)
+	if err != nil {
+		return whenResult{}, errors.Wrap(err, "while parsing expression")
+	}
+	ast.Walk(&tree.Node, &whenPathPatcher{})
+
+	refs := &whenReferences{}
+	ast.Walk(&tree.Node, refs)
+	if refs.err != nil {
+		return whenResult{}, errors.Wrap(refs.err, "while parsing expression")
+	}
+
+	env, err := ctx.env(refs.names)
+	if err != nil {
+		return whenResult{}, errors.Wrap(err, "while evaluating expression")
+	}
+
+	program, err := expr.Compile(exprString, expr.Patch(&whenPathPatcher{}))
+	if err != nil {
+		return whenResult{}, errors.Wrap(err, "while parsing expression")
+	}
+
+	out, err := expr.Run(program, env)
+	if err != nil {
+		return whenResult{}, errors.Wrap(err, "while evaluating expression")
+	}
+
+	value, ok := out.(bool)
+	if !ok {
+		return whenResult{}, errors.Errorf("while evaluating expression: statement must evaluate to a boolean value, got %v", out)
+	}
+
+	if value {
+		return whenResult{value: true}, nil
+	}
+
+	return whenResult{value: false, satisfiedBy: ctx.falsifyingArtifacts(tree.Node, false, nil)}, nil
+}
+
+// env returns values of the referenced artifacts and parameters, together with the `capact-when` functions.
+// Defined artifacts resolve to their names, not defined ones resolve to nil.
+func (c whenEvalContext) env(names []string) (map[string]interface{}, error) {
+	env := map[string]interface{}{
+		isDefinedFn: func(args ...interface{}) bool {
+			for _, arg := range args {
+				if arg == nil {
+					return false
+				}
+			}
+			return true
+		},
+		isNotDefinedFn: func(args ...interface{}) bool {
+			for _, arg := range args {
+				if arg != nil {
+					return false
+				}
+			}
+			return true
+		},
+	}
+
+	for _, name := range names {
+		if strings.HasPrefix(name, parametersPathRoot+".") {
+			value, err := c.parameterValue(strings.Split(name, ".")[1:])
+			if err != nil {
+				return nil, err
+			}
+			env[name] = value
+			continue
+		}
+
+		env[name] = nil
+		if c.isDefined(name) {
+			env[name] = name
+		}
+	}
+
+	return env, nil
+}
+
+func (c whenEvalContext) parameterValue(path []string) (interface{}, error) {
+	raw, found := c.parameters[path[0]]
+	if !found {
+		return nil, nil
+	}
+
+	var current interface{}
+	if err := yaml.Unmarshal([]byte(raw), &current); err != nil {
+		return nil, errors.Wrapf(err, "while unmarshaling %q input parameters", path[0])
+	}
+
+	for _, field := range path[1:] {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		current = obj[field]
+	}
+
+	return current, nil
+}
+
+func (c whenEvalContext) isDefined(name string) bool {
+	_, defined := c.definedArtifacts[name]
+	return defined
+}
+
+// falsifyingArtifacts appends to out the defined artifacts, which make a given node false.
+// The negated flag is set, if the node is used in a negated condition.
+func (c whenEvalContext) falsifyingArtifacts(node ast.Node, negated bool, out []string) []string {
+	appendIfFalsifying := func(arg ast.Node, definedMeansFalse bool) {
+		ident, ok := arg.(*ast.IdentifierNode)
+		if !ok || !c.isDefined(ident.Value) || containsString(out, ident.Value) {
+			return
+		}
+		if definedMeansFalse != negated {
+			out = append(out, ident.Value)
+		}
+	}
+
+	switch n := node.(type) {
+	case *ast.UnaryNode:
+		if n.Operator == "!" || n.Operator == "not" {
+			return c.falsifyingArtifacts(n.Node, !negated, out)
+		}
+	case *ast.BinaryNode:
+		switch n.Operator {
+		case "&&", "and", "||", "or":
+			out = c.falsifyingArtifacts(n.Left, negated, out)
+			return c.falsifyingArtifacts(n.Right, negated, out)
+		case "==", "!=":
+			if _, ok := n.Right.(*ast.NilNode); ok {
+				appendIfFalsifying(n.Left, n.Operator == "==")
+			}
+			if _, ok := n.Left.(*ast.NilNode); ok {
+				appendIfFalsifying(n.Right, n.Operator == "==")
+			}
+		}
+	case *ast.FunctionNode:
+		for _, arg := range n.Arguments {
+			appendIfFalsifying(arg, n.Name == isNotDefinedFn)
+		}
+	}
+
+	return out
+}
+
+// whenPathPatcher joins artifact names and parameter paths, which are split by the expr parser, into single identifiers.
+// For example, `app-config` is parsed as a subtraction and `parameters.input-parameters.db` as a subtraction
+// of property accesses, but both are patched to identifiers named as in the statement.
+type whenPathPatcher struct{}
+
+func (p *whenPathPatcher) Enter(_ *ast.Node) {}
+
+func (p *whenPathPatcher) Exit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.PropertyNode:
+		if ident, ok := n.Node.(*ast.IdentifierNode); ok {
+			*node = &ast.IdentifierNode{Value: ident.Value + "." + n.Property}
+		}
+	case *ast.BinaryNode:
+		if n.Operator != "-" {
+			return
+		}
+		left, leftOK := n.Left.(*ast.IdentifierNode)
+		right, rightOK := n.Right.(*ast.IdentifierNode)
+		if leftOK && rightOK {
+			*node = &ast.IdentifierNode{Value: left.Value + "-" + right.Value}
+		}
+	}
+}
+
+// whenReferences collects names of artifacts and parameter paths used in a given statement, in order of their occurrence.
+type whenReferences struct {
+	names []string
+	err   error
+}
+
+func (r *whenReferences) Enter(node *ast.Node) {
+	if r.err != nil {
+		return
+	}
+
+	switch n := (*node).(type) {
+	case *ast.IdentifierNode:
+		if strings.Contains(n.Value, ".") && !strings.HasPrefix(n.Value, parametersPathRoot+".") {
+			r.err = errors.Errorf("unknown path %q, only %q paths are supported", n.Value, parametersPathRoot)
+			return
+		}
+		if !containsString(r.names, n.Value) {
+			r.names = append(r.names, n.Value)
+		}
+	case *ast.FunctionNode:
+		if n.Name != isDefinedFn && n.Name != isNotDefinedFn {
+			r.err = errors.Errorf("unknown function %q, only %q and %q are supported", n.Name, isDefinedFn, isNotDefinedFn)
+			return
+		}
+		if len(n.Arguments) == 0 {
+			r.err = errors.New("functions require at least one artifact name")
+			return
+		}
+		for _, arg := range n.Arguments {
+			if _, ok := arg.(*ast.IdentifierNode); !ok {
+				r.err = errors.Errorf("function %q accepts only artifact names", n.Name)
+				return
+			}
+		}
+	}
+}
+
+func (r *whenReferences) Exit(_ *ast.Node) {}
+
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package argo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateWhenExpression(t *testing.T) {
+	// given
+	evalCtx := whenEvalContext{
+		definedArtifacts: map[string]struct{}{
+			"postgresql": {},
+			"app-config": {},
+		},
+		parameters: map[string]string{
+			"input-parameters": `{"replicas": 3, "db": {"engine": "postgres", "create": true}}`,
+		},
+	}
+
+	tests := []struct {
+		name                string
+		expr                string
+		expectedValue       bool
+		expectedSatisfiedBy []string
+		expectedErr         string
+	}{
+		{
+			name:                "Defined artifact compared with nil",
+			expr:                "postgresql == nil",
+			expectedValue:       false,
+			expectedSatisfiedBy: []string{"postgresql"},
+		},
+		{
+			name:          "Not defined artifact compared with nil",
+			expr:          "mysql == nil",
+			expectedValue: true,
+		},
+		{
+			name:          "Nil compared with defined artifact",
+			expr:          "nil != postgresql",
+			expectedValue: true,
+		},
+		{
+			name:                "isNotDefined with one of the artifacts defined",
+			expr:                "isNotDefined(mysql, app-config)",
+			expectedValue:       false,
+			expectedSatisfiedBy: []string{"app-config"},
+		},
+		{
+			name:                "isNotDefined with all artifacts defined",
+			expr:                "isNotDefined(postgresql, app-config)",
+			expectedValue:       false,
+			expectedSatisfiedBy: []string{"postgresql", "app-config"},
+		},
+		{
+			name:          "isDefined with missing artifact",
+			expr:          "isDefined(postgresql, mysql)",
+			expectedValue: false,
+		},
+		{
+			name:                "Negated isDefined",
+			expr:                "!isDefined(postgresql)",
+			expectedValue:       false,
+			expectedSatisfiedBy: []string{"postgresql"},
+		},
+		{
+			name:                "Legacy form with both artifacts",
+			expr:                "postgresql == nil && app-config == nil",
+			expectedValue:       false,
+			expectedSatisfiedBy: []string{"postgresql", "app-config"},
+		},
+		{
+			name:                "Conjunction with only one artifact making it false",
+			expr:                "isNotDefined(postgresql) && isDefined(app-config)",
+			expectedValue:       false,
+			expectedSatisfiedBy: []string{"postgresql"},
+		},
+		{
+			name:          "Conjunction false because of missing artifact",
+			expr:          "postgresql != nil && isDefined(mysql)",
+			expectedValue: false,
+		},
+		{
+			name:                "Disjunction of false statements",
+			expr:                "isNotDefined(postgresql) || (app-config == nil)",
+			expectedValue:       false,
+			expectedSatisfiedBy: []string{"postgresql", "app-config"},
+		},
+		{
+			name:          "Input parameter with nested field",
+			expr:          `parameters.input-parameters.db.engine == "postgres" && parameters.input-parameters.replicas >= 2`,
+			expectedValue: true,
+		},
+		{
+			name:          "Input parameter used as boolean",
+			expr:          "!parameters.input-parameters.db.create",
+			expectedValue: false,
+		},
+		{
+			name:          "Missing input parameter",
+			expr:          "parameters.additional-parameters.foo == nil",
+			expectedValue: true,
+		},
+		{
+			name:                "Input parameter combined with artifacts",
+			expr:                "parameters.input-parameters.db.create == true && isNotDefined(postgresql)",
+			expectedValue:       false,
+			expectedSatisfiedBy: []string{"postgresql"},
+		},
+		{
+			name:        "Unknown path",
+			expr:        "inputs.foo == nil",
+			expectedErr: `while parsing expression: unknown path "inputs.foo", only "parameters" paths are supported`,
+		},
+		{
+			name:        "Function without artifacts",
+			expr:        "isDefined()",
+			expectedErr: `while parsing expression: functions require at least one artifact name`,
+		},
+		{
+			name:        "Unknown function",
+			expr:        "isEmpty(postgresql)",
+			expectedErr: `while parsing expression: unknown function "isEmpty", only "isDefined" and "isNotDefined" are supported`,
+		},
+		{
+			name:        "Non-boolean value",
+			expr:        "parameters.input-parameters.replicas",
+			expectedErr: `while evaluating expression: statement must evaluate to a boolean value, got 3`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			result, err := evaluateWhenExpression(evalCtx, tt.expr)
+
+			// then
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedValue, result.value)
+			assert.Equal(t, tt.expectedSatisfiedBy, result.satisfiedBy)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PermanentErrorReason describes the reason of a permanent error.
+type PermanentErrorReason string
+
+// List of possible permanent error reasons.
+const (
+	// InterfaceNotFoundReason indicates that a given Interface does not exist in Hub.
+	InterfaceNotFoundReason PermanentErrorReason = "InterfaceNotFound"
+	// ImplementationNotFoundReason indicates that no Implementation satisfies the Policy.
+	ImplementationNotFoundReason PermanentErrorReason = "ImplementationNotFound"
+	// InvalidInputReason indicates that the input parameters or TypeInstances are invalid.
+	InvalidInputReason PermanentErrorReason = "InvalidInput"
+	// InvalidPolicyReason indicates that the Policy is invalid.
+	InvalidPolicyReason PermanentErrorReason = "InvalidPolicy"
+	// InvalidManifestReason indicates that the Hub manifests cannot be rendered.
+	InvalidManifestReason PermanentErrorReason = "InvalidManifest"
+	// UnsupportedRunnerReason indicates that the selected runner is not supported.
+	UnsupportedRunnerReason PermanentErrorReason = "UnsupportedRunner"
+)
+
+// PermanentError indicates that an operation cannot succeed without changing its input,
+// so it must not be retried.
+type PermanentError struct {
+	Reason PermanentErrorReason
+	Err    error
+}
+
+// NewPermanentError returns a new PermanentError instance.
+func NewPermanentError(reason PermanentErrorReason, err error) *PermanentError {
+	return &PermanentError{
+		Reason: reason,
+		Err:    err,
+	}
+}
+
+// Error returns the error message.
+func (e *PermanentError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("permanent error: %s", e.Reason)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// AsPermanentError finds the first PermanentError in the err chain.
+// Returns false if the err is transient.
+func AsPermanentError(err error) (*PermanentError, bool) {
+	var permErr *PermanentError
+	if errors.As(err, &permErr) {
+		return permErr, true
+	}
+	return nil, false
+}
@@ -67,7 +67,11 @@ func (w *WorkflowInputValidator) ValidateInterfaceInput(ctx context.Context, in
 		return errors.Wrap(err, "while validating TypeInstances")
 	}
 
-	return rs.ErrorOrNil()
+	if err := rs.ErrorOrNil(); err != nil {
+		return NewPermanentError(InvalidInputReason, err)
+	}
+
+	return nil
 }
 
 // PolicyValidator returns Policy-related validator for a given workflow.